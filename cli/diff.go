@@ -0,0 +1,299 @@
+/*
+File: diff.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-21 14:06:51
+
+Description: 子命令 'diff' 的实现
+
+- 逐部分比较两个快照，输出新增、移除和变更的输出项
+- 多设备部分按 DeviceKeyer 指定的输出项匹配设备，未指定时按设备顺序匹配
+*/
+
+package cli
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/gookit/color"
+	"github.com/yhyj/eniac/general"
+)
+
+// 输出项的变化类型
+const (
+	diffAdded   = "Added"   // 新增
+	diffRemoved = "Removed" // 移除
+	diffChanged = "Changed" // 变更
+)
+
+// diffEntry 一个输出项的变化
+type diffEntry struct {
+	Device string // 设备标识，单设备部分为空
+	Change string // 变化类型
+	Item   string // 输出项
+	Old    string // 旧值
+	New    string // 新值
+}
+
+// DiffSnapshots 比较两个快照并逐部分输出变化
+//
+// 参数：
+//   - config: 解析 toml 配置文件得到的配置项
+//   - oldFile: 旧快照文件路径
+//   - newFile: 新快照文件路径，为空时与当前系统比较
+//   - volatile: 是否比较随时间变化的输出项（如负载、已用内存、运行时间）
+func DiffSnapshots(config *general.Config, oldFile, newFile string, volatile bool) {
+	oldSnapshot, err := readSnapshot(oldFile)
+	if err != nil {
+		fileName, lineNo := general.GetCallerInfo()
+		color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+		return
+	}
+	var newSnapshot Snapshot
+	if newFile == "" {
+		newFile = "current system"
+		newSnapshot, err = collectSnapshot(config)
+	} else {
+		newSnapshot, err = readSnapshot(newFile)
+	}
+	if err != nil {
+		fileName, lineNo := general.GetCallerInfo()
+		color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+		return
+	}
+
+	color.Printf("%s %s (%s) %s %s (%s)\n", general.Separator1st, general.PrimaryText(oldFile), general.UnixTime2TimeString(oldSnapshot.Created), general.Separator2st, general.PrimaryText(newFile), general.UnixTime2TimeString(newSnapshot.Created))
+
+	names := snapshotSectionNames(oldSnapshot, newSnapshot)
+
+	// 获取随机颜色切片
+	if config.Main.Colorful {
+		colors = general.GetColor(len(names) * 2) // 因为分奇数/偶数行，所以要乘2
+	}
+
+	changed := false
+	for _, name := range names {
+		section := LookupSection(name)
+		entries, err := diffSnapshotSection(section, oldSnapshot.Sections[name], newSnapshot.Sections[name], volatile)
+		if err != nil {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), color.Sprintf("section '%s': %s", name, err))
+			continue
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		changed = true
+
+		// 组装表
+		part := name
+		if section != nil {
+			part = partName(section)
+		}
+		tableHeader = []string{"", diffName("Change"), diffName("Item"), diffName("Old"), diffName("New")} // 表头
+		tableData = [][]string{}                                                                           // 表数据
+		for _, entry := range entries {
+			label := part
+			if entry.Device != "" {
+				label = part + " " + entry.Device
+			}
+			rowData = []string{label, diffName(entry.Change), itemName(entry.Item), entry.Old, entry.New} // 行数据
+			tableData = append(tableData, rowData)
+		}
+
+		oddColor, evenColor := nextRowColors()
+		dataTable = newDataTable(oddColor, evenColor, map[int]bool{}, true)
+		dataTable.Headers(tableHeader...) // 设置表头
+		dataTable.Rows(tableData...)      // 设置单元格
+
+		color.Println(dataTable)
+	}
+
+	if !changed {
+		color.Printf("%s\n", general.SuccessText("No differences"))
+	}
+}
+
+// snapshotSectionNames 获取两个快照中所有部分的参数名
+//
+// 参数：
+//   - snapshots: 快照
+//
+// 返回：
+//   - 参数名，已注册的部分按注册顺序在前，其他部分（如其他平台的快照中的部分）按名称排序在后
+func snapshotSectionNames(snapshots ...Snapshot) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, section := range Sections() {
+		names = append(names, section.Name())
+		seen[section.Name()] = true
+	}
+
+	var others []string
+	for _, snapshot := range snapshots {
+		for name := range snapshot.Sections {
+			if !seen[name] {
+				others = append(others, name)
+				seen[name] = true
+			}
+		}
+	}
+	sort.Strings(others)
+
+	return append(names, others...)
+}
+
+// diffSnapshotSection 比较快照中一个部分的原始数据
+//
+// 参数：
+//   - section: 部分，未注册时为 nil
+//   - oldRaw: 旧快照中该部分的原始数据，不存在时为 nil
+//   - newRaw: 新快照中该部分的原始数据，不存在时为 nil
+//   - volatile: 是否比较随时间变化的输出项
+//
+// 返回：
+//   - 变化的输出项，按设备和输出项名排序
+//   - 错误信息
+func diffSnapshotSection(section Section, oldRaw, newRaw json.RawMessage, volatile bool) ([]diffEntry, error) {
+	oldDevices, err := flattenSnapshotSection(oldRaw)
+	if err != nil {
+		return nil, err
+	}
+	newDevices, err := flattenSnapshotSection(newRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	// 需要忽略的输出项
+	ignored := make(map[string]bool)
+	if volatileSection, ok := section.(Volatile); ok && !volatile {
+		for _, item := range volatileSection.VolatileItems() {
+			ignored[item] = true
+		}
+	}
+
+	// 设备标识
+	multiple := isSnapshotDevices(oldRaw) || isSnapshotDevices(newRaw)
+	deviceKey := ""
+	if keyer, ok := section.(DeviceKeyer); ok {
+		deviceKey = keyer.DeviceKey()
+	}
+	oldIDs, oldByID := indexSnapshotDevices(oldDevices, deviceKey, multiple)
+	newIDs, newByID := indexSnapshotDevices(newDevices, deviceKey, multiple)
+
+	// 先按旧快照的设备顺序，再补充新快照独有的设备
+	deviceIDs := oldIDs
+	for _, id := range newIDs {
+		if _, ok := oldByID[id]; !ok {
+			deviceIDs = append(deviceIDs, id)
+		}
+	}
+
+	var entries []diffEntry
+	for _, id := range deviceIDs {
+		oldDevice := oldByID[id]
+		newDevice := newByID[id]
+
+		items := make(map[string]bool)
+		for item := range oldDevice {
+			items[item] = true
+		}
+		for item := range newDevice {
+			items[item] = true
+		}
+		sortedItems := make([]string, 0, len(items))
+		for item := range items {
+			if !ignored[item] {
+				sortedItems = append(sortedItems, item)
+			}
+		}
+		sort.Strings(sortedItems)
+
+		for _, item := range sortedItems {
+			oldValue, oldOK := oldDevice[item]
+			newValue, newOK := newDevice[item]
+			entry := diffEntry{Device: id, Item: item, Old: "--/--", New: "--/--"}
+			switch {
+			case !oldOK && newOK:
+				entry.Change, entry.New = diffAdded, newValue
+			case oldOK && !newOK:
+				entry.Change, entry.Old = diffRemoved, oldValue
+			case oldValue != newValue:
+				entry.Change, entry.Old, entry.New = diffChanged, oldValue, newValue
+			default:
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// isSnapshotDevices 判断快照中一个部分的原始数据是否为多设备数据
+//
+// 参数：
+//   - raw: 该部分的原始数据
+//
+// 返回：
+//   - 是多设备数据返回 true，否则返回 false
+func isSnapshotDevices(raw json.RawMessage) bool {
+	for _, c := range raw {
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// indexSnapshotDevices 为设备生成标识
+//
+// 参数：
+//   - devices: 设备列表
+//   - deviceKey: 标识设备的输出项，为空或设备缺少该项时以序号标识
+//   - multiple: 是否为多设备部分，单设备部分的标识为空
+//
+// 返回：
+//   - 按设备顺序排列的标识
+//   - 以标识为键的设备
+func indexSnapshotDevices(devices []map[string]string, deviceKey string, multiple bool) ([]string, map[string]map[string]string) {
+	ids := make([]string, 0, len(devices))
+	byID := make(map[string]map[string]string, len(devices))
+	for index, device := range devices {
+		id := ""
+		if multiple {
+			id = device[deviceKey]
+			if deviceKey == "" || id == "" {
+				id = "#" + strconv.Itoa(index+1)
+			}
+		}
+		if _, ok := byID[id]; ok {
+			id = id + "#" + strconv.Itoa(index+1) // 标识重复时追加序号
+		}
+		ids = append(ids, id)
+		byID[id] = device
+	}
+	return ids, byID
+}
+
+// diffName 获取比较结果表头或变化类型的 i18n 名称
+//
+// 参数：
+//   - key: 表头或变化类型
+//
+// 返回：
+//   - i18n 名称，未定义时为 key 本身
+func diffName(key string) string {
+	name := general.DiffName[key][general.Language]
+	if name == "" {
+		name = key
+	}
+	return name
+}
@@ -10,19 +10,25 @@ Description: 子命令 'get' 的实现
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
+	"github.com/gookit/color"
 	"github.com/yhyj/eniac/general"
 	"github.com/zcalusic/sysinfo"
 )
 
 // 表格参数
 var (
-	items        []string                                     // 输出项名称
-	oddRowColor  = general.DefaultColor                       // 奇数行颜色
-	evenRowColor = general.DefaultColor                       // 偶数行颜色
-	oddRowStyle  = general.CellStyle.Foreground(oddRowColor)  // 奇数行样式
-	evenRowStyle = general.CellStyle.Foreground(evenRowColor) // 偶数行样式
+	items        []string               // 输出项名称
+	oddRowColor  = general.DefaultColor // 奇数行颜色
+	evenRowColor = general.DefaultColor // 偶数行颜色
 
 	dataTable   *table.Table // 创建一个表格
 	tableHeader []string     // 表头
@@ -34,3 +40,393 @@ var (
 
 // 采集系统信息
 var sysInfo sysinfo.SysInfo
+
+// GrabInformationToTable 抓取信息，各种信息分别输出为表格
+//
+// 参数：
+//   - config: 解析 toml 配置文件得到的配置项
+//   - flags: 系统信息各部分的开关，键为部分的参数名加 'Flag'
+func GrabInformationToTable(config *general.Config, flags map[string]bool) {
+	// 系统信息分配到不同的参数
+	sysInfo.GetSysInfo()
+
+	// 计算有多少个 Flag 要显示
+	viewQuantity := general.MapBoolCounter(flags, true)
+
+	// 获取随机颜色切片
+	if config.Main.Colorful {
+		colors = general.GetColor(viewQuantity * 2) // 因为分奇数/偶数行，所以要乘2
+	}
+
+	for _, section := range Sections() {
+		if !flags[section.Name()+"Flag"] {
+			continue
+		}
+
+		data, _ := section.Collect(config) // 原始数据，抓取出错时仍输出已抓取到的部分
+
+		// 仅输出纯文本
+		if printer, ok := section.(PlainPrinter); ok && flags["onlyFlag"] {
+			printer.PrintPlain(config, data)
+			continue
+		}
+
+		items = section.Items(config, data) // 原始表头
+
+		// 未配置表头时不显示该项，发送通知
+		if len(items) == 0 {
+			general.Notify(section.Part() + " items is empty")
+			continue
+		}
+
+		// 组装表
+		part := partName(section)
+		multiple := isDevices(data)
+		tableHeader = []string{""} // 表头
+		tableData = [][]string{}   // 表数据
+		for _, item := range items {
+			tableHeader = append(tableHeader, itemName(item))
+		}
+		rows := section.Render(config, data)
+		if len(rows) == 0 {
+			// 没有设备时显示一个空行
+			rows = []map[string]string{emptyRow(items)}
+			multiple = false
+		}
+		for index, info := range rows {
+			rowData = []string{part} // 行数据
+			if multiple {
+				rowData = []string{part + strconv.Itoa(index+1)}
+			}
+			for _, item := range items {
+				rowData = append(rowData, info[item])
+			}
+			tableData = append(tableData, rowData)
+		}
+
+		oddColor, evenColor := nextRowColors()
+		dataTable = newDataTable(oddColor, evenColor, leftAlignedColumns(section, items, 1), true)
+		dataTable.Headers(tableHeader...) // 设置表头
+		dataTable.Rows(tableData...)      // 设置单元格
+
+		color.Println(dataTable)
+	}
+}
+
+// GrabInformationToTab 抓取信息，各种信息通过标签交互展示
+//
+// 参数：
+//   - config: 解析 toml 配置文件得到的配置项
+func GrabInformationToTab(config *general.Config) {
+	// Tab 参数
+	var (
+		tabName     []string // 标签名称
+		tabContents []string // 标签内容
+	)
+
+	// 系统信息分配到不同的参数
+	sysInfo.GetSysInfo()
+
+	// 获取随机颜色切片
+	if config.Main.Colorful {
+		colors = general.GetColor(len(Sections()) * 2) // 因为分奇数/偶数行，所以要乘2
+	}
+
+	viewRows := tabViewRows()
+
+	for _, section := range Sections() {
+		data, _ := section.Collect(config) // 原始数据，抓取出错时仍输出已抓取到的部分
+
+		oddColor, evenColor := nextRowColors()
+		if content, ok := renderTabContent(config, section, data, viewRows, oddColor, evenColor); ok {
+			tabName = append(tabName, partName(section))
+			tabContents = append(tabContents, content)
+		}
+	}
+
+	// 输出 Tab
+	if err := general.TabSelector(tabName, tabContents, config.Main.Cycle); err != nil {
+		fileName, lineNo := general.GetCallerInfo()
+		color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+	}
+}
+
+// GrabInformationToJSON 抓取信息，各种信息汇总输出为一个 JSON 文档
+//
+//   - 数据保持原始类型，容量单位为 Byte，时间为 Unix 时间戳
+//
+// 参数：
+//   - config: 解析 toml 配置文件得到的配置项
+//   - flags: 系统信息各部分的开关，键为部分的参数名加 'Flag'
+func GrabInformationToJSON(config *general.Config, flags map[string]bool) {
+	// JSON 文档，键为各部分的参数名
+	document := make(map[string]any)
+
+	// 系统信息分配到不同的参数
+	sysInfo.GetSysInfo()
+
+	for _, section := range Sections() {
+		if !flags[section.Name()+"Flag"] {
+			continue
+		}
+
+		data, _ := section.Collect(config) // 原始数据，抓取出错时仍输出已抓取到的部分
+		if isDevices(data) {
+			document[section.Name()] = pickDevices(data, section.Items(config, data))
+		} else {
+			document[section.Name()] = pickItems(data, section.Items(config, data))
+		}
+	}
+
+	// 输出 JSON
+	if err := printJSON(document); err != nil {
+		fileName, lineNo := general.GetCallerInfo()
+		color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+	}
+}
+
+// renderTabContent 将一个部分的数据渲染为标签内容
+//
+// 参数：
+//   - config: 解析 toml 配置文件得到的配置项
+//   - section: 部分
+//   - data: 该部分的原始数据
+//   - viewRows: 多行单元格最多显示的行数
+//   - oddColor: 奇数行颜色
+//   - evenColor: 偶数行颜色
+//
+// 返回：
+//   - 标签内容
+//   - 未配置输出项时返回 false，该部分不显示
+func renderTabContent(config *general.Config, section Section, data any, viewRows int, oddColor, evenColor lipgloss.Color) (string, bool) {
+	items = section.Items(config, data) // 原始表头
+
+	// 未配置表头时不显示该项
+	if len(items) == 0 {
+		return "", false
+	}
+
+	// 组装表
+	tableHeader = []string{} // 表头
+	tableData = [][]string{} // 表数据
+	for _, item := range items {
+		tableHeader = append(tableHeader, itemName(item))
+	}
+	rows := section.Render(config, data)
+	if len(rows) == 0 {
+		// 没有设备时显示一个空行
+		rows = []map[string]string{emptyRow(items)}
+	}
+	for _, info := range rows {
+		rowData = []string{} // 行数据
+		for _, item := range items {
+			cellData := info[item]
+			if lines := strings.Split(cellData, "\n"); len(lines) > viewRows && viewRows > 0 {
+				cellData = strings.Join(lines[:viewRows], "\n") + "\n......"
+			}
+			rowData = append(rowData, cellData)
+		}
+		tableData = append(tableData, rowData)
+	}
+
+	dataTable = newDataTable(oddColor, evenColor, leftAlignedColumns(section, items, 0), false)
+	dataTable.Headers(tableHeader...) // 设置表头
+	dataTable.Rows(tableData...)      // 设置单元格
+
+	return dataTable.String(), true
+}
+
+// tabViewRows 计算标签模式下多行单元格最多显示的行数
+//
+// 返回：
+//   - 行数（终端行数 -（标签头行数+标签尾行数）-（表格头行数+表格尾行数）- 为省略号留的行数 - 命令行数 - 预留行数 - 数据表外部上下边距）
+func tabViewRows() int {
+	_, height, _ := general.GetTerminalSize()
+	return height - (3 + 1) - (3 + 1) - 1 - 1 - 1 - general.TableExPaddingUD*2
+}
+
+// nextRowColors 从随机颜色切片中取出下一组奇偶行颜色，未启用彩色输出或颜色用尽时沿用上一组颜色
+//
+// 返回：
+//   - 奇数行颜色
+//   - 偶数行颜色
+func nextRowColors() (lipgloss.Color, lipgloss.Color) {
+	if len(colors) >= 2 {
+		oddRowColor = colors[0]
+		evenRowColor = colors[1]
+		colors = colors[2:]
+	}
+	return oddRowColor, evenRowColor
+}
+
+// newDataTable 创建一个数据表
+//
+// 参数：
+//   - oddColor: 奇数行颜色
+//   - evenColor: 偶数行颜色
+//   - leftAligned: 需要左对齐的列
+//   - labeled: 第一列是否为行标签
+//
+// 返回：
+//   - 数据表
+func newDataTable(oddColor, evenColor lipgloss.Color, leftAligned map[int]bool, labeled bool) *table.Table {
+	oddRowStyle := general.CellStyle.Foreground(oddColor)   // 奇数行样式
+	evenRowStyle := general.CellStyle.Foreground(evenColor) // 偶数行样式
+
+	dataTable := table.New()                                // 创建一个表格
+	dataTable.Border(lipgloss.RoundedBorder())              // 设置表格边框
+	dataTable.BorderStyle(general.BorderStyle)              // 设置表格边框样式
+	dataTable.StyleFunc(func(row, col int) lipgloss.Style { // 按位置设置单元格样式
+		var style lipgloss.Style
+
+		switch {
+		case row == 0:
+			return general.HeaderStyle // 第一行为表头
+		case row%2 == 0:
+			style = evenRowStyle // 偶数行
+		default:
+			style = oddRowStyle // 奇数行
+		}
+
+		// 设置特定列格式
+		if col == 0 && labeled {
+			style = style.Foreground(general.ColumnOneColor)
+		}
+		if leftAligned[col] {
+			style = style.Align(lipgloss.Left)
+		}
+
+		return style
+	})
+
+	return dataTable
+}
+
+// leftAlignedColumns 获取部分中需要左对齐的列
+//
+// 参数：
+//   - section: 部分
+//   - items: 输出项
+//   - offset: 输出项之前的列数
+//
+// 返回：
+//   - 需要左对齐的列
+func leftAlignedColumns(section Section, items []string, offset int) map[int]bool {
+	columns := make(map[int]bool)
+	aligner, ok := section.(LeftAligner)
+	if !ok {
+		return columns
+	}
+	for _, leftItem := range aligner.LeftAlignedItems() {
+		for index, item := range items {
+			if item == leftItem {
+				columns[index+offset] = true
+			}
+		}
+	}
+	return columns
+}
+
+// emptyRow 生成一个空行，用于没有设备时占位
+//
+// 参数：
+//   - items: 输出项
+//
+// 返回：
+//   - 各输出项均为占位符的行
+func emptyRow(items []string) map[string]string {
+	row := make(map[string]string)
+	for _, item := range items {
+		row[item] = "--/--"
+	}
+	return row
+}
+
+// isDevices 判断原始数据是否为多设备数据
+//
+// 参数：
+//   - data: 抓取器返回的原始数据
+//
+// 返回：
+//   - 是多设备数据返回 true，否则返回 false
+func isDevices(data any) bool {
+	return data != nil && reflect.TypeOf(data).Kind() == reflect.Slice
+}
+
+// pickItems 按输出项从原始数据中挑选数据
+//
+// 参数：
+//   - data: 抓取器返回的原始数据，其 JSON 键即输出项名
+//   - items: 输出项
+//
+// 返回：
+//   - 挑选出的数据
+func pickItems(data any, items []string) map[string]any {
+	var info map[string]any
+	if err := remarshal(data, &info); err != nil {
+		return map[string]any{}
+	}
+
+	picked := make(map[string]any)
+	for _, item := range items {
+		if value, ok := info[item]; ok {
+			picked[item] = value
+		}
+	}
+	return picked
+}
+
+// pickDevices 按输出项从多设备原始数据中挑选数据，保持设备顺序
+//
+// 参数：
+//   - data: 抓取器返回的原始数据切片
+//   - items: 输出项
+//
+// 返回：
+//   - 挑选出的设备数据
+func pickDevices(data any, items []string) []map[string]any {
+	var devices []json.RawMessage
+	if err := remarshal(data, &devices); err != nil {
+		return []map[string]any{}
+	}
+
+	picked := make([]map[string]any, 0, len(devices))
+	for _, device := range devices {
+		picked = append(picked, pickItems(device, items))
+	}
+	return picked
+}
+
+// remarshal 将数据编码为 JSON 后再解码到目标，数字保持原样不转换为浮点数
+//
+// 参数：
+//   - data: 源数据
+//   - target: 目标指针
+//
+// 返回：
+//   - 错误信息
+func remarshal(data any, target any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
+
+// printJSON 将各部分的数据输出为一个 JSON 文档
+//
+// 参数：
+//   - document: 各部分的数据
+//
+// 返回：
+//   - 错误信息
+func printJSON(document map[string]any) error {
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,288 @@
+/*
+File: section.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 09:12:40
+
+Description: 系统信息的各个部分及其注册表
+
+- 每个部分实现 Section 接口，表格、标签和 JSON 模式均遍历注册表输出
+- 新增部分只需新建一个文件实现 Section 接口，并在 init 函数中调用 RegisterSection 注册
+*/
+
+package cli
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/yhyj/eniac/general"
+)
+
+// Section 系统信息的一个部分
+type Section interface {
+	// Name 参数名，同时也是 JSON 文档中该部分的键
+	Name() string
+	// Part 部分名，用于查找 i18n 名称
+	Part() string
+	// Usage 参数说明
+	Usage() string
+	// Collect 抓取该部分的原始数据，多设备的部分返回切片
+	Collect(config *general.Config) (any, error)
+	// Items 从配置中获取该部分的输出项
+	Items(config *general.Config, data any) []string
+	// Render 格式化原始数据，每个元素为一行，以输出项名为键
+	Render(config *general.Config, data any) []map[string]string
+}
+
+// LeftAligner 可选接口，指定需要左对齐的输出项
+type LeftAligner interface {
+	LeftAlignedItems() []string
+}
+
+// PlainPrinter 可选接口，以不带额外格式的纯文本输出数据，对应参数 'only'
+type PlainPrinter interface {
+	PrintPlain(config *general.Config, data any)
+}
+
+// Dynamic 可选接口，数据随时间变化的部分实现该接口，watch 模式中按间隔重新抓取，其他部分只抓取一次
+type Dynamic interface {
+	Dynamic() bool
+}
+
+// DeviceKeyer 可选接口，指定多设备部分中标识设备的输出项，比较快照时据此匹配设备，未实现时按设备顺序匹配
+type DeviceKeyer interface {
+	DeviceKey() string
+}
+
+// Volatile 可选接口，指定随时间变化的输出项，比较快照时默认忽略
+type Volatile interface {
+	VolatileItems() []string
+}
+
+// ConfigResolver 可选接口，检查并规范化该部分的配置项，未配置或无效的配置项替换为默认值
+type ConfigResolver interface {
+	ResolveConfig(config *general.Config)
+}
+
+// sections 已注册的部分，内置部分在前且顺序固定，其他部分按注册顺序排在其后
+var sections = builtinSections
+
+// RegisterSection 注册一个部分
+//
+// 参数：
+//   - section: 待注册的部分，参数名与已注册的部分重复时不注册
+func RegisterSection(section Section) {
+	if LookupSection(section.Name()) != nil {
+		general.Notify("Section '" + section.Name() + "' is already registered")
+		return
+	}
+	sections = append(sections, section)
+}
+
+// Sections 获取所有已注册的部分
+//
+// 返回：
+//   - 已注册的部分
+func Sections() []Section {
+	return sections
+}
+
+// LookupSection 按参数名查找已注册的部分
+//
+// 参数：
+//   - name: 参数名
+//
+// 返回：
+//   - 找到的部分，未找到时为 nil
+func LookupSection(name string) Section {
+	for _, section := range sections {
+		if section.Name() == name {
+			return section
+		}
+	}
+	return nil
+}
+
+// ResolveConfig 检查并规范化配置项，各部分的 Collect 和 Render 直接使用规范化后的配置项
+//
+//   - 须在抓取之前调用一次，watch 模式刷新时不再重复检查
+//   - 警告输出到标准错误，不混入 JSON 等标准输出的内容
+//
+// 参数：
+//   - config: 解析 toml 配置文件得到的配置项
+func ResolveConfig(config *general.Config) {
+	warnLegacyDataUnits(config)
+	config.Main.ResourceView = configResourceView(config.Main.ResourceView)
+	config.Main.Interval = configInterval(config.Main.Interval)
+	for _, section := range sections {
+		if resolver, ok := section.(ConfigResolver); ok {
+			resolver.ResolveConfig(config)
+		}
+	}
+}
+
+// warnMissingConfig 警告配置文件缺少配置项
+//
+// 参数：
+//   - item: 缺少的配置项
+func warnMissingConfig(item string) {
+	color.Fprintln(os.Stderr, color.Warn.Sprintf("Config file is missing '%s' item, using default value", item))
+}
+
+// warnInvalidConfig 警告配置项的值无效
+//
+// 参数：
+//   - item: 值无效的配置项
+//   - value: 配置项的值
+func warnInvalidConfig(item string, value any) {
+	color.Fprintln(os.Stderr, color.Warn.Sprintf("Config item '%s' has invalid value '%v', using default value", item, value))
+}
+
+// legacyDataUnits 旧版本生成的配置文件中各存储数据单位配置项的默认值
+var legacyDataUnits = map[string]string{
+	"cpu.cache_unit":   "KB",
+	"memory.data_unit": "GB",
+	"swap.data_unit":   "GB",
+}
+
+// warnLegacyDataUnits 检查旧版本生成的配置文件，警告其中含义已改变的存储数据单位配置项
+//
+//   - 旧版本忽略存储数据单位配置项，总是自动选择单位，其默认值现在作为固定单位生效
+//   - 配置项的值不做改动，只警告一次，提示改为 'auto' 或重新生成配置文件
+//
+// 参数：
+//   - config: 解析 toml 配置文件得到的配置项
+func warnLegacyDataUnits(config *general.Config) {
+	if config.Main.ConfigVersion >= general.CurrentConfigVersion {
+		return
+	}
+	units := map[string]string{
+		"cpu.cache_unit":   config.Genealogy.CPU.CacheUnit,
+		"memory.data_unit": config.Genealogy.Memory.DataUnit,
+		"swap.data_unit":   config.Genealogy.Swap.DataUnit,
+	}
+	var items []string
+	for item, legacyUnit := range legacyDataUnits {
+		if units[item] == legacyUnit {
+			items = append(items, color.Sprintf("'%s = %s'", item, legacyUnit))
+		}
+	}
+	if len(items) == 0 {
+		return
+	}
+	slices.Sort(items)
+	color.Fprintln(os.Stderr, color.Warn.Sprintf("Config file was created by an older version, which ignored %s and always chose units automatically; these units now apply as written, change them to 'auto' or recreate the config file with 'config --create' to keep the old output", strings.Join(items, ", ")))
+}
+
+// configDataUnit 获取配置的存储数据单位
+//
+// 参数：
+//   - item: 配置项，用于警告信息
+//   - unit: 配置的存储数据单位
+//
+// 返回：
+//   - 规范化的存储数据单位，未配置或无效时警告并使用 'auto'
+func configDataUnit(item, unit string) string {
+	if unit == "" {
+		warnMissingConfig(item)
+		return general.DataUnitAuto
+	}
+	parsedUnit, err := general.ParseDataUnit(unit)
+	if err != nil {
+		warnInvalidConfig(item, unit)
+		return general.DataUnitAuto
+	}
+	return parsedUnit
+}
+
+// configPercentUnit 获取配置的百分比单位
+//
+// 参数：
+//   - item: 配置项，用于警告信息
+//   - unit: 配置的百分比单位
+//
+// 返回：
+//   - 百分比单位，未配置或无效时警告并使用 '%'
+func configPercentUnit(item, unit string) string {
+	if unit == "" {
+		warnMissingConfig(item)
+		return general.PercentUnitPercent
+	}
+	parsedUnit, err := general.ParsePercentUnit(unit)
+	if err != nil {
+		warnInvalidConfig(item, unit)
+		return general.PercentUnitPercent
+	}
+	return parsedUnit
+}
+
+// configResourceView 获取配置的内存和 CPU 主视图
+//
+// 参数：
+//   - view: 配置的主视图
+//
+// 返回：
+//   - 主视图，未配置或无效时警告并使用 'host'
+func configResourceView(view string) string {
+	switch view {
+	case general.ResourceViewHost, general.ResourceViewCgroup:
+		return view
+	case "":
+		warnMissingConfig("main.resource_view")
+	default:
+		warnInvalidConfig("main.resource_view", view)
+	}
+	return general.ResourceViewHost
+}
+
+// configInterval 获取配置的 watch 模式刷新间隔
+//
+// 参数：
+//   - interval: 配置的刷新间隔，单位为秒
+//
+// 返回：
+//   - 刷新间隔，未配置或无效时警告并使用 2 秒
+func configInterval(interval int) int {
+	switch {
+	case interval > 0:
+		return interval
+	case interval == 0:
+		warnMissingConfig("main.interval")
+	default:
+		warnInvalidConfig("main.interval", interval)
+	}
+	return 2
+}
+
+// partName 获取部分的 i18n 名称
+//
+// 参数：
+//   - section: 部分
+//
+// 返回：
+//   - 部分的 i18n 名称，未定义时为部分名本身
+func partName(section Section) string {
+	name := general.PartName[section.Part()][general.Language]
+	if name == "" {
+		name = section.Part()
+	}
+	return name
+}
+
+// itemName 获取输出项的 i18n 名称
+//
+// 参数：
+//   - item: 输出项
+//
+// 返回：
+//   - 输出项的 i18n 名称，未定义时为输出项本身
+func itemName(item string) string {
+	name := general.GenealogyName[item][general.Language]
+	if name == "" {
+		name = item
+	}
+	return name
+}
@@ -0,0 +1,76 @@
+//go:build linux
+
+/*
+File: section_battery_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-28 10:22:36
+
+Description: 系统信息的电池部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// batterySection 电池信息，每块电池一行
+type batterySection struct{}
+
+// Name 参数名
+func (batterySection) Name() string {
+	return "battery"
+}
+
+// Part 部分名
+func (batterySection) Part() string {
+	return "Battery"
+}
+
+// Dynamic 数据随时间变化
+func (batterySection) Dynamic() bool {
+	return true
+}
+
+// Usage 参数说明
+func (batterySection) Usage() string {
+	return "Get Battery information"
+}
+
+// DeviceKey 以电池名称标识设备
+func (batterySection) DeviceKey() string {
+	return "BatteryName"
+}
+
+// VolatileItems 随时间变化的输出项
+func (batterySection) VolatileItems() []string {
+	return []string{"BatteryStatus", "BatteryCapacity", "BatteryACOnline"}
+}
+
+// ResolveConfig 规范化百分比单位
+func (batterySection) ResolveConfig(config *general.Config) {
+	config.Genealogy.Battery.PercentUnit = configPercentUnit("battery.percent_unit", config.Genealogy.Battery.PercentUnit)
+}
+
+// Collect 抓取电池信息
+func (batterySection) Collect(config *general.Config) (any, error) {
+	return general.GetBatteryInfo()
+}
+
+// Items 获取电池信息的输出项，是否有电池对应不同的输出项
+func (batterySection) Items(config *general.Config, data any) []string {
+	if batteries := data.([]general.BatteryInfo); len(batteries) > 0 && batteries[0].Availability == "Unavailable" {
+		return config.Genealogy.Battery.Items.Unavailable
+	}
+	return config.Genealogy.Battery.Items.Available
+}
+
+// Render 格式化电池信息
+func (batterySection) Render(config *general.Config, data any) []map[string]string {
+	var rows []map[string]string
+	for _, battery := range data.([]general.BatteryInfo) {
+		rows = append(rows, general.FormatBatteryInfo(battery, config.Genealogy.Battery.PercentUnit))
+	}
+	return rows
+}
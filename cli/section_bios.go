@@ -0,0 +1,47 @@
+/*
+File: section_bios.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:07:50
+
+Description: 系统信息的BIOS部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// biosSection BIOS信息
+type biosSection struct{}
+
+// Name 参数名
+func (biosSection) Name() string {
+	return "bios"
+}
+
+// Part 部分名
+func (biosSection) Part() string {
+	return "BIOS"
+}
+
+// Usage 参数说明
+func (biosSection) Usage() string {
+	return "Get BIOS information"
+}
+
+// Collect 抓取BIOS信息
+func (biosSection) Collect(config *general.Config) (any, error) {
+	return general.GetBIOSInfo(sysInfo), nil
+}
+
+// Items 获取BIOS信息的输出项
+func (biosSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Bios.Items
+}
+
+// Render 格式化BIOS信息
+func (biosSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatBIOSInfo(data.(general.BIOSInfo))}
+}
@@ -0,0 +1,47 @@
+/*
+File: section_board.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:05:27
+
+Description: 系统信息的主板部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// boardSection 主板信息
+type boardSection struct{}
+
+// Name 参数名
+func (boardSection) Name() string {
+	return "board"
+}
+
+// Part 部分名
+func (boardSection) Part() string {
+	return "Board"
+}
+
+// Usage 参数说明
+func (boardSection) Usage() string {
+	return "Get Board information"
+}
+
+// Collect 抓取主板信息
+func (boardSection) Collect(config *general.Config) (any, error) {
+	return general.GetBoardInfo(sysInfo), nil
+}
+
+// Items 获取主板信息的输出项
+func (boardSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Board.Items
+}
+
+// Render 格式化主板信息
+func (boardSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatBoardInfo(data.(general.BoardInfo))}
+}
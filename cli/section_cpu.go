@@ -0,0 +1,91 @@
+/*
+File: section_cpu.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:09:14
+
+Description: 系统信息的处理器部分
+*/
+
+package cli
+
+import (
+	"slices"
+	"time"
+
+	"github.com/yhyj/eniac/general"
+)
+
+// cpuSection 处理器信息
+type cpuSection struct{}
+
+// cpuUsageItems 需要采样 CPU 时间的输出项
+var cpuUsageItems = []string{"CPUUsage", "CPUUsageUser", "CPUUsageSystem", "CPUUsageIowait", "CPUUsageSteal", "CPUCoreUsage", "CPUCgroupQuota", "CPUCgroupUsage"}
+
+// Name 参数名
+func (cpuSection) Name() string {
+	return "cpu"
+}
+
+// Part 部分名
+func (cpuSection) Part() string {
+	return "CPU"
+}
+
+// Dynamic 数据随时间变化
+func (cpuSection) Dynamic() bool {
+	return true
+}
+
+// Usage 参数说明
+func (cpuSection) Usage() string {
+	return "Get CPU information"
+}
+
+// VolatileItems 随时间变化的输出项，逻辑处理器列表包含当前频率
+func (cpuSection) VolatileItems() []string {
+	return []string{"CPUCoreDetails", "CPUUsage", "CPUUsageUser", "CPUUsageSystem", "CPUUsageIowait", "CPUUsageSteal", "CPUCoreUsage", "CPUCgroupUsage"}
+}
+
+// LeftAlignedItems 漏洞列表和逻辑处理器列表左对齐
+func (cpuSection) LeftAlignedItems() []string {
+	return []string{"CPUVulnerabilities", "CPUCoreDetails", "CPUCoreUsage"}
+}
+
+// ResolveConfig 规范化采样时长、缓存容量单位和百分比单位
+func (cpuSection) ResolveConfig(config *general.Config) {
+	switch sampleWindow := config.Genealogy.CPU.SampleWindow; {
+	case sampleWindow == 0:
+		warnMissingConfig("cpu.sample_window")
+		config.Genealogy.CPU.SampleWindow = 500
+	case sampleWindow < 0:
+		warnInvalidConfig("cpu.sample_window", sampleWindow)
+		config.Genealogy.CPU.SampleWindow = 500
+	}
+	config.Genealogy.CPU.CacheUnit = configDataUnit("cpu.cache_unit", config.Genealogy.CPU.CacheUnit)
+	config.Genealogy.CPU.PercentUnit = configPercentUnit("cpu.percent_unit", config.Genealogy.CPU.PercentUnit)
+}
+
+// Collect 抓取处理器信息和使用率
+func (cpuSection) Collect(config *general.Config) (any, error) {
+	cpuInfo := general.GetCPUInfo(sysInfo)
+
+	// 使用率首次抓取需间隔采样两次，未配置使用率相关的输出项时不抓取
+	if !slices.ContainsFunc(config.Genealogy.CPU.Items, func(item string) bool { return slices.Contains(cpuUsageItems, item) }) {
+		return cpuInfo, nil
+	}
+	cpuUsage, err := general.GetCPUUsage(time.Duration(config.Genealogy.CPU.SampleWindow) * time.Millisecond)
+	cpuInfo.CPUUsage = cpuUsage
+
+	return cpuInfo, err
+}
+
+// Items 获取处理器信息的输出项
+func (cpuSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.CPU.Items
+}
+
+// Render 格式化处理器信息
+func (cpuSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatCPUInfo(data.(general.CPUInfo), config.Genealogy.CPU.CacheUnit, config.Genealogy.CPU.PercentUnit, config.Main.ResourceView)}
+}
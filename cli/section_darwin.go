@@ -0,0 +1,27 @@
+//go:build darwin
+
+/*
+File: section_darwin.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:51:09
+
+Description: 内置的系统信息部分
+*/
+
+package cli
+
+// builtinSections 内置部分，按输出顺序排列
+var builtinSections = []Section{
+	productSection{},
+	boardSection{},
+	biosSection{},
+	cpuSection{},
+	memorySection{},
+	swapSection{},
+	storageSection{},
+	filesystemSection{},
+	osSection{},
+	loadSection{},
+	userSection{},
+}
@@ -0,0 +1,78 @@
+/*
+File: section_filesystem.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-22 09:47:15
+
+Description: 系统信息的文件系统部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// filesystemSection 已挂载文件系统信息，每个文件系统一行
+type filesystemSection struct{}
+
+// Name 参数名
+func (filesystemSection) Name() string {
+	return "filesystem"
+}
+
+// Part 部分名
+func (filesystemSection) Part() string {
+	return "Filesystem"
+}
+
+// Dynamic 数据随时间变化
+func (filesystemSection) Dynamic() bool {
+	return true
+}
+
+// Usage 参数说明
+func (filesystemSection) Usage() string {
+	return "Get Filesystem information"
+}
+
+// DeviceKey 以挂载点标识文件系统
+func (filesystemSection) DeviceKey() string {
+	return "FilesystemMountPoint"
+}
+
+// VolatileItems 随时间变化的输出项
+func (filesystemSection) VolatileItems() []string {
+	return []string{"FilesystemUsed", "FilesystemAvail", "FilesystemUsedPercent", "FilesystemInodesUsed", "FilesystemInodeUsedPercent"}
+}
+
+// LeftAlignedItems 挂载点和设备左对齐
+func (filesystemSection) LeftAlignedItems() []string {
+	return []string{"FilesystemMountPoint", "FilesystemDevice"}
+}
+
+// ResolveConfig 规范化存储数据单位和百分比单位
+func (filesystemSection) ResolveConfig(config *general.Config) {
+	config.Genealogy.Filesystem.DataUnit = configDataUnit("filesystem.data_unit", config.Genealogy.Filesystem.DataUnit)
+	config.Genealogy.Filesystem.PercentUnit = configPercentUnit("filesystem.percent_unit", config.Genealogy.Filesystem.PercentUnit)
+}
+
+// Collect 抓取已挂载文件系统信息
+func (filesystemSection) Collect(config *general.Config) (any, error) {
+	filesystemConfig := config.Genealogy.Filesystem
+	return general.GetFilesystemInfo(filesystemConfig.ShowPseudo, filesystemConfig.Include, filesystemConfig.Exclude)
+}
+
+// Items 获取已挂载文件系统信息的输出项
+func (filesystemSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Filesystem.Items
+}
+
+// Render 格式化已挂载文件系统信息
+func (filesystemSection) Render(config *general.Config, data any) []map[string]string {
+	var rows []map[string]string
+	for _, filesystem := range data.([]general.FilesystemInfo) {
+		rows = append(rows, general.FormatFilesystemInfo(filesystem, config.Genealogy.Filesystem.DataUnit, config.Genealogy.Filesystem.PercentUnit))
+	}
+	return rows
+}
@@ -0,0 +1,58 @@
+//go:build linux
+
+/*
+File: section_gpu_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:12:33
+
+Description: 系统信息的显卡部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// gpuSection 显卡信息，每张显卡一行
+type gpuSection struct{}
+
+// Name 参数名
+func (gpuSection) Name() string {
+	return "gpu"
+}
+
+// Part 部分名
+func (gpuSection) Part() string {
+	return "GPU"
+}
+
+// Usage 参数说明
+func (gpuSection) Usage() string {
+	return "Get GPU information"
+}
+
+// DeviceKey 以显卡地址标识设备
+func (gpuSection) DeviceKey() string {
+	return "GPUAddress"
+}
+
+// Collect 抓取显卡信息
+func (gpuSection) Collect(config *general.Config) (any, error) {
+	return general.GetGPUInfo()
+}
+
+// Items 获取显卡信息的输出项
+func (gpuSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.GPU.Items
+}
+
+// Render 格式化显卡信息
+func (gpuSection) Render(config *general.Config, data any) []map[string]string {
+	var rows []map[string]string
+	for _, card := range data.([]general.GPUInfo) {
+		rows = append(rows, general.FormatGPUInfo(card))
+	}
+	return rows
+}
@@ -0,0 +1,36 @@
+//go:build linux
+
+/*
+File: section_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:50:36
+
+Description: 内置的系统信息部分
+*/
+
+package cli
+
+// builtinSections 内置部分，按输出顺序排列
+var builtinSections = []Section{
+	productSection{},
+	boardSection{},
+	biosSection{},
+	cpuSection{},
+	gpuSection{},
+	memorySection{},
+	swapSection{},
+	storageSection{},
+	filesystemSection{},
+	sensorsSection{},
+	batterySection{},
+	nicSection{},
+	osSection{},
+	virtSection{},
+	loadSection{},
+	servicesSection{},
+	timeSection{},
+	userSection{},
+	packageSection{},
+	updateSection{},
+}
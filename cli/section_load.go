@@ -0,0 +1,85 @@
+/*
+File: section_load.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:33:42
+
+Description: 系统信息的负载部分
+*/
+
+package cli
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/yhyj/eniac/general"
+)
+
+// loadSection 负载信息
+type loadSection struct{}
+
+// Name 参数名
+func (loadSection) Name() string {
+	return "load"
+}
+
+// Part 部分名
+func (loadSection) Part() string {
+	return "Load"
+}
+
+// Dynamic 数据随时间变化
+func (loadSection) Dynamic() bool {
+	return true
+}
+
+// Usage 参数说明
+func (loadSection) Usage() string {
+	return "Get Load information"
+}
+
+// VolatileItems 随时间变化的输出项
+func (loadSection) VolatileItems() []string {
+	return []string{"Load1", "Load5", "Load15", "Process", "TopProcesses", "PressureCPU", "PressureMemory", "PressureIO"}
+}
+
+// LeftAlignedItems 左对齐的输出项
+func (loadSection) LeftAlignedItems() []string {
+	return []string{"TopProcesses", "PressureCPU", "PressureMemory", "PressureIO"}
+}
+
+// ResolveConfig 规范化进程排行的排序依据
+func (loadSection) ResolveConfig(config *general.Config) {
+	switch config.Genealogy.Load.TopSortKey {
+	case "cpu", "rss":
+		return
+	case "":
+		warnMissingConfig("load.top_sort_key")
+	default:
+		warnInvalidConfig("load.top_sort_key", config.Genealogy.Load.TopSortKey)
+	}
+	config.Genealogy.Load.TopSortKey = "cpu"
+}
+
+// Collect 抓取负载信息和进程排行
+func (loadSection) Collect(config *general.Config) (any, error) {
+	loadInfo, loadErr := general.GetLoadInfo()
+	// 进程排行首次抓取需间隔采样两次，未配置该输出项时不抓取
+	var processErr error
+	if slices.Contains(config.Genealogy.Load.Items, "TopProcesses") {
+		loadInfo.TopProcesses, processErr = general.GetTopProcesses(config.Genealogy.Load.TopCount, config.Genealogy.Load.TopSortKey)
+	}
+
+	return loadInfo, errors.Join(loadErr, processErr)
+}
+
+// Items 获取负载信息的输出项
+func (loadSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Load.Items
+}
+
+// Render 格式化负载信息
+func (loadSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatLoadInfo(data.(general.LoadInfo))}
+}
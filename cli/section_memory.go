@@ -0,0 +1,68 @@
+/*
+File: section_memory.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:15:02
+
+Description: 系统信息的内存部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// memorySection 内存信息
+type memorySection struct{}
+
+// Name 参数名
+func (memorySection) Name() string {
+	return "memory"
+}
+
+// Part 部分名
+func (memorySection) Part() string {
+	return "Memory"
+}
+
+// Dynamic 数据随时间变化
+func (memorySection) Dynamic() bool {
+	return true
+}
+
+// Usage 参数说明
+func (memorySection) Usage() string {
+	return "Get Memory information"
+}
+
+// VolatileItems 随时间变化的输出项，内存总量除外
+func (memorySection) VolatileItems() []string {
+	return []string{"MemoryUsed", "MemoryUsedPercent", "MemoryFree", "MemoryShared", "MemoryBuffCache", "MemoryAvail", "MemoryCgroupUsed"}
+}
+
+// LeftAlignedItems 内存插槽列表左对齐
+func (memorySection) LeftAlignedItems() []string {
+	return []string{"MemoryModules"}
+}
+
+// ResolveConfig 规范化存储数据单位和百分比单位
+func (memorySection) ResolveConfig(config *general.Config) {
+	config.Genealogy.Memory.DataUnit = configDataUnit("memory.data_unit", config.Genealogy.Memory.DataUnit)
+	config.Genealogy.Memory.PercentUnit = configPercentUnit("memory.percent_unit", config.Genealogy.Memory.PercentUnit)
+}
+
+// Collect 抓取内存信息
+func (memorySection) Collect(config *general.Config) (any, error) {
+	return general.GetMemoryInfo()
+}
+
+// Items 获取内存信息的输出项
+func (memorySection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Memory.Items
+}
+
+// Render 格式化内存信息
+func (memorySection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatMemoryInfo(data.(general.MemoryInfo), config.Genealogy.Memory.DataUnit, config.Genealogy.Memory.PercentUnit, config.Main.ResourceView)}
+}
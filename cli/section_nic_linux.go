@@ -0,0 +1,78 @@
+//go:build linux
+
+/*
+File: section_nic_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:28:51
+
+Description: 系统信息的网卡部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// nicSection 网卡信息，每个网卡一行
+type nicSection struct{}
+
+// Name 参数名
+func (nicSection) Name() string {
+	return "nic"
+}
+
+// Part 部分名
+func (nicSection) Part() string {
+	return "NIC"
+}
+
+// Dynamic 数据随时间变化
+func (nicSection) Dynamic() bool {
+	return true
+}
+
+// Usage 参数说明
+func (nicSection) Usage() string {
+	return "Get NIC information"
+}
+
+// DeviceKey 以网卡名称标识设备
+func (nicSection) DeviceKey() string {
+	return "NicName"
+}
+
+// VolatileItems 随时间变化的输出项
+func (nicSection) VolatileItems() []string {
+	return []string{"NicState", "NicCarrier", "NicRXBytes", "NicTXBytes", "NicSignal", "NicBitrate"}
+}
+
+// LeftAlignedItems 地址、网关和 DNS 服务器左对齐
+func (nicSection) LeftAlignedItems() []string {
+	return []string{"NicIPv4", "NicIPv6", "NicGateways", "NicDNS"}
+}
+
+// ResolveConfig 规范化存储数据单位
+func (nicSection) ResolveConfig(config *general.Config) {
+	config.Genealogy.Nic.DataUnit = configDataUnit("nic.data_unit", config.Genealogy.Nic.DataUnit)
+}
+
+// Collect 抓取网卡信息
+func (nicSection) Collect(config *general.Config) (any, error) {
+	return general.GetNicInfo(config.Genealogy.Nic.ShowVirtual)
+}
+
+// Items 获取网卡信息的输出项
+func (nicSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Nic.Items
+}
+
+// Render 格式化网卡信息
+func (nicSection) Render(config *general.Config, data any) []map[string]string {
+	var rows []map[string]string
+	for _, nic := range data.([]general.NicInfo) {
+		rows = append(rows, general.FormatNicInfo(nic, config.Genealogy.Nic.DataUnit))
+	}
+	return rows
+}
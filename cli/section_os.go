@@ -0,0 +1,47 @@
+/*
+File: section_os.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:31:06
+
+Description: 系统信息的系统部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// osSection 系统信息
+type osSection struct{}
+
+// Name 参数名
+func (osSection) Name() string {
+	return "os"
+}
+
+// Part 部分名
+func (osSection) Part() string {
+	return "OS"
+}
+
+// Usage 参数说明
+func (osSection) Usage() string {
+	return "Get Operating System information"
+}
+
+// Collect 抓取系统信息
+func (osSection) Collect(config *general.Config) (any, error) {
+	return general.GetOSInfo(sysInfo)
+}
+
+// Items 获取系统信息的输出项
+func (osSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.OS.Items
+}
+
+// Render 格式化系统信息
+func (osSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatOSInfo(data.(general.OSInfo))}
+}
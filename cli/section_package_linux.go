@@ -0,0 +1,54 @@
+//go:build linux
+
+/*
+File: section_package_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:40:48
+
+Description: 系统信息的安装包部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// packageSection 安装包信息
+type packageSection struct{}
+
+// Name 参数名
+func (packageSection) Name() string {
+	return "package"
+}
+
+// Part 部分名
+func (packageSection) Part() string {
+	return "Package"
+}
+
+// Usage 参数说明
+func (packageSection) Usage() string {
+	return "Get Package information"
+}
+
+// ResolveConfig 规范化存储数据单位
+func (packageSection) ResolveConfig(config *general.Config) {
+	config.Genealogy.Package.DataUnit = configDataUnit("package.data_unit", config.Genealogy.Package.DataUnit)
+}
+
+// Collect 抓取安装包信息
+func (packageSection) Collect(config *general.Config) (any, error) {
+	return general.GetPackageInfo()
+}
+
+// Items 获取安装包信息的输出项
+func (packageSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Package.Items
+}
+
+// Render 格式化安装包信息
+func (packageSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatPackageInfo(data.(general.PackageInfo), config.Genealogy.Package.DataUnit)}
+}
@@ -0,0 +1,47 @@
+/*
+File: section_product.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:02:11
+
+Description: 系统信息的产品部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// productSection 产品信息
+type productSection struct{}
+
+// Name 参数名
+func (productSection) Name() string {
+	return "product"
+}
+
+// Part 部分名
+func (productSection) Part() string {
+	return "Product"
+}
+
+// Usage 参数说明
+func (productSection) Usage() string {
+	return "Get Product information"
+}
+
+// Collect 抓取产品信息
+func (productSection) Collect(config *general.Config) (any, error) {
+	return general.GetProductInfo(sysInfo), nil
+}
+
+// Items 获取产品信息的输出项
+func (productSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Product.Items
+}
+
+// Render 格式化产品信息
+func (productSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatProductInfo(data.(general.ProductInfo))}
+}
@@ -0,0 +1,73 @@
+//go:build linux
+
+/*
+File: section_sensors_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-27 10:05:12
+
+Description: 系统信息的传感器部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// sensorsSection 硬件传感器信息，每个芯片一行
+type sensorsSection struct{}
+
+// Name 参数名
+func (sensorsSection) Name() string {
+	return "sensors"
+}
+
+// Part 部分名
+func (sensorsSection) Part() string {
+	return "Sensor"
+}
+
+// Dynamic 数据随时间变化
+func (sensorsSection) Dynamic() bool {
+	return true
+}
+
+// Usage 参数说明
+func (sensorsSection) Usage() string {
+	return "Get Sensors information"
+}
+
+// DeviceKey 以芯片所属设备标识芯片
+func (sensorsSection) DeviceKey() string {
+	return "SensorDevice"
+}
+
+// VolatileItems 读数随时间变化
+func (sensorsSection) VolatileItems() []string {
+	return []string{"SensorReadings"}
+}
+
+// LeftAlignedItems 读数左对齐
+func (sensorsSection) LeftAlignedItems() []string {
+	return []string{"SensorReadings"}
+}
+
+// Collect 抓取传感器信息
+func (sensorsSection) Collect(config *general.Config) (any, error) {
+	return general.GetSensorInfo()
+}
+
+// Items 获取传感器信息的输出项
+func (sensorsSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Sensors.Items
+}
+
+// Render 格式化传感器信息
+func (sensorsSection) Render(config *general.Config, data any) []map[string]string {
+	var rows []map[string]string
+	for _, sensor := range data.([]general.SensorInfo) {
+		rows = append(rows, general.FormatSensorInfo(sensor))
+	}
+	return rows
+}
@@ -0,0 +1,101 @@
+//go:build linux
+
+/*
+File: section_services_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-06 14:18:05
+
+Description: 系统信息的服务部分
+*/
+
+package cli
+
+import (
+	"errors"
+
+	"github.com/yhyj/eniac/general"
+)
+
+// servicesSection systemd 服务信息，每个管理器一行
+type servicesSection struct{}
+
+// Name 参数名
+func (servicesSection) Name() string {
+	return "services"
+}
+
+// Part 部分名
+func (servicesSection) Part() string {
+	return "Services"
+}
+
+// Dynamic 数据随时间变化
+func (servicesSection) Dynamic() bool {
+	return true
+}
+
+// Usage 参数说明
+func (servicesSection) Usage() string {
+	return "Get systemd Services information"
+}
+
+// DeviceKey 以管理器标识设备
+func (servicesSection) DeviceKey() string {
+	return "ServiceManager"
+}
+
+// LeftAlignedItems 左对齐的输出项
+func (servicesSection) LeftAlignedItems() []string {
+	return []string{"ServiceFailedUnits", "ServiceUnits"}
+}
+
+// ResolveConfig 规范化服务管理器列表，去掉无效的管理器
+func (servicesSection) ResolveConfig(config *general.Config) {
+	if len(config.Genealogy.Services.Managers) == 0 {
+		warnMissingConfig("services.managers")
+		config.Genealogy.Services.Managers = []string{"system", "user"}
+		return
+	}
+
+	var managers []string
+	for _, manager := range config.Genealogy.Services.Managers {
+		if manager != "system" && manager != "user" {
+			warnInvalidConfig("services.managers", manager)
+			continue
+		}
+		managers = append(managers, manager)
+	}
+	config.Genealogy.Services.Managers = managers
+}
+
+// Collect 抓取各管理器的服务信息
+func (servicesSection) Collect(config *general.Config) (any, error) {
+	var services []general.ServiceInfo
+	var errs []error
+	for _, manager := range config.Genealogy.Services.Managers {
+		units := config.Genealogy.Services.SystemUnits
+		if manager == "user" {
+			units = config.Genealogy.Services.UserUnits
+		}
+		serviceInfo, err := general.GetServiceInfo(manager, units)
+		services = append(services, serviceInfo)
+		errs = append(errs, err)
+	}
+
+	return services, errors.Join(errs...)
+}
+
+// Items 获取服务信息的输出项
+func (servicesSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Services.Items
+}
+
+// Render 格式化服务信息
+func (servicesSection) Render(config *general.Config, data any) []map[string]string {
+	var rows []map[string]string
+	for _, serviceInfo := range data.([]general.ServiceInfo) {
+		rows = append(rows, general.FormatServiceInfo(serviceInfo))
+	}
+	return rows
+}
@@ -0,0 +1,71 @@
+/*
+File: section_storage.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:24:38
+
+Description: 系统信息的存储设备部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// storageSection 存储设备信息，每个设备一行
+type storageSection struct{}
+
+// Name 参数名
+func (storageSection) Name() string {
+	return "storage"
+}
+
+// Part 部分名
+func (storageSection) Part() string {
+	return "Disk"
+}
+
+// Usage 参数说明
+func (storageSection) Usage() string {
+	return "Get Storage information"
+}
+
+// DeviceKey 以磁盘名称标识设备
+func (storageSection) DeviceKey() string {
+	return "StorageName"
+}
+
+// VolatileItems 磁盘温度和通电时间随时间变化
+func (storageSection) VolatileItems() []string {
+	return []string{"StorageTemperature", "StoragePowerOnHours"}
+}
+
+// LeftAlignedItems 分区树左对齐
+func (storageSection) LeftAlignedItems() []string {
+	return []string{"StoragePartitions"}
+}
+
+// ResolveConfig 规范化存储数据单位
+func (storageSection) ResolveConfig(config *general.Config) {
+	config.Genealogy.Storage.DataUnit = configDataUnit("storage.data_unit", config.Genealogy.Storage.DataUnit)
+}
+
+// Collect 抓取存储设备信息
+func (storageSection) Collect(config *general.Config) (any, error) {
+	return general.GetStorageInfo()
+}
+
+// Items 获取存储设备信息的输出项
+func (storageSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Storage.Items
+}
+
+// Render 格式化存储设备信息
+func (storageSection) Render(config *general.Config, data any) []map[string]string {
+	var rows []map[string]string
+	for _, device := range data.([]general.StorageInfo) {
+		rows = append(rows, general.FormatStorageInfo(device, config.Genealogy.Storage.DataUnit))
+	}
+	return rows
+}
@@ -0,0 +1,65 @@
+/*
+File: section_swap.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:19:45
+
+Description: 系统信息的交换空间部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// swapSection 交换空间信息
+type swapSection struct{}
+
+// Name 参数名
+func (swapSection) Name() string {
+	return "swap"
+}
+
+// Part 部分名
+func (swapSection) Part() string {
+	return "Swap"
+}
+
+// Dynamic 数据随时间变化
+func (swapSection) Dynamic() bool {
+	return true
+}
+
+// Usage 参数说明
+func (swapSection) Usage() string {
+	return "Get Swap information"
+}
+
+// VolatileItems 随时间变化的输出项
+func (swapSection) VolatileItems() []string {
+	return []string{"SwapFree"}
+}
+
+// ResolveConfig 规范化存储数据单位
+func (swapSection) ResolveConfig(config *general.Config) {
+	config.Genealogy.Swap.DataUnit = configDataUnit("swap.data_unit", config.Genealogy.Swap.DataUnit)
+}
+
+// Collect 抓取交换空间信息
+func (swapSection) Collect(config *general.Config) (any, error) {
+	return general.GetSwapInfo()
+}
+
+// Items 获取交换空间信息的输出项，交换空间是否可用对应不同的输出项
+func (swapSection) Items(config *general.Config, data any) []string {
+	if data.(general.SwapInfo).Status == "Unavailable" {
+		return config.Genealogy.Swap.Items.Unavailable
+	}
+	return config.Genealogy.Swap.Items.Available
+}
+
+// Render 格式化交换空间信息
+func (swapSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatSwapInfo(data.(general.SwapInfo), config.Genealogy.Swap.DataUnit)}
+}
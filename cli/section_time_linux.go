@@ -0,0 +1,64 @@
+//go:build linux
+
+/*
+File: section_time_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:36:20
+
+Description: 系统信息的时间部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// timeSection 时间信息
+type timeSection struct{}
+
+// Name 参数名
+func (timeSection) Name() string {
+	return "time"
+}
+
+// Part 部分名
+func (timeSection) Part() string {
+	return "Time"
+}
+
+// Dynamic 数据随时间变化
+func (timeSection) Dynamic() bool {
+	return true
+}
+
+// Usage 参数说明
+func (timeSection) Usage() string {
+	return "Get Time information"
+}
+
+// VolatileItems 随时间变化的输出项，每次启动后均不同
+func (timeSection) VolatileItems() []string {
+	return []string{"BootTime", "Uptime", "StartTime", "BootFirmware", "BootLoader", "BootKernel", "BootInitrd", "BootUserspace", "BootBlame", "BootCriticalChain"}
+}
+
+// LeftAlignedItems 左对齐的输出项
+func (timeSection) LeftAlignedItems() []string {
+	return []string{"BootBlame", "BootCriticalChain"}
+}
+
+// Collect 抓取时间信息和启动用时
+func (timeSection) Collect(config *general.Config) (any, error) {
+	return general.GetTimeInfo(config.Genealogy.Time.BlameCount)
+}
+
+// Items 获取时间信息的输出项
+func (timeSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Time.Items
+}
+
+// Render 格式化时间信息
+func (timeSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatTimeInfo(data.(general.TimeInfo))}
+}
@@ -0,0 +1,101 @@
+//go:build linux
+
+/*
+File: section_update_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:44:17
+
+Description: 系统信息的更新部分
+*/
+
+package cli
+
+import (
+	"errors"
+
+	"github.com/gookit/color"
+	"github.com/yhyj/eniac/general"
+)
+
+// updateSection 更新信息
+type updateSection struct{}
+
+// updateData 更新信息的原始数据，合并更新检测服务信息和可更新包信息
+type updateData struct {
+	general.UpdateDaemonInfo
+	general.UpdatablePackageInfo
+}
+
+// Name 参数名
+func (updateSection) Name() string {
+	return "update"
+}
+
+// Part 部分名
+func (updateSection) Part() string {
+	return "Update"
+}
+
+// Usage 参数说明
+func (updateSection) Usage() string {
+	return "Get Update information"
+}
+
+// VolatileItems 随时间变化的输出项
+func (updateSection) VolatileItems() []string {
+	return []string{"LastCheckTime", "UpdatablePackageList", "UpdatablePackageQuantity"}
+}
+
+// ResolveConfig 未配置可更新包列表中各来源的开始符时使用默认值
+func (updateSection) ResolveConfig(config *general.Config) {
+	if config.Genealogy.Update.ArchDividing == "" {
+		warnMissingConfig("update.arch_dividing")
+		config.Genealogy.Update.ArchDividing = "······Arch Official Repository······"
+	}
+	if config.Genealogy.Update.AurDividing == "" {
+		warnMissingConfig("update.aur_dividing")
+		config.Genealogy.Update.AurDividing = "········Arch User Repository········"
+	}
+}
+
+// Collect 抓取更新信息
+func (updateSection) Collect(config *general.Config) (any, error) {
+	daemonInfo, daemonErr := general.GetCheckUpdateDaemonInfo(config.Genealogy.Update.Basis, "user")
+	packageInfo, packageErr := general.GetUpdatablePackageInfo(config.Genealogy.Update.ArchRecordFile, config.Genealogy.Update.AurRecordFile, 0)
+
+	return updateData{daemonInfo, packageInfo}, errors.Join(daemonErr, packageErr)
+}
+
+// Items 获取更新信息的输出项
+func (updateSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Update.Items
+}
+
+// Render 格式化更新信息
+func (updateSection) Render(config *general.Config, data any) []map[string]string {
+	info := data.(updateData)
+
+	return []map[string]string{general.FormatUpdateInfo(info.UpdateDaemonInfo, info.UpdatablePackageInfo, config.Genealogy.Update.ArchDividing, config.Genealogy.Update.AurDividing)}
+}
+
+// LeftAlignedItems 可更新包列表左对齐
+func (updateSection) LeftAlignedItems() []string {
+	return []string{"UpdatablePackageList"}
+}
+
+// PrintPlain 仅输出不带额外格式的可更新包信息，专为第三方更新检测插件服务
+func (updateSection) PrintPlain(config *general.Config, data any) {
+	archDividing, aurDividing := config.Genealogy.Update.ArchDividing, config.Genealogy.Update.AurDividing
+	info := data.(updateData)
+
+	num := 1
+	for _, line := range general.ComposeUpdatablePackageList(info.PackageList, archDividing, aurDividing) {
+		if line == archDividing || line == aurDividing || line == "" {
+			color.Printf("%v\n", line)
+			continue
+		}
+		color.Printf("%v: %v\n", num, line)
+		num += 1
+	}
+}
@@ -0,0 +1,47 @@
+/*
+File: section_user.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-14 10:38:15
+
+Description: 系统信息的用户部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// userSection 用户信息
+type userSection struct{}
+
+// Name 参数名
+func (userSection) Name() string {
+	return "user"
+}
+
+// Part 部分名
+func (userSection) Part() string {
+	return "User"
+}
+
+// Usage 参数说明
+func (userSection) Usage() string {
+	return "Get User information"
+}
+
+// Collect 抓取用户信息
+func (userSection) Collect(config *general.Config) (any, error) {
+	return general.GetUserInfo()
+}
+
+// Items 获取用户信息的输出项
+func (userSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.User.Items
+}
+
+// Render 格式化用户信息
+func (userSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatUserInfo(data.(general.CurrentUserInfo))}
+}
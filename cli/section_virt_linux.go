@@ -0,0 +1,54 @@
+//go:build linux
+
+/*
+File: section_virt_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-05 14:03:26
+
+Description: 系统信息的虚拟化部分
+*/
+
+package cli
+
+import (
+	"github.com/yhyj/eniac/general"
+)
+
+// virtSection 虚拟化和容器环境信息
+type virtSection struct{}
+
+// Name 参数名
+func (virtSection) Name() string {
+	return "virt"
+}
+
+// Part 部分名
+func (virtSection) Part() string {
+	return "Virt"
+}
+
+// Usage 参数说明
+func (virtSection) Usage() string {
+	return "Get Virtualization information"
+}
+
+// ResolveConfig 规范化存储数据单位
+func (virtSection) ResolveConfig(config *general.Config) {
+	config.Genealogy.Virt.DataUnit = configDataUnit("virt.data_unit", config.Genealogy.Virt.DataUnit)
+}
+
+// Collect 抓取虚拟化信息
+func (virtSection) Collect(config *general.Config) (any, error) {
+	return general.GetVirtInfo(), nil
+}
+
+// Items 获取虚拟化信息的输出项
+func (virtSection) Items(config *general.Config, data any) []string {
+	return config.Genealogy.Virt.Items
+}
+
+// Render 格式化虚拟化信息
+func (virtSection) Render(config *general.Config, data any) []map[string]string {
+	return []map[string]string{general.FormatVirtInfo(data.(general.VirtInfo), config.Genealogy.Virt.DataUnit)}
+}
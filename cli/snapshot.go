@@ -0,0 +1,225 @@
+/*
+File: snapshot.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-21 10:32:17
+
+Description: 子命令 'snapshot' 的实现
+
+- 快照为 JSON 文件，保存所有已注册部分的完整原始数据，容量单位为 Byte，时间为 Unix 时间戳
+- 快照格式变化时递增 snapshotVersion，读取时拒绝不支持的版本
+*/
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/yhyj/eniac/general"
+)
+
+// snapshotVersion 快照格式版本
+const snapshotVersion = 1
+
+// Snapshot 系统信息快照
+type Snapshot struct {
+	Version  int                        `json:"version"`  // 快照格式版本
+	Program  string                     `json:"program"`  // 生成快照的程序及其版本
+	Hostname string                     `json:"hostname"` // 主机名
+	Created  int64                      `json:"created"`  // 生成时间，Unix 时间戳
+	Sections map[string]json.RawMessage `json:"sections"` // 各部分的原始数据，键为部分的参数名
+}
+
+// SnapshotFileName 生成默认的快照文件名
+//
+// 返回：
+//   - 快照文件名，格式为 'eniac-<主机名>-<时间>.json'
+func SnapshotFileName() string {
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		hostname = "localhost"
+	}
+	return fmt.Sprintf("%s-%s-%s.json", strings.ToLower(general.Name), hostname, time.Now().Format("20060102150405"))
+}
+
+// TakeSnapshot 抓取所有部分的信息并保存为快照文件
+//
+// 参数：
+//   - config: 解析 toml 配置文件得到的配置项
+//   - snapshotFile: 快照文件路径
+func TakeSnapshot(config *general.Config, snapshotFile string) {
+	// 询问是否覆写已存在的快照文件
+	if general.FileExist(snapshotFile) {
+		question := color.Sprintf(general.OverWriteTips, snapshotFile)
+		overWrite, err := general.AreYouSure(general.QuestionText(question), false)
+		if err != nil {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+			return
+		}
+		if !overWrite {
+			return
+		}
+	}
+
+	snapshot, err := collectSnapshot(config)
+	if err != nil {
+		fileName, lineNo := general.GetCallerInfo()
+		color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+		return
+	}
+	if err := writeSnapshot(snapshotFile, snapshot); err != nil {
+		fileName, lineNo := general.GetCallerInfo()
+		color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+		return
+	}
+	color.Printf("Create %s: %s\n", general.PrimaryText(snapshotFile), general.SuccessText("snapshot saved"))
+}
+
+// collectSnapshot 抓取所有已注册部分的原始数据
+//
+// 参数：
+//   - config: 解析 toml 配置文件得到的配置项
+//
+// 返回：
+//   - 快照，抓取出错的部分仍保存已抓取到的数据
+//   - 错误信息
+func collectSnapshot(config *general.Config) (Snapshot, error) {
+	hostname, _ := os.Hostname()
+	snapshot := Snapshot{
+		Version:  snapshotVersion,
+		Program:  general.Name + " " + general.Version,
+		Hostname: hostname,
+		Created:  time.Now().Unix(),
+		Sections: make(map[string]json.RawMessage),
+	}
+
+	// 系统信息分配到不同的参数
+	sysInfo.GetSysInfo()
+
+	for _, section := range Sections() {
+		data, _ := section.Collect(config) // 原始数据，抓取出错时仍保存已抓取到的部分
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return snapshot, fmt.Errorf("section '%s': %w", section.Name(), err)
+		}
+		snapshot.Sections[section.Name()] = encoded
+	}
+
+	return snapshot, nil
+}
+
+// writeSnapshot 将快照写入文件
+//
+// 参数：
+//   - snapshotFile: 快照文件路径
+//   - snapshot: 快照
+//
+// 返回：
+//   - 错误信息
+func writeSnapshot(snapshotFile string, snapshot Snapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(snapshotFile, append(data, '\n'), 0644)
+}
+
+// readSnapshot 读取快照文件
+//
+// 参数：
+//   - snapshotFile: 快照文件路径
+//
+// 返回：
+//   - 快照
+//   - 错误信息，文件不是快照或快照版本不受支持时返回错误
+func readSnapshot(snapshotFile string) (Snapshot, error) {
+	var snapshot Snapshot
+
+	data, err := os.ReadFile(snapshotFile)
+	if err != nil {
+		return snapshot, err
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("%s is not a snapshot file: %w", snapshotFile, err)
+	}
+	switch {
+	case snapshot.Version == 0 || snapshot.Sections == nil:
+		return snapshot, fmt.Errorf("%s is not a snapshot file", snapshotFile)
+	case snapshot.Version > snapshotVersion:
+		return snapshot, fmt.Errorf("%s has snapshot version %d, only versions up to %d are supported", snapshotFile, snapshot.Version, snapshotVersion)
+	}
+
+	return snapshot, nil
+}
+
+// flattenSnapshotSection 将快照中一个部分的原始数据展开为设备及其输出项
+//
+//   - 单设备部分展开为一个设备，多设备部分每个元素为一个设备
+//   - 数字保持原样，嵌套的数据编码为紧凑的 JSON 字符串
+//
+// 参数：
+//   - raw: 该部分的原始数据，快照中没有该部分时为空
+//
+// 返回：
+//   - 设备列表，每个设备以输出项名为键
+//   - 错误信息
+func flattenSnapshotSection(raw json.RawMessage) ([]map[string]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var devices []map[string]string
+	switch value := value.(type) {
+	case map[string]any:
+		devices = append(devices, flattenSnapshotDevice(value))
+	case []any:
+		for _, element := range value {
+			if device, ok := element.(map[string]any); ok {
+				devices = append(devices, flattenSnapshotDevice(device))
+			}
+		}
+	}
+
+	return devices, nil
+}
+
+// flattenSnapshotDevice 将一个设备的原始数据转换为字符串
+//
+// 参数：
+//   - device: 设备的原始数据
+//
+// 返回：
+//   - 以输出项名为键的字符串数据
+func flattenSnapshotDevice(device map[string]any) map[string]string {
+	flattened := make(map[string]string, len(device))
+	for item, value := range device {
+		switch value := value.(type) {
+		case nil:
+			flattened[item] = ""
+		case string:
+			flattened[item] = value
+		case json.Number:
+			flattened[item] = value.String()
+		case bool:
+			flattened[item] = fmt.Sprint(value)
+		default:
+			encoded, _ := json.Marshal(value)
+			flattened[item] = string(encoded)
+		}
+	}
+	return flattened
+}
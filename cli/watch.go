@@ -0,0 +1,83 @@
+/*
+File: watch.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-16 15:20:43
+
+Description: 子命令 'watch' 的实现
+*/
+
+package cli
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gookit/color"
+	"github.com/yhyj/eniac/general"
+)
+
+// watchedSection 监视中的部分及其数据
+type watchedSection struct {
+	section   Section        // 部分
+	data      any            // 最近一次抓取的原始数据
+	oddColor  lipgloss.Color // 奇数行颜色，刷新时保持不变
+	evenColor lipgloss.Color // 偶数行颜色，刷新时保持不变
+}
+
+// WatchInformation 抓取信息并通过标签交互展示，按间隔刷新数据随时间变化的部分
+//
+//   - 数据不随时间变化的部分（如 BIOS、主板、产品）只抓取一次
+//
+// 参数：
+//   - config: 解析 toml 配置文件得到的配置项
+//   - interval: 刷新间隔
+func WatchInformation(config *general.Config, interval time.Duration) {
+	// Tab 参数
+	var (
+		tabName     []string         // 标签名称
+		tabContents []string         // 标签内容
+		watched     []watchedSection // 有输出项的部分
+	)
+
+	// 系统信息分配到不同的参数
+	sysInfo.GetSysInfo()
+
+	// 获取随机颜色切片
+	if config.Main.Colorful {
+		colors = general.GetColor(len(Sections()) * 2) // 因为分奇数/偶数行，所以要乘2
+	}
+
+	viewRows := tabViewRows()
+
+	for _, section := range Sections() {
+		data, _ := section.Collect(config) // 原始数据，抓取出错时仍输出已抓取到的部分
+
+		oddColor, evenColor := nextRowColors()
+		if content, ok := renderTabContent(config, section, data, viewRows, oddColor, evenColor); ok {
+			tabName = append(tabName, partName(section))
+			tabContents = append(tabContents, content)
+			watched = append(watched, watchedSection{section: section, data: data, oddColor: oddColor, evenColor: evenColor})
+		}
+	}
+
+	// 刷新函数，只重新抓取数据随时间变化的部分，其他部分使用已有数据重新渲染以适应终端尺寸
+	refresh := func() []string {
+		viewRows := tabViewRows()
+		contents := make([]string, 0, len(watched))
+		for index, item := range watched {
+			if dynamic, ok := item.section.(Dynamic); ok && dynamic.Dynamic() {
+				watched[index].data, _ = item.section.Collect(config)
+			}
+			content, _ := renderTabContent(config, item.section, watched[index].data, viewRows, item.oddColor, item.evenColor)
+			contents = append(contents, content)
+		}
+		return contents
+	}
+
+	// 输出 Tab
+	if err := general.TabWatcher(tabName, tabContents, config.Main.Cycle, interval, refresh); err != nil {
+		fileName, lineNo := general.GetCallerInfo()
+		color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+	}
+}
@@ -31,7 +31,7 @@ var configCmd = &cobra.Command{
 		// 检查参数
 		if !createFlag && !printFlag && !openFlag {
 			cmd.Help()
-			general.Notifier = append(general.Notifier, "Please refer to the above help information")
+			general.Notify("Please refer to the above help information")
 		}
 
 		// 创建配置文件流程
@@ -0,0 +1,64 @@
+/*
+File: diff.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-21 14:02:19
+
+Description: 执行子命令 'diff'
+*/
+
+package cmd
+
+import (
+	"github.com/gookit/color"
+	"github.com/spf13/cobra"
+	"github.com/yhyj/eniac/cli"
+	"github.com/yhyj/eniac/general"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff OLD [NEW]",
+	Short: "Compare two snapshots of system information",
+	Long:  `Compare two snapshots and show added, removed and changed items per section, compare with the current system when only one snapshot is given.`,
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		// 获取配置文件路径
+		configFile, _ := cmd.Flags().GetString("config")
+
+		// 读取配置文件
+		configTree, err := general.GetTomlConfig(configFile)
+		if err != nil {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+			return
+		}
+		// 获取配置项
+		config, err := general.LoadConfigToStruct(configTree)
+		if err != nil {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+			return
+		}
+
+		// 检查并规范化配置项
+		cli.ResolveConfig(config)
+
+		// 解析参数
+		volatileFlag, _ := cmd.Flags().GetBool("volatile")
+		newFile := ""
+		if len(args) == 2 {
+			newFile = args[1]
+		}
+
+		// 比较快照
+		cli.DiffSnapshots(config, args[0], newFile, volatileFlag)
+	},
+}
+
+func init() {
+	diffCmd.Flags().Bool("volatile", false, "Also compare items that change over time, such as load, used memory and uptime")
+
+	diffCmd.Flags().BoolP("help", "h", false, "help for diff command")
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,101 @@
+/*
+File: get.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2023-04-20 10:53:10
+
+Description: 执行子命令 'get'
+*/
+
+package cmd
+
+import (
+	"github.com/gookit/color"
+	"github.com/spf13/cobra"
+	"github.com/yhyj/eniac/cli"
+	"github.com/yhyj/eniac/general"
+)
+
+// getCmd represents the get command
+var getCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get system information",
+	Long:  `Get system information, no flag entry alternate mode.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 获取配置文件路径
+		configFile, _ := cmd.Flags().GetString("config")
+
+		// 读取配置文件
+		configTree, err := general.GetTomlConfig(configFile)
+		if err != nil {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+			return
+		}
+		// 获取配置项
+		config, err := general.LoadConfigToStruct(configTree)
+		if err != nil {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+			return
+		}
+
+		// 检查并规范化配置项
+		cli.ResolveConfig(config)
+
+		// 获取输出格式
+		outputFormat, _ := cmd.Flags().GetString("output")
+		if outputFormat != "table" && outputFormat != "json" {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), color.Sprintf("Unsupported output format '%s', expected 'table' or 'json'", outputFormat))
+			return
+		}
+
+		if cmd.Flags().NFlag() == 0 {
+			// 抓取系统信息
+			cli.GrabInformationToTab(config)
+		} else {
+			// 解析参数
+			allFlag, _ := cmd.Flags().GetBool("all")
+			allFlags := make(map[string]bool)
+			for _, section := range cli.Sections() {
+				if allFlag {
+					allFlags[section.Name()+"Flag"] = true
+				} else {
+					allFlags[section.Name()+"Flag"], _ = cmd.Flags().GetBool(section.Name())
+				}
+			}
+			if !allFlag {
+				allFlags["onlyFlag"], _ = cmd.Flags().GetBool("only")
+			}
+
+			// JSON 格式未指定任何部分时输出全部信息
+			if outputFormat == "json" {
+				if general.MapBoolCounter(allFlags, true) == 0 {
+					for _, section := range cli.Sections() {
+						allFlags[section.Name()+"Flag"] = true
+					}
+				}
+				cli.GrabInformationToJSON(config, allFlags)
+				return
+			}
+
+			// 抓取系统信息
+			cli.GrabInformationToTable(config, allFlags)
+
+			// 显示通知
+			general.Notification()
+		}
+	},
+}
+
+func init() {
+	getCmd.Flags().Bool("all", false, "Get all information")
+	for _, section := range cli.Sections() {
+		getCmd.Flags().Bool(section.Name(), false, section.Usage())
+	}
+	getCmd.Flags().StringP("output", "o", "table", "Output format, 'table' or 'json'")
+
+	getCmd.Flags().BoolP("help", "h", false, "help for get command")
+	rootCmd.AddCommand(getCmd)
+}
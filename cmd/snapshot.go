@@ -0,0 +1,62 @@
+/*
+File: snapshot.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-21 10:28:45
+
+Description: 执行子命令 'snapshot'
+*/
+
+package cmd
+
+import (
+	"github.com/gookit/color"
+	"github.com/spf13/cobra"
+	"github.com/yhyj/eniac/cli"
+	"github.com/yhyj/eniac/general"
+)
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save a snapshot of system information",
+	Long:  `Save all system information to a versioned snapshot file, which can be compared later with the diff command.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 获取配置文件路径
+		configFile, _ := cmd.Flags().GetString("config")
+
+		// 读取配置文件
+		configTree, err := general.GetTomlConfig(configFile)
+		if err != nil {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+			return
+		}
+		// 获取配置项
+		config, err := general.LoadConfigToStruct(configTree)
+		if err != nil {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+			return
+		}
+
+		// 检查并规范化配置项
+		cli.ResolveConfig(config)
+
+		// 获取快照文件路径
+		snapshotFile, _ := cmd.Flags().GetString("file")
+		if snapshotFile == "" {
+			snapshotFile = cli.SnapshotFileName()
+		}
+
+		// 保存快照
+		cli.TakeSnapshot(config, snapshotFile)
+	},
+}
+
+func init() {
+	snapshotCmd.Flags().StringP("file", "f", "", "Snapshot file (default 'eniac-<hostname>-<time>.json' in the current directory)")
+
+	snapshotCmd.Flags().BoolP("help", "h", false, "help for snapshot command")
+	rootCmd.AddCommand(snapshotCmd)
+}
@@ -0,0 +1,73 @@
+/*
+File: watch.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-16 15:41:08
+
+Description: 执行子命令 'watch'
+*/
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/spf13/cobra"
+	"github.com/yhyj/eniac/cli"
+	"github.com/yhyj/eniac/general"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:     "watch",
+	Aliases: []string{"top"},
+	Short:   "Watch system information",
+	Long:    `Watch system information in alternate mode, dynamic information is refreshed on an interval.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// 获取配置文件路径
+		configFile, _ := cmd.Flags().GetString("config")
+
+		// 读取配置文件
+		configTree, err := general.GetTomlConfig(configFile)
+		if err != nil {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+			return
+		}
+		// 获取配置项
+		config, err := general.LoadConfigToStruct(configTree)
+		if err != nil {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+			return
+		}
+
+		// 检查并规范化配置项
+		cli.ResolveConfig(config)
+
+		// 获取刷新间隔，参数优先于配置文件
+		interval := config.Main.Interval
+		if cmd.Flags().Changed("interval") {
+			interval, _ = cmd.Flags().GetInt("interval")
+		}
+		if interval <= 0 {
+			fileName, lineNo := general.GetCallerInfo()
+			color.Printf("%s %s %s\n", general.DangerText(general.ErrorInfoFlag), general.SecondaryText("[", fileName, ":", lineNo+1, "]"), color.Sprintf("Invalid refresh interval '%d', expected a positive number of seconds", interval))
+			return
+		}
+
+		// 监视系统信息
+		cli.WatchInformation(config, time.Duration(interval)*time.Second)
+
+		// 退出监视后显示通知
+		general.Notification()
+	},
+}
+
+func init() {
+	watchCmd.Flags().IntP("interval", "n", 0, "Refresh interval in seconds (default from 'main.interval' in the config file)")
+
+	watchCmd.Flags().BoolP("help", "h", false, "help for watch command")
+	rootCmd.AddCommand(watchCmd)
+}
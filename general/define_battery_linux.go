@@ -0,0 +1,139 @@
+//go:build linux
+
+/*
+File: define_battery_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-28 09:41:27
+
+Description: 读取电池和电源适配器信息
+
+- /sys/class/power_supply 下 type 为 'Battery' 的是电池，'Mains'、'USB' 为电源适配器
+- 电池容量可能以能量（energy_*，µWh）或电荷（charge_*，µAh）表示，后者按设计最低电压换算为能量
+- scope 为 'Device' 的电池属于鼠标、键盘等外设，不予显示
+*/
+
+package general
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// powerSupplyPath 电源的 sysfs 目录
+var powerSupplyPath = "/sys/class/power_supply"
+
+// BatteryInfo 电池信息，能量单位为 Wh
+type BatteryInfo struct {
+	Availability     string   `json:"BatteryAvailability"`     // 电池状态，Available 或 Unavailable
+	Name             string   `json:"BatteryName"`             // 电池名称，例如 'BAT0'
+	Status           string   `json:"BatteryStatus"`           // 充电状态：'Charging'、'Discharging'、'Full'、'Not charging' 等
+	Capacity         *float64 `json:"BatteryCapacity"`         // 剩余电量百分比
+	EnergyFull       *float64 `json:"BatteryEnergyFull"`       // 当前满充能量
+	EnergyFullDesign *float64 `json:"BatteryEnergyFullDesign"` // 设计满充能量
+	Health           *float64 `json:"BatteryHealth"`           // 健康度，即当前满充容量占设计容量的百分比
+	CycleCount       *int     `json:"BatteryCycleCount"`       // 循环次数
+	Manufacturer     string   `json:"BatteryManufacturer"`     // 制造商
+	Model            string   `json:"BatteryModel"`            // 型号
+	ACOnline         *bool    `json:"BatteryACOnline"`         // 电源适配器是否接通，没有电源适配器信息时为 nil
+}
+
+// GetBatteryInfo 获取电池信息
+//
+// 返回：
+//   - 电池信息，每块电池一个元素，没有电池时为一个 Availability 为 Unavailable 的元素
+//   - 错误信息
+func GetBatteryInfo() ([]BatteryInfo, error) {
+	entries, err := os.ReadDir(powerSupplyPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var (
+		batteryInfo []BatteryInfo
+		acOnline    *bool
+	)
+	for _, entry := range entries {
+		supplyPath := filepath.Join(powerSupplyPath, entry.Name())
+		switch readSensorValue(supplyPath, "type") {
+		case "Mains", "USB":
+			online := readSensorValue(supplyPath, "online")
+			if online == "" {
+				continue
+			}
+			// 任一电源适配器接通即视为接通
+			isOnline := online != "0"
+			if acOnline == nil || isOnline {
+				acOnline = &isOnline
+			}
+		case "Battery":
+			if readSensorValue(supplyPath, "scope") == "Device" {
+				continue
+			}
+			batteryInfo = append(batteryInfo, readBattery(supplyPath))
+		}
+	}
+
+	if len(batteryInfo) == 0 {
+		batteryInfo = append(batteryInfo, BatteryInfo{Availability: "Unavailable"})
+	}
+	for index := range batteryInfo {
+		batteryInfo[index].ACOnline = acOnline
+	}
+
+	return batteryInfo, nil
+}
+
+// readBattery 读取一块电池的信息
+//
+// 参数：
+//   - supplyPath: 电池目录
+//
+// 返回：
+//   - 电池信息，不含电源适配器状态
+func readBattery(supplyPath string) BatteryInfo {
+	battery := BatteryInfo{
+		Availability: "Available",
+		Name:         filepath.Base(supplyPath),
+		Status:       readSensorValue(supplyPath, "status"),
+		Manufacturer: readSensorValue(supplyPath, "manufacturer"),
+		Model:        readSensorValue(supplyPath, "model_name"),
+	}
+
+	if capacity, ok := readSensorNumber(supplyPath, "capacity", 1); ok {
+		battery.Capacity = &capacity
+	}
+	if cycleCount, err := strconv.Atoi(readSensorValue(supplyPath, "cycle_count")); err == nil {
+		battery.CycleCount = &cycleCount
+	}
+
+	// 以能量表示时单位为 µWh，换算为 Wh
+	full, fullOK := readSensorNumber(supplyPath, "energy_full", 1000000)
+	design, designOK := readSensorNumber(supplyPath, "energy_full_design", 1000000)
+	if fullOK && designOK && design > 0 {
+		health := full / design * 100
+		battery.Health = &health
+	}
+	if !fullOK && !designOK {
+		// 以电荷表示时单位为 µAh，健康度直接由电荷计算，能量需按设计最低电压（µV）换算
+		chargeFull, chargeFullOK := readSensorNumber(supplyPath, "charge_full", 1)
+		chargeDesign, chargeDesignOK := readSensorNumber(supplyPath, "charge_full_design", 1)
+		if chargeFullOK && chargeDesignOK && chargeDesign > 0 {
+			health := chargeFull / chargeDesign * 100
+			battery.Health = &health
+		}
+		if voltage, ok := readSensorNumber(supplyPath, "voltage_min_design", 1); ok {
+			full, fullOK = chargeFull*voltage/1e12, chargeFullOK
+			design, designOK = chargeDesign*voltage/1e12, chargeDesignOK
+		}
+	}
+	if fullOK {
+		battery.EnergyFull = &full
+	}
+	if designOK {
+		battery.EnergyFullDesign = &design
+	}
+
+	return battery
+}
@@ -0,0 +1,291 @@
+//go:build linux
+
+/*
+File: define_blockdev_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-23 09:36:18
+
+Description: 读取块设备的堆叠关系
+
+- /sys/class/block/<设备>/holders 列出堆叠在该设备上的设备（LVM、dm-crypt、mdraid 等），slaves 列出其下层设备
+- 文件系统类型、UUID 和卷标来自 udev 数据库，挂载点来自 /proc/self/mounts
+*/
+
+package general
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jaypipes/ghw"
+)
+
+var (
+	sysClassBlockPath = "/sys/class/block"  // 块设备的 sysfs 目录
+	udevDataPath      = "/run/udev/data"    // udev 数据库
+	mountsFile        = "/proc/self/mounts" // 当前挂载的文件系统
+)
+
+// blockMount 块设备的挂载信息
+type blockMount struct {
+	MountPoint string // 挂载点
+	FSType     string // 文件系统类型
+}
+
+// blockReader 读取块设备信息，缓存挂载信息以免重复读取
+type blockReader struct {
+	mounts map[string]blockMount // 挂载信息，键为内核设备名，例如 'dm-0'
+}
+
+// newBlockReader 创建块设备信息读取器
+//
+// 返回：
+//   - 块设备信息读取器，读取挂载信息失败时各设备均视为未挂载
+func newBlockReader() *blockReader {
+	mounts, _ := readBlockMounts(mountsFile)
+	return &blockReader{mounts: mounts}
+}
+
+// readBlockMounts 读取已挂载的块设备
+//
+// 参数：
+//   - filePath: mounts 文件路径
+//
+// 返回：
+//   - 挂载信息，键为内核设备名，同一设备多次挂载时只保留第一个挂载点
+//   - 错误信息
+func readBlockMounts(filePath string) (map[string]blockMount, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	mounts := make(map[string]blockMount)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !strings.HasPrefix(fields[0], "/dev/") {
+			continue
+		}
+		// /dev/mapper/* 等路径是指向内核设备的符号链接
+		device := fields[0]
+		if resolved, err := filepath.EvalSymlinks(device); err == nil {
+			device = resolved
+		}
+		name := filepath.Base(device)
+		if _, ok := mounts[name]; !ok {
+			mounts[name] = blockMount{MountPoint: unescapeMountField(fields[1]), FSType: fields[2]}
+		}
+	}
+
+	return mounts, scanner.Err()
+}
+
+// unescapeMountField 还原 mounts 文件中以八进制转义的空白字符，例如 '\040' 为空格
+//
+// 参数：
+//   - field: mounts 文件中的字段
+//
+// 返回：
+//   - 还原后的字段
+func unescapeMountField(field string) string {
+	var builder strings.Builder
+	for index := 0; index < len(field); index++ {
+		if field[index] == '\\' && index+3 < len(field) {
+			if value, err := strconv.ParseUint(field[index+1:index+4], 8, 8); err == nil {
+				builder.WriteByte(byte(value))
+				index += 3
+				continue
+			}
+		}
+		builder.WriteByte(field[index])
+	}
+	return builder.String()
+}
+
+// readUdevProperties 读取块设备在 udev 数据库中的属性
+//
+// 参数：
+//   - name: 内核设备名
+//
+// 返回：
+//   - 属性，例如 'ID_FS_TYPE'、'ID_FS_UUID'，没有 udev 数据库时为空
+func readUdevProperties(name string) map[string]string {
+	properties := make(map[string]string)
+
+	devNumber, err := os.ReadFile(filepath.Join(sysClassBlockPath, name, "dev"))
+	if err != nil {
+		return properties
+	}
+	file, err := os.Open(filepath.Join(udevDataPath, "b"+strings.TrimSpace(string(devNumber))))
+	if err != nil {
+		return properties
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if key, value, found := strings.Cut(strings.TrimPrefix(scanner.Text(), "E:"), "="); found {
+			properties[key] = value
+		}
+	}
+
+	return properties
+}
+
+// readSysfsValue 读取块设备的 sysfs 属性
+//
+// 参数：
+//   - name: 内核设备名
+//   - attribute: 属性的相对路径，例如 'size'、'dm/name'
+//
+// 返回：
+//   - 属性值，不存在时为空
+func readSysfsValue(name, attribute string) string {
+	value, err := os.ReadFile(filepath.Join(sysClassBlockPath, name, attribute))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(value))
+}
+
+// readSysfsLinks 读取块设备的 holders 或 slaves 目录
+//
+// 参数：
+//   - name: 内核设备名
+//   - relation: 'holders' 或 'slaves'
+//
+// 返回：
+//   - 相关设备的内核设备名
+func readSysfsLinks(name, relation string) []string {
+	entries, err := os.ReadDir(filepath.Join(sysClassBlockPath, name, relation))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+// stackedDeviceType 判断堆叠设备的类型
+//
+// 参数：
+//   - name: 内核设备名
+//
+// 返回：
+//   - 设备类型：'crypt'、'lvm'、'mpath'、'dm'，或 mdraid 的级别（如 'raid1'）
+func stackedDeviceType(name string) string {
+	if level := readSysfsValue(name, "md/level"); level != "" {
+		return level
+	}
+	uuid := readSysfsValue(name, "dm/uuid")
+	switch {
+	case strings.HasPrefix(uuid, "CRYPT-"):
+		return "crypt"
+	case strings.HasPrefix(uuid, "LVM-"):
+		return "lvm"
+	case strings.HasPrefix(uuid, "mpath-"):
+		return "mpath"
+	case uuid != "" || readSysfsValue(name, "dm/name") != "":
+		return "dm"
+	}
+	return "disk"
+}
+
+// partition 将 ghw 的分区信息转换为块设备，并读取堆叠在分区上的设备
+//
+// 参数：
+//   - partition: ghw 的分区信息
+//
+// 返回：
+//   - 分区
+func (reader *blockReader) partition(partition *ghw.Partition) BlockDevice {
+	// ghw 以 'unknown' 表示缺失的值
+	known := func(value string) string {
+		if value == "unknown" {
+			return ""
+		}
+		return value
+	}
+
+	properties := readUdevProperties(partition.Name)
+	device := BlockDevice{
+		Name:       partition.Name,
+		Type:       "part",
+		Label:      known(partition.FilesystemLabel),
+		UUID:       properties["ID_FS_UUID"],
+		FSType:     known(partition.Type),
+		Size:       partition.SizeBytes,
+		MountPoint: known(partition.MountPoint),
+		Holders:    reader.holders(partition.Name),
+	}
+	if device.Label == "" {
+		device.Label = known(partition.Label) // GPT 分区名
+	}
+	if device.UUID == "" {
+		device.UUID = known(partition.UUID) // 分区 UUID
+	}
+
+	return device
+}
+
+// holders 读取堆叠在块设备上的设备树
+//
+// 参数：
+//   - name: 内核设备名
+//
+// 返回：
+//   - 堆叠在该设备上的设备，每个设备包含其上层设备
+func (reader *blockReader) holders(name string) []BlockDevice {
+	return reader.readHolders(name, map[string]bool{name: true})
+}
+
+// readHolders 递归读取堆叠设备
+//
+// 参数：
+//   - name: 内核设备名
+//   - visited: 已读取的设备，防止异常的循环引用
+//
+// 返回：
+//   - 堆叠在该设备上的设备
+func (reader *blockReader) readHolders(name string, visited map[string]bool) []BlockDevice {
+	var devices []BlockDevice
+	for _, holder := range readSysfsLinks(name, "holders") {
+		if visited[holder] {
+			continue
+		}
+		visited[holder] = true
+
+		properties := readUdevProperties(holder)
+		mount := reader.mounts[holder]
+		sectors, _ := strconv.ParseUint(readSysfsValue(holder, "size"), 10, 64)
+
+		device := BlockDevice{
+			Name:       holder,
+			Type:       stackedDeviceType(holder),
+			Label:      properties["ID_FS_LABEL"],
+			UUID:       properties["ID_FS_UUID"],
+			FSType:     mount.FSType,
+			Size:       sectors * 512, // sysfs 中的 size 以 512 Byte 扇区为单位
+			MountPoint: mount.MountPoint,
+			Slaves:     readSysfsLinks(holder, "slaves"),
+			Holders:    reader.readHolders(holder, visited),
+		}
+		// device-mapper 设备使用其映射名，例如 'vg-home'、'luks-<UUID>'
+		if dmName := readSysfsValue(holder, "dm/name"); dmName != "" {
+			device.Name = dmName
+		}
+		if device.FSType == "" {
+			device.FSType = properties["ID_FS_TYPE"]
+		}
+		devices = append(devices, device)
+	}
+	return devices
+}
@@ -0,0 +1,23 @@
+//go:build darwin
+
+/*
+File: define_boot_darwin.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-09 11:05:32
+
+Description: 系统启动用时
+*/
+
+package general
+
+// GetBootPerformance 获取系统启动用时，macOS 不使用 systemd，启动用时记为 'unavailable'
+//
+// 参数：
+//   - blameCount: 启动用时排行显示的单元数
+//
+// 返回：
+//   - 启动用时
+func GetBootPerformance(blameCount int) BootPerformance {
+	return BootPerformance{StartTime: "unavailable"}
+}
@@ -0,0 +1,255 @@
+//go:build linux
+
+/*
+File: define_boot_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-09 09:41:18
+
+Description: 读取 systemd 记录的系统启动用时
+
+- 各阶段用时来自 systemd-analyze time，容器和虚拟机中通常只有部分阶段
+- 单元启动用时排行来自 systemd-analyze blame，关键链来自 systemd-analyze critical-chain
+- 不使用 systemd 时 systemd-analyze 不存在或无法连接管理器，启动用时记为 'unavailable'
+- 启动完成后结果不再变化，缓存以免 watch 模式中反复运行 systemd-analyze
+- 启动用时排行只在 blame_count 大于 0 时抓取，单元较多时 systemd-analyze blame 较慢
+*/
+
+package general
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// systemdAnalyzeRunner 运行 systemd-analyze 的函数
+var systemdAnalyzeRunner = RunCommandToBuffer
+
+// systemdTimespanUnits systemd 时间跨度的单位及对应的秒数
+var systemdTimespanUnits = map[string]float64{
+	"y":     31557600,
+	"month": 2629800,
+	"w":     604800,
+	"d":     86400,
+	"h":     3600,
+	"min":   60,
+	"s":     1,
+	"ms":    1e-3,
+	"us":    1e-6,
+	"μs":    1e-6,
+}
+
+// bootPerformanceCache 已完成启动的启动用时
+var bootPerformanceCache struct {
+	performance *BootPerformance // 启动用时，不含启动用时排行
+	blamed      bool             // 是否已抓取启动用时排行
+	blame       []BootUnit       // 启动用时排行，包含所有单元
+}
+
+// GetBootPerformance 获取系统启动用时
+//
+// 参数：
+//   - blameCount: 启动用时排行显示的单元数，为 0 时不运行 systemd-analyze blame
+//
+// 返回：
+//   - 启动用时
+func GetBootPerformance(blameCount int) BootPerformance {
+	cache := &bootPerformanceCache
+	if cache.performance == nil {
+		performance := readBootPerformance()
+		// 启动未完成时结果仍会变化，不缓存
+		if performance.StartTime == "not finished" {
+			if blameCount > 0 {
+				performance.Blame = limitBootBlame(readBootBlame(), blameCount)
+			}
+			return performance
+		}
+		cache.performance = &performance
+	}
+
+	performance := *cache.performance
+	if blameCount <= 0 || performance.StartTime == "unavailable" {
+		return performance
+	}
+	// 启动用时排行只在首次需要时抓取
+	if !cache.blamed {
+		cache.blame, cache.blamed = readBootBlame(), true
+	}
+	performance.Blame = limitBootBlame(cache.blame, blameCount)
+	return performance
+}
+
+// limitBootBlame 截取启动用时排行的前若干个单元
+//
+// 参数：
+//   - blame: 启动用时排行
+//   - blameCount: 保留的单元数
+//
+// 返回：
+//   - 截取后的启动用时排行
+func limitBootBlame(blame []BootUnit, blameCount int) []BootUnit {
+	return blame[:min(max(blameCount, 0), len(blame))]
+}
+
+// readBootPerformance 运行 systemd-analyze 读取各阶段用时和关键链
+//
+// 返回：
+//   - 启动用时，不含启动用时排行
+func readBootPerformance() BootPerformance {
+	var performance BootPerformance
+
+	// 启动未完成时退出码不为 0，以输出内容判断
+	stdout, stderr, _ := systemdAnalyzeRunner("systemd-analyze", []string{"time"})
+	switch {
+	case strings.HasPrefix(stdout, "Startup finished in "):
+		parseBootTime(stdout, &performance)
+	case strings.Contains(stdout+stderr, "Bootup is not yet finished"):
+		performance.StartTime = "not finished"
+	default:
+		performance.StartTime = "unavailable"
+		return performance
+	}
+
+	if stdout, _, err := systemdAnalyzeRunner("systemd-analyze", []string{"critical-chain", "--no-pager"}); err == nil {
+		performance.CriticalChain = parseCriticalChain(stdout)
+	}
+
+	return performance
+}
+
+// readBootBlame 运行 systemd-analyze blame 读取启动用时排行
+//
+// 返回：
+//   - 所有单元的启动用时，运行失败时为 nil
+func readBootBlame() []BootUnit {
+	stdout, _, err := systemdAnalyzeRunner("systemd-analyze", []string{"blame", "--no-pager"})
+	if err != nil {
+		return nil
+	}
+	return parseBootBlame(stdout)
+}
+
+// parseBootTime 解析 systemd-analyze time 的输出
+//
+//   - 第一行例如 'Startup finished in 6.1s (firmware) + 2.1s (loader) + 1.2s (kernel) + 2.3s (initrd) + 10.5s (userspace) = 22.4s'
+//
+// 参数：
+//   - output: systemd-analyze time 的输出
+//   - performance: 启动用时
+func parseBootTime(output string, performance *BootPerformance) {
+	firstLine, _, _ := strings.Cut(output, "\n")
+	phases, total, _ := strings.Cut(strings.TrimPrefix(firstLine, "Startup finished in "), " = ")
+	performance.StartTime = strings.TrimSpace(total)
+
+	for _, phase := range strings.Split(phases, " + ") {
+		span, name, found := strings.Cut(phase, " (")
+		if !found {
+			continue
+		}
+		seconds, ok := parseSystemdTimespan(span)
+		if !ok {
+			continue
+		}
+		switch strings.TrimSuffix(name, ")") {
+		case "firmware":
+			performance.Firmware = &seconds
+		case "loader":
+			performance.Loader = &seconds
+		case "kernel":
+			performance.Kernel = &seconds
+		case "initrd":
+			performance.Initrd = &seconds
+		case "userspace":
+			performance.Userspace = &seconds
+		}
+	}
+}
+
+// parseBootBlame 解析 systemd-analyze blame 的输出
+//
+//   - 每行例如 '1min 2.345s foo.service'，已按用时降序排列
+//
+// 参数：
+//   - output: systemd-analyze blame 的输出
+//
+// 返回：
+//   - 单元的启动用时
+func parseBootBlame(output string) []BootUnit {
+	var units []BootUnit
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		unit := BootUnit{Unit: fields[len(fields)-1]}
+		if seconds, ok := parseSystemdTimespan(strings.Join(fields[:len(fields)-1], " ")); ok {
+			unit.Time = &seconds
+		}
+		units = append(units, unit)
+	}
+	return units
+}
+
+// parseCriticalChain 解析 systemd-analyze critical-chain 的输出
+//
+//   - 说明文字之后每行例如 '└─cups.service @9.8s +700ms'，'@' 之后为进入活动状态的时间，'+' 之后为启动用时
+//
+// 参数：
+//   - output: systemd-analyze critical-chain 的输出
+//
+// 返回：
+//   - 关键链上的单元
+func parseCriticalChain(output string) []BootUnit {
+	var units []BootUnit
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimLeft(line, " │├└─")
+		if line == "" || strings.Contains(line, "printed after") {
+			continue
+		}
+		name, rest, _ := strings.Cut(line, " ")
+		unit := BootUnit{Unit: name}
+		activated, took, _ := strings.Cut(rest, " +")
+		if seconds, ok := parseSystemdTimespan(strings.TrimPrefix(activated, "@")); ok {
+			unit.Activated = &seconds
+		}
+		if seconds, ok := parseSystemdTimespan(took); ok {
+			unit.Time = &seconds
+		}
+		units = append(units, unit)
+	}
+	return units
+}
+
+// parseSystemdTimespan 解析 systemd 的时间跨度，例如 '1min 2.345s'、'700ms'
+//
+// 参数：
+//   - span: 时间跨度
+//
+// 返回：
+//   - 秒数
+//   - 是否解析成功
+func parseSystemdTimespan(span string) (float64, bool) {
+	fields := strings.Fields(span)
+	if len(fields) == 0 {
+		return 0, false
+	}
+
+	var seconds float64
+	for _, field := range fields {
+		index := strings.IndexFunc(field, unicode.IsLetter)
+		if index <= 0 {
+			return 0, false
+		}
+		value, err := strconv.ParseFloat(field[:index], 64)
+		if err != nil {
+			return 0, false
+		}
+		unit, ok := systemdTimespanUnits[field[index:]]
+		if !ok {
+			return 0, false
+		}
+		seconds += value * unit
+	}
+	return seconds, true
+}
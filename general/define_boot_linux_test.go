@@ -0,0 +1,353 @@
+//go:build linux
+
+/*
+File: define_boot_linux_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-11 19:03:46
+
+Description: 测试 systemd-analyze 输出的解析和启动用时的缓存
+*/
+
+package general
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// systemdAnalyzeTime 已完成启动时 systemd-analyze time 的输出
+const systemdAnalyzeTime = "Startup finished in 6.123s (firmware) + 2.045s (loader) + 1.234s (kernel) + 2.345s (initrd) + 1min 10.512s (userspace) = 1min 22.259s \n" +
+	"graphical.target reached after 1min 10.500s in userspace."
+
+// systemdAnalyzeBlame systemd-analyze blame 的输出
+const systemdAnalyzeBlame = " 1min 2.345s NetworkManager-wait-online.service\n" +
+	"     10.512s plymouth-quit-wait.service\n" +
+	"       700ms cups.service\n" +
+	"        12us boot-efi.mount\n"
+
+// systemdAnalyzeCriticalChain systemd-analyze critical-chain 的输出
+const systemdAnalyzeCriticalChain = "The time when unit became active or started is printed after the \"@\" character.\n" +
+	"The time the unit took to start is printed after the \"+\" character.\n" +
+	"\n" +
+	"graphical.target @10.500s\n" +
+	"└─multi-user.target @10.499s\n" +
+	"  └─cups.service @9.8s +700ms\n" +
+	"    └─network.target @9.7s\n" +
+	"      └─NetworkManager.service @1min 1.2s +1min 2.345s\n" +
+	"        └─basic.target\n"
+
+// bootSeconds 返回秒数指针
+func bootSeconds(value float64) *float64 {
+	return &value
+}
+
+// describeBootUnits 以可读形式列出单元，指针字段展开为数值
+func describeBootUnits(units []BootUnit) string {
+	var parts []string
+	for _, unit := range units {
+		parts = append(parts, fmt.Sprintf("%s @%s +%s", unit.Unit, describeSeconds(unit.Activated), describeSeconds(unit.Time)))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// describeSeconds 以可读形式显示秒数，nil 显示为 'nil'
+func describeSeconds(seconds *float64) string {
+	if seconds == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%.6f", *seconds)
+}
+
+// equalSeconds 比较秒数，允许浮点误差
+func equalSeconds(got, want *float64) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return math.Abs(*got-*want) < 1e-9
+}
+
+// equalBootUnits 比较单元列表，允许浮点误差
+func equalBootUnits(got, want []BootUnit) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for index := range got {
+		if got[index].Unit != want[index].Unit || !equalSeconds(got[index].Activated, want[index].Activated) || !equalSeconds(got[index].Time, want[index].Time) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParseSystemdTimespan(t *testing.T) {
+	tests := []struct {
+		span string
+		want *float64 // nil 表示解析失败
+	}{
+		{"700ms", bootSeconds(0.7)},
+		{"10.512s", bootSeconds(10.512)},
+		{"12us", bootSeconds(12e-6)},
+		{"12μs", bootSeconds(12e-6)},
+		{"1min 2.345s", bootSeconds(62.345)},
+		{"1h 2min 3s", bootSeconds(3723)},
+		{"1d 1h", bootSeconds(90000)},
+		{"  3s  ", bootSeconds(3)},
+		{"", nil},
+		{"s", nil},
+		{"12", nil},
+		{"12parsecs", nil},
+		{"1.2.3s", nil},
+		{"1min cups.service", nil},
+	}
+	for _, tt := range tests {
+		got, ok := parseSystemdTimespan(tt.span)
+		var gotPointer *float64
+		if ok {
+			gotPointer = &got
+		}
+		if !equalSeconds(gotPointer, tt.want) {
+			t.Errorf("parseSystemdTimespan(%q) = %s, want %s", tt.span, describeSeconds(gotPointer), describeSeconds(tt.want))
+		}
+	}
+}
+
+func TestParseBootTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		startTime string
+		firmware  *float64
+		loader    *float64
+		kernel    *float64
+		initrd    *float64
+		userspace *float64
+	}{
+		{
+			name:      "all phases",
+			output:    systemdAnalyzeTime,
+			startTime: "1min 22.259s",
+			firmware:  bootSeconds(6.123),
+			loader:    bootSeconds(2.045),
+			kernel:    bootSeconds(1.234),
+			initrd:    bootSeconds(2.345),
+			userspace: bootSeconds(70.512),
+		},
+		{
+			// 容器中只有用户空间阶段
+			name:      "container",
+			output:    "Startup finished in 235ms (userspace) = 235ms",
+			startTime: "235ms",
+			userspace: bootSeconds(0.235),
+		},
+		{
+			// 无法解析的阶段跳过，其他阶段不受影响
+			name:      "unparsable phase",
+			output:    "Startup finished in soon (kernel) + 3s (userspace) + 1s = 3s",
+			startTime: "3s",
+			userspace: bootSeconds(3),
+		},
+	}
+	for _, tt := range tests {
+		var performance BootPerformance
+		parseBootTime(tt.output, &performance)
+		if performance.StartTime != tt.startTime {
+			t.Errorf("%s: StartTime = %q, want %q", tt.name, performance.StartTime, tt.startTime)
+		}
+		phases := []struct {
+			name      string
+			got, want *float64
+		}{
+			{"firmware", performance.Firmware, tt.firmware},
+			{"loader", performance.Loader, tt.loader},
+			{"kernel", performance.Kernel, tt.kernel},
+			{"initrd", performance.Initrd, tt.initrd},
+			{"userspace", performance.Userspace, tt.userspace},
+		}
+		for _, phase := range phases {
+			if !equalSeconds(phase.got, phase.want) {
+				t.Errorf("%s: %s = %s, want %s", tt.name, phase.name, describeSeconds(phase.got), describeSeconds(phase.want))
+			}
+		}
+	}
+}
+
+func TestParseBootBlame(t *testing.T) {
+	want := []BootUnit{
+		{Unit: "NetworkManager-wait-online.service", Time: bootSeconds(62.345)},
+		{Unit: "plymouth-quit-wait.service", Time: bootSeconds(10.512)},
+		{Unit: "cups.service", Time: bootSeconds(0.7)},
+		{Unit: "boot-efi.mount", Time: bootSeconds(12e-6)},
+	}
+	if got := parseBootBlame(systemdAnalyzeBlame); !equalBootUnits(got, want) {
+		t.Errorf("parseBootBlame\n got: %s\nwant: %s", describeBootUnits(got), describeBootUnits(want))
+	}
+
+	// 用时无法解析时仍保留单元，只有一个字段的行跳过
+	want = []BootUnit{{Unit: "odd.service"}}
+	if got := parseBootBlame("soon odd.service\nlonely.service\n\n"); !equalBootUnits(got, want) {
+		t.Errorf("parseBootBlame with malformed lines\n got: %s\nwant: %s", describeBootUnits(got), describeBootUnits(want))
+	}
+
+	if got := parseBootBlame(""); got != nil {
+		t.Errorf("parseBootBlame(\"\") = %s, want nil", describeBootUnits(got))
+	}
+}
+
+func TestParseCriticalChain(t *testing.T) {
+	want := []BootUnit{
+		{Unit: "graphical.target", Activated: bootSeconds(10.5)},
+		{Unit: "multi-user.target", Activated: bootSeconds(10.499)},
+		{Unit: "cups.service", Activated: bootSeconds(9.8), Time: bootSeconds(0.7)},
+		{Unit: "network.target", Activated: bootSeconds(9.7)},
+		{Unit: "NetworkManager.service", Activated: bootSeconds(61.2), Time: bootSeconds(62.345)},
+		{Unit: "basic.target"},
+	}
+	if got := parseCriticalChain(systemdAnalyzeCriticalChain); !equalBootUnits(got, want) {
+		t.Errorf("parseCriticalChain\n got: %s\nwant: %s", describeBootUnits(got), describeBootUnits(want))
+	}
+
+	if got := parseCriticalChain(""); got != nil {
+		t.Errorf("parseCriticalChain(\"\") = %s, want nil", describeBootUnits(got))
+	}
+}
+
+// fakeSystemdAnalyze 模拟 systemd-analyze
+type fakeSystemdAnalyze struct {
+	finished bool           // 启动是否已完成
+	missing  bool           // systemd-analyze 是否不存在
+	calls    map[string]int // 各子命令的运行次数
+}
+
+// useFakeSystemdAnalyze 清空启动用时的缓存并替换 systemdAnalyzeRunner，测试结束后恢复
+//
+// 参数：
+//   - t: 测试对象
+//   - fake: 模拟的 systemd-analyze
+func useFakeSystemdAnalyze(t *testing.T, fake *fakeSystemdAnalyze) {
+	oldRunner := systemdAnalyzeRunner
+	bootPerformanceCache.performance, bootPerformanceCache.blamed, bootPerformanceCache.blame = nil, false, nil
+	fake.calls = make(map[string]int)
+	systemdAnalyzeRunner = func(command string, args []string) (string, string, error) {
+		fake.calls[args[0]]++
+		switch {
+		case fake.missing:
+			return "", "", errors.New(`exec: "systemd-analyze": executable file not found in $PATH`)
+		case !fake.finished && args[0] == "time":
+			return "", "Bootup is not yet finished (org.freedesktop.systemd1.Manager.FinishTimestampMonotonic=0).\nPlease try again later.", errors.New("exit status 1")
+		}
+		switch args[0] {
+		case "time":
+			return systemdAnalyzeTime, "", nil
+		case "blame":
+			return systemdAnalyzeBlame, "", nil
+		case "critical-chain":
+			return systemdAnalyzeCriticalChain, "", nil
+		}
+		return "", "Unknown command verb " + args[0], errors.New("exit status 1")
+	}
+	t.Cleanup(func() {
+		systemdAnalyzeRunner = oldRunner
+		bootPerformanceCache.performance, bootPerformanceCache.blamed, bootPerformanceCache.blame = nil, false, nil
+	})
+}
+
+func TestGetBootPerformanceFinished(t *testing.T) {
+	fake := &fakeSystemdAnalyze{finished: true}
+	useFakeSystemdAnalyze(t, fake)
+
+	performance := GetBootPerformance(2)
+	if performance.StartTime != "1min 22.259s" || !equalSeconds(performance.Userspace, bootSeconds(70.512)) {
+		t.Errorf("StartTime = %q, Userspace = %s", performance.StartTime, describeSeconds(performance.Userspace))
+	}
+	wantBlame := parseBootBlame(systemdAnalyzeBlame)[:2]
+	if !equalBootUnits(performance.Blame, wantBlame) {
+		t.Errorf("Blame = %s, want %s", describeBootUnits(performance.Blame), describeBootUnits(wantBlame))
+	}
+	if len(performance.CriticalChain) != 6 {
+		t.Errorf("got %d units in the critical chain, want 6", len(performance.CriticalChain))
+	}
+
+	// 启动已完成，再次获取时使用缓存，排行数量可以不同
+	for range 3 {
+		if performance = GetBootPerformance(10); len(performance.Blame) != 4 {
+			t.Errorf("cached Blame has %d units, want 4", len(performance.Blame))
+		}
+	}
+	for _, subcommand := range []string{"time", "blame", "critical-chain"} {
+		if fake.calls[subcommand] != 1 {
+			t.Errorf("systemd-analyze %s ran %d times, want 1", subcommand, fake.calls[subcommand])
+		}
+	}
+}
+
+func TestGetBootPerformanceWithoutBlame(t *testing.T) {
+	fake := &fakeSystemdAnalyze{finished: true}
+	useFakeSystemdAnalyze(t, fake)
+
+	for range 3 {
+		if performance := GetBootPerformance(0); performance.Blame != nil || performance.StartTime != "1min 22.259s" {
+			t.Errorf("blame_count 0: StartTime = %q, Blame = %s", performance.StartTime, describeBootUnits(performance.Blame))
+		}
+	}
+	if fake.calls["blame"] != 0 {
+		t.Errorf("systemd-analyze blame ran %d times with blame_count 0", fake.calls["blame"])
+	}
+
+	// 之后需要排行时只抓取一次
+	for range 3 {
+		if performance := GetBootPerformance(1); len(performance.Blame) != 1 {
+			t.Errorf("got %d units in Blame, want 1", len(performance.Blame))
+		}
+	}
+	if fake.calls["blame"] != 1 || fake.calls["time"] != 1 {
+		t.Errorf("systemd-analyze ran %v, want time and blame once", fake.calls)
+	}
+}
+
+func TestGetBootPerformanceNotFinished(t *testing.T) {
+	fake := &fakeSystemdAnalyze{}
+	useFakeSystemdAnalyze(t, fake)
+
+	performance := GetBootPerformance(2)
+	if performance.StartTime != "not finished" || performance.Userspace != nil {
+		t.Errorf("StartTime = %q, Userspace = %s, want \"not finished\" without phases", performance.StartTime, describeSeconds(performance.Userspace))
+	}
+	// 启动未完成时排行和关键链仍然可用
+	if len(performance.Blame) != 2 || len(performance.CriticalChain) != 6 {
+		t.Errorf("got %d units in Blame and %d in the critical chain, want 2 and 6", len(performance.Blame), len(performance.CriticalChain))
+	}
+	if performance = GetBootPerformance(0); performance.Blame != nil {
+		t.Errorf("blame_count 0: Blame = %s, want nil", describeBootUnits(performance.Blame))
+	}
+	if fake.calls["time"] != 2 || fake.calls["blame"] != 1 {
+		t.Errorf("systemd-analyze ran %v, want time twice and blame once", fake.calls)
+	}
+
+	// 启动完成后的结果不受之前的影响
+	fake.finished = true
+	if performance = GetBootPerformance(2); performance.StartTime != "1min 22.259s" || len(performance.Blame) != 2 {
+		t.Errorf("after finishing: StartTime = %q, %d units in Blame", performance.StartTime, len(performance.Blame))
+	}
+	if fake.calls["time"] != 3 || fake.calls["blame"] != 2 {
+		t.Errorf("systemd-analyze ran %v, want time 3 times and blame twice", fake.calls)
+	}
+}
+
+func TestGetBootPerformanceUnavailable(t *testing.T) {
+	fake := &fakeSystemdAnalyze{missing: true}
+	useFakeSystemdAnalyze(t, fake)
+
+	for range 3 {
+		performance := GetBootPerformance(10)
+		if performance.StartTime != "unavailable" || performance.Blame != nil || performance.CriticalChain != nil {
+			t.Errorf("StartTime = %q, Blame = %s, CriticalChain = %s", performance.StartTime, describeBootUnits(performance.Blame), describeBootUnits(performance.CriticalChain))
+		}
+	}
+	if fake.calls["time"] != 1 || fake.calls["blame"] != 0 || fake.calls["critical-chain"] != 0 {
+		t.Errorf("systemd-analyze ran %v, want only time once", fake.calls)
+	}
+}
@@ -0,0 +1,30 @@
+//go:build darwin
+
+/*
+File: define_cgroup_darwin.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-06 10:21:47
+
+Description: cgroup 的资源限制
+*/
+
+package general
+
+// readCgroupMemory 读取 cgroup 的内存限制和用量，macOS 没有 cgroup，返回空值
+//
+// 返回：
+//   - 内存限制
+//   - 内存用量
+func readCgroupMemory() (*uint64, *uint64) {
+	return nil, nil
+}
+
+// readCgroupCPU 读取 cgroup 的 CPU 配额和累计 CPU 时间，macOS 没有 cgroup，返回空值
+//
+// 返回：
+//   - CPU 配额
+//   - 累计 CPU 时间
+func readCgroupCPU() (*float64, *float64) {
+	return nil, nil
+}
@@ -0,0 +1,354 @@
+//go:build linux
+
+/*
+File: define_cgroup_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-05 09:18:44
+
+Description: 读取当前进程所在 cgroup 的资源限制和用量
+
+- 进程所在的 cgroup 来自 /proc/self/cgroup，各层级的挂载点来自 /proc/self/mountinfo
+- cgroup v2 的限制在 cpu.max 和 memory.max，v1 在 cpu 控制器的 cpu.cfs_quota_us、cpu.cfs_period_us 和 memory 控制器的 memory.limit_in_bytes
+- 上级 cgroup 的限制同样生效，因此从进程所在 cgroup 向上直到挂载点取最小值
+- 内存用量来自 memory.current（v1 为 memory.usage_in_bytes），CPU 时间来自 cpu.stat（v1 为 cpuacct.usage），cgroup 文件无需 root 权限即可读取
+*/
+
+package general
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	procSelfCgroupFile    = "/proc/self/cgroup"    // 当前进程所在的 cgroup
+	procSelfMountinfoFile = "/proc/self/mountinfo" // 当前进程可见的挂载点
+)
+
+// cgroupUnlimited cgroup v1 中不小于该值的内存限制表示不限制（v1 以按页对齐的最大 int64 表示不限制）
+const cgroupUnlimited = 1 << 62
+
+// cgroupHierarchy 一个 cgroup 层级
+type cgroupHierarchy struct {
+	version    int    // 版本：1 或 2
+	mountPoint string // 挂载点
+	root       string // 挂载的层级内路径
+	path       string // 当前进程在层级内的路径
+}
+
+// readCgroupHierarchies 读取当前进程所在的 cgroup 层级
+//
+// 返回：
+//   - cgroup 层级，v1 的键为控制器名，v2 的键为空字符串，没有挂载的层级不包括在内
+func readCgroupHierarchies() map[string]cgroupHierarchy {
+	hierarchies := make(map[string]cgroupHierarchy)
+
+	// 每行格式为 '层级 ID:控制器列表:路径'，v2 的层级 ID 为 0 且控制器列表为空
+	paths := make(map[string]string)
+	if file, err := os.Open(procSelfCgroupFile); err == nil {
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			fields := strings.SplitN(scanner.Text(), ":", 3)
+			if len(fields) != 3 {
+				continue
+			}
+			if fields[0] == "0" && fields[1] == "" {
+				paths[""] = fields[2]
+				continue
+			}
+			for _, controller := range strings.Split(fields[1], ",") {
+				paths[controller] = fields[2]
+			}
+		}
+		file.Close()
+	}
+
+	// 每行格式为 'ID 父 ID 设备号 层级内路径 挂载点 挂载选项 [可选字段...] - 文件系统类型 来源 超级块选项'
+	file, err := os.Open(procSelfMountinfoFile)
+	if err != nil {
+		return hierarchies
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		mountFields, fsFields, found := strings.Cut(scanner.Text(), " - ")
+		if !found {
+			continue
+		}
+		fields, fsInfo := strings.Fields(mountFields), strings.Fields(fsFields)
+		if len(fields) < 5 || len(fsInfo) < 3 {
+			continue
+		}
+		switch fsInfo[0] {
+		case "cgroup2":
+			if path, ok := paths[""]; ok {
+				hierarchies[""] = cgroupHierarchy{version: 2, mountPoint: fields[4], root: fields[3], path: path}
+			}
+		case "cgroup":
+			for _, option := range strings.Split(fsInfo[2], ",") {
+				if path, ok := paths[option]; ok {
+					hierarchies[option] = cgroupHierarchy{version: 1, mountPoint: fields[4], root: fields[3], path: path}
+				}
+			}
+		}
+	}
+
+	return hierarchies
+}
+
+// cgroupMode 获取 cgroup 的工作模式
+//
+// 参数：
+//   - hierarchies: cgroup 层级
+//
+// 返回：
+//   - 'v1'、'v2' 或 'hybrid'（v1 控制器与不带控制器的 v2 层级共存），没有挂载 cgroup 时为空
+func cgroupMode(hierarchies map[string]cgroupHierarchy) string {
+	_, hasV2 := hierarchies[""]
+	hasV1 := len(hierarchies) > 1 || (len(hierarchies) == 1 && !hasV2)
+	switch {
+	case hasV1 && hasV2:
+		return "hybrid"
+	case hasV1:
+		return "v1"
+	case hasV2:
+		return "v2"
+	}
+	return ""
+}
+
+// cgroupDirs 获取控制器从当前进程所在 cgroup 到挂载点的各级目录
+//
+// 参数：
+//   - hierarchies: cgroup 层级
+//   - controller: 控制器名，例如 'cpu'、'memory'
+//
+// 返回：
+//   - 目录列表，由下至上排列，不存在的目录不包括在内
+//   - 控制器所在层级的版本，控制器不可用时为 0
+func cgroupDirs(hierarchies map[string]cgroupHierarchy, controller string) ([]string, int) {
+	hierarchy, ok := hierarchies[controller]
+	if !ok {
+		// v2 的控制器在 cgroup.controllers 中列出
+		hierarchy, ok = hierarchies[""]
+		if !ok || !hasController(filepath.Join(hierarchy.mountPoint, "cgroup.controllers"), controller) {
+			return nil, 0
+		}
+	}
+
+	// 挂载的是层级的子目录时（例如容器中），进程路径需去掉该子目录；进程在挂载范围之外时只能使用挂载点
+	relative := hierarchy.path
+	if hierarchy.root != "/" {
+		if trimmed, found := strings.CutPrefix(relative, hierarchy.root); found {
+			relative = trimmed
+		} else {
+			relative = "/"
+		}
+	}
+	if strings.HasPrefix(relative, "/..") {
+		relative = "/"
+	}
+
+	var dirs []string
+	for dir := filepath.Join(hierarchy.mountPoint, relative); ; dir = filepath.Dir(dir) {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+		if dir == hierarchy.mountPoint || len(dir) < len(hierarchy.mountPoint) {
+			break
+		}
+	}
+	return dirs, hierarchy.version
+}
+
+// hasController 判断 cgroup.controllers 文件中是否列出了控制器
+//
+// 参数：
+//   - filePath: cgroup.controllers 文件路径
+//   - controller: 控制器名
+//
+// 返回：
+//   - 列出了返回 true，否则返回 false
+func hasController(filePath, controller string) bool {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return false
+	}
+	return indexOf(strings.Fields(string(data)), controller) >= 0
+}
+
+// readCgroupCPUQuota 读取 CPU 配额
+//
+// 参数：
+//   - hierarchies: cgroup 层级
+//
+// 返回：
+//   - 可用的 CPU 数，例如 1.5 表示每个调度周期最多使用 1.5 个 CPU 的时间，不限制时为 nil
+func readCgroupCPUQuota(hierarchies map[string]cgroupHierarchy) *float64 {
+	dirs, version := cgroupDirs(hierarchies, "cpu")
+
+	var quota *float64
+	for _, dir := range dirs {
+		var limit, period float64
+		switch version {
+		case 1:
+			var err error
+			if limit, err = strconv.ParseFloat(readSensorValue(dir, "cpu.cfs_quota_us"), 64); err != nil || limit < 0 {
+				continue
+			}
+			period, _ = strconv.ParseFloat(readSensorValue(dir, "cpu.cfs_period_us"), 64)
+		case 2:
+			// 格式为 '配额 周期'，配额为 'max' 时不限制
+			fields := strings.Fields(readSensorValue(dir, "cpu.max"))
+			if len(fields) != 2 {
+				continue
+			}
+			var err error
+			if limit, err = strconv.ParseFloat(fields[0], 64); err != nil {
+				continue
+			}
+			period, _ = strconv.ParseFloat(fields[1], 64)
+		}
+		if period <= 0 {
+			continue
+		}
+		if cpus := limit / period; quota == nil || cpus < *quota {
+			quota = &cpus
+		}
+	}
+	return quota
+}
+
+// readCgroupMemoryLimit 读取内存限制
+//
+// 参数：
+//   - hierarchies: cgroup 层级
+//
+// 返回：
+//   - 内存限制，单位为 Byte，不限制时为 nil
+func readCgroupMemoryLimit(hierarchies map[string]cgroupHierarchy) *uint64 {
+	dirs, version := cgroupDirs(hierarchies, "memory")
+
+	file := "memory.max"
+	if version == 1 {
+		file = "memory.limit_in_bytes"
+	}
+	var limit *uint64
+	for _, dir := range dirs {
+		// v2 不限制时为 'max'
+		value, err := strconv.ParseUint(readSensorValue(dir, file), 10, 64)
+		if err != nil || value >= cgroupUnlimited {
+			continue
+		}
+		if limit == nil || value < *limit {
+			limit = &value
+		}
+	}
+	return limit
+}
+
+// cgroupScoped 判断是否需要报告 cgroup 的数值：在容器中，或者所在 cgroup 有资源限制
+//
+// 参数：
+//   - limited: 所在 cgroup 是否有资源限制
+//
+// 返回：
+//   - 需要报告返回 true，否则返回 false
+func cgroupScoped(limited bool) bool {
+	return limited || detectContainer() != ""
+}
+
+// readCgroupStat 读取 cgroup 中 '键 值' 格式文件的一个值
+//
+// 参数：
+//   - dir: cgroup 目录
+//   - file: 文件名，例如 'memory.stat'、'cpu.stat'
+//   - key: 键
+//
+// 返回：
+//   - 值
+//   - 读取到返回 true，否则返回 false
+func readCgroupStat(dir, file, key string) (uint64, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return 0, false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, found := strings.CutPrefix(line, key+" "); found {
+			number, err := strconv.ParseUint(value, 10, 64)
+			return number, err == nil
+		}
+	}
+	return 0, false
+}
+
+// readCgroupMemory 读取当前进程所在 cgroup 的内存限制和用量
+//
+//   - 用量与 'docker stats' 一致，不含可回收的非活跃文件缓存
+//
+// 返回：
+//   - 内存限制，单位为 Byte，不限制时为 nil
+//   - 内存用量，单位为 Byte，不在容器中且没有限制时为 nil
+func readCgroupMemory() (*uint64, *uint64) {
+	hierarchies := readCgroupHierarchies()
+	limit := readCgroupMemoryLimit(hierarchies)
+	if !cgroupScoped(limit != nil) {
+		return nil, nil
+	}
+
+	dirs, version := cgroupDirs(hierarchies, "memory")
+	if len(dirs) == 0 {
+		return limit, nil
+	}
+	usageFile, inactiveKey := "memory.current", "inactive_file"
+	if version == 1 {
+		usageFile, inactiveKey = "memory.usage_in_bytes", "total_inactive_file"
+	}
+	used, err := strconv.ParseUint(readSensorValue(dirs[0], usageFile), 10, 64)
+	if err != nil {
+		return limit, nil
+	}
+	if inactive, ok := readCgroupStat(dirs[0], "memory.stat", inactiveKey); ok && inactive < used {
+		used -= inactive
+	}
+
+	return limit, &used
+}
+
+// readCgroupCPU 读取当前进程所在 cgroup 的 CPU 配额和累计 CPU 时间
+//
+// 返回：
+//   - CPU 配额，单位为 CPU 数，不限制时为 nil
+//   - 累计 CPU 时间，单位为秒，不在容器中且没有配额时为 nil
+func readCgroupCPU() (*float64, *float64) {
+	hierarchies := readCgroupHierarchies()
+	quota := readCgroupCPUQuota(hierarchies)
+	if !cgroupScoped(quota != nil) {
+		return nil, nil
+	}
+
+	// v1 的 CPU 时间在 cpuacct 控制器，单位为纳秒；v2 的在 cpu.stat，无论是否启用 cpu 控制器都存在，单位为微秒
+	var seconds float64
+	if dirs, version := cgroupDirs(hierarchies, "cpuacct"); version == 1 && len(dirs) > 0 {
+		nanoseconds, err := strconv.ParseUint(readSensorValue(dirs[0], "cpuacct.usage"), 10, 64)
+		if err != nil {
+			return quota, nil
+		}
+		seconds = float64(nanoseconds) / 1e9
+	} else if dirs, version := cgroupDirs(hierarchies, ""); version == 2 && len(dirs) > 0 {
+		microseconds, ok := readCgroupStat(dirs[0], "cpu.stat", "usage_usec")
+		if !ok {
+			return quota, nil
+		}
+		seconds = float64(microseconds) / 1e6
+	} else {
+		return quota, nil
+	}
+
+	return quota, &seconds
+}
@@ -0,0 +1,364 @@
+//go:build linux
+
+/*
+File: define_cgroup_linux_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-12 14:36:05
+
+Description: 测试 cgroup 层级、资源限制和用量的读取，在临时目录中构造 /proc/self/cgroup、mountinfo 和 cgroupfs
+*/
+
+package general
+
+import (
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// useCgroupFixture 在临时目录中构造 cgroup 相关文件并替换读取路径
+//
+//   - mountinfo 中的 '{root}' 替换为临时目录
+//   - 容器检测的标志文件指向不存在的路径，相关环境变量置空，检测结果为不在容器中
+//
+// 参数：
+//   - t: 测试对象
+//   - cgroup: /proc/self/cgroup 的内容
+//   - mountinfo: /proc/self/mountinfo 的内容
+//   - files: cgroupfs 中的文件，键为相对于临时目录的路径
+//
+// 返回：
+//   - 临时目录
+func useCgroupFixture(t *testing.T, cgroup, mountinfo string, files map[string]string) string {
+	root := t.TempDir()
+	procPath := filepath.Join(root, "proc")
+	writeSensorFiles(t, procPath, map[string]string{
+		"cgroup":    cgroup,
+		"mountinfo": strings.ReplaceAll(mountinfo, "{root}", root),
+	})
+	for name, content := range files {
+		writeSensorFiles(t, filepath.Join(root, filepath.Dir(name)), map[string]string{filepath.Base(name): content})
+	}
+
+	oldCgroupFile, oldMountinfoFile := procSelfCgroupFile, procSelfMountinfoFile
+	oldDockerEnv, oldContainerEnv, oldInitEnviron, oldInitCgroup := dockerEnvFile, containerEnvFile, procInitEnvironFile, procInitCgroupFile
+	procSelfCgroupFile, procSelfMountinfoFile = filepath.Join(procPath, "cgroup"), filepath.Join(procPath, "mountinfo")
+	missing := filepath.Join(root, "missing")
+	dockerEnvFile, containerEnvFile = filepath.Join(missing, ".dockerenv"), filepath.Join(missing, ".containerenv")
+	procInitEnvironFile, procInitCgroupFile = filepath.Join(missing, "environ"), filepath.Join(missing, "cgroup")
+	t.Setenv("container", "")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Cleanup(func() {
+		procSelfCgroupFile, procSelfMountinfoFile = oldCgroupFile, oldMountinfoFile
+		dockerEnvFile, containerEnvFile, procInitEnvironFile, procInitCgroupFile = oldDockerEnv, oldContainerEnv, oldInitEnviron, oldInitCgroup
+	})
+
+	return root
+}
+
+// relativeCgroupDirs 获取控制器的各级目录，去掉临时目录前缀
+func relativeCgroupDirs(root string, hierarchies map[string]cgroupHierarchy, controller string) ([]string, int) {
+	dirs, version := cgroupDirs(hierarchies, controller)
+	for index, dir := range dirs {
+		dirs[index] = strings.TrimPrefix(dir, root)
+	}
+	return dirs, version
+}
+
+// describeFloat 以可读形式显示浮点数，nil 显示为 'nil'
+func describeFloat(value *float64) string {
+	if value == nil {
+		return "nil"
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+// describeUint 以可读形式显示整数，nil 显示为 'nil'
+func describeUint(value *uint64) string {
+	if value == nil {
+		return "nil"
+	}
+	return strconv.FormatUint(*value, 10)
+}
+
+// cgroupMountinfoOther 与 cgroup 无关的挂载点，以及无法解析的行
+const cgroupMountinfoOther = "22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw\n" +
+	"25 22 0:21 / {root}/proc rw,nosuid shared:12 - proc proc rw\n" +
+	"garbage\n"
+
+func TestCgroupV2(t *testing.T) {
+	const scope = "/sys/fs/cgroup/user.slice/user-1000.slice/session-2.scope"
+	root := useCgroupFixture(t,
+		"0::/user.slice/user-1000.slice/session-2.scope\n",
+		cgroupMountinfoOther+"30 23 0:26 / {root}/sys/fs/cgroup rw,nosuid,nodev,noexec,relatime shared:4 - cgroup2 cgroup2 rw,nsdelegate,memory_recursiveprot\n",
+		map[string]string{
+			"sys/fs/cgroup/cgroup.controllers":                      "cpuset cpu io memory pids",
+			"sys/fs/cgroup/user.slice/memory.max":                   "8589934592",
+			"sys/fs/cgroup/user.slice/cpu.max":                      "200000 100000",
+			"sys/fs/cgroup/user.slice/user-1000.slice/memory.max":   "max",
+			"sys/fs/cgroup/user.slice/user-1000.slice/cpu.max":      "max 100000",
+			strings.TrimPrefix(scope, "/") + "/memory.max":          "4294967296",
+			strings.TrimPrefix(scope, "/") + "/cpu.max":             "150000 100000",
+			strings.TrimPrefix(scope, "/") + "/memory.current":      "1073741824",
+			strings.TrimPrefix(scope, "/") + "/memory.stat":         "anon 805306368\nfile 268435456\ninactive_file 268435456\nactive_file 0",
+			strings.TrimPrefix(scope, "/") + "/cpu.stat":            "usage_usec 2500000\nuser_usec 2000000\nsystem_usec 500000",
+			"sys/fs/cgroup/user.slice/user-1000.slice/cgroup.procs": "",
+			"sys/fs/cgroup/system.slice/sshd.service/memory.max":    "1",
+		},
+	)
+
+	hierarchies := readCgroupHierarchies()
+	want := map[string]cgroupHierarchy{
+		"": {version: 2, mountPoint: filepath.Join(root, "sys/fs/cgroup"), root: "/", path: "/user.slice/user-1000.slice/session-2.scope"},
+	}
+	if !reflect.DeepEqual(hierarchies, want) {
+		t.Fatalf("hierarchies = %+v, want %+v", hierarchies, want)
+	}
+	if mode := cgroupMode(hierarchies); mode != "v2" {
+		t.Errorf("cgroupMode = %q, want \"v2\"", mode)
+	}
+
+	dirs, version := relativeCgroupDirs(root, hierarchies, "memory")
+	wantDirs := []string{scope, "/sys/fs/cgroup/user.slice/user-1000.slice", "/sys/fs/cgroup/user.slice", "/sys/fs/cgroup"}
+	if !reflect.DeepEqual(dirs, wantDirs) || version != 2 {
+		t.Errorf("cgroupDirs(memory) = %q, v%d, want %q, v2", dirs, version, wantDirs)
+	}
+	// 没有启用的控制器不可用
+	if dirs, version := cgroupDirs(hierarchies, "hugetlb"); dirs != nil || version != 0 {
+		t.Errorf("cgroupDirs(hugetlb) = %q, v%d, want none", dirs, version)
+	}
+
+	// 取各级中最小的限制，'max' 表示不限制
+	if quota := readCgroupCPUQuota(hierarchies); describeFloat(quota) != "1.5" {
+		t.Errorf("readCgroupCPUQuota = %s, want 1.5", describeFloat(quota))
+	}
+	if limit := readCgroupMemoryLimit(hierarchies); describeUint(limit) != "4294967296" {
+		t.Errorf("readCgroupMemoryLimit = %s, want 4294967296", describeUint(limit))
+	}
+
+	// 用量不含非活跃文件缓存
+	limit, used := readCgroupMemory()
+	if describeUint(limit) != "4294967296" || describeUint(used) != "805306368" {
+		t.Errorf("readCgroupMemory = %s, %s, want 4294967296, 805306368", describeUint(limit), describeUint(used))
+	}
+	quota, seconds := readCgroupCPU()
+	if describeFloat(quota) != "1.5" || describeFloat(seconds) != "2.5" {
+		t.Errorf("readCgroupCPU = %s, %s, want 1.5, 2.5", describeFloat(quota), describeFloat(seconds))
+	}
+}
+
+func TestCgroupV1(t *testing.T) {
+	const (
+		memoryPath = "sys/fs/cgroup/memory"
+		cpuPath    = "sys/fs/cgroup/cpu,cpuacct"
+		unlimited  = "9223372036854771712" // 按页对齐的最大 int64
+	)
+	root := useCgroupFixture(t,
+		"12:memory:/docker/abc\n11:cpu,cpuacct:/docker/abc\n10:pids:/docker/abc\n1:name=systemd:/docker/abc\n",
+		cgroupMountinfoOther+
+			"31 23 0:27 / {root}/sys/fs/cgroup rw shared:5 - tmpfs tmpfs ro,mode=755\n"+
+			"32 31 0:28 / {root}/sys/fs/cgroup/systemd rw,nosuid shared:6 - cgroup cgroup rw,xattr,name=systemd\n"+
+			"33 31 0:29 / {root}/sys/fs/cgroup/memory rw,nosuid shared:7 - cgroup cgroup rw,memory\n"+
+			"34 31 0:30 / {root}/sys/fs/cgroup/cpu,cpuacct rw,nosuid shared:8 optional:9 - cgroup cgroup rw,cpu,cpuacct\n",
+		map[string]string{
+			memoryPath + "/memory.limit_in_bytes":            unlimited,
+			memoryPath + "/docker/memory.limit_in_bytes":     unlimited,
+			memoryPath + "/docker/abc/memory.limit_in_bytes": "536870912",
+			memoryPath + "/docker/abc/memory.usage_in_bytes": "300000000",
+			memoryPath + "/docker/abc/memory.stat":           "cache 150000000\ninactive_file 1\ntotal_cache 150000000\ntotal_inactive_file 100000000",
+			cpuPath + "/cpu.cfs_quota_us":                    "-1",
+			cpuPath + "/cpu.cfs_period_us":                   "100000",
+			cpuPath + "/docker/cpu.cfs_quota_us":             "50000",
+			cpuPath + "/docker/cpu.cfs_period_us":            "100000",
+			cpuPath + "/docker/abc/cpu.cfs_quota_us":         "-1",
+			cpuPath + "/docker/abc/cpu.cfs_period_us":        "100000",
+			cpuPath + "/docker/abc/cpuacct.usage":            "3000000000",
+			"sys/fs/cgroup/systemd/docker/abc/cgroup.procs":  "1",
+			"sys/fs/cgroup/pids/docker/abc/pids.max":         "max",
+		},
+	)
+
+	hierarchies := readCgroupHierarchies()
+	memoryHierarchy := cgroupHierarchy{version: 1, mountPoint: filepath.Join(root, memoryPath), root: "/", path: "/docker/abc"}
+	cpuHierarchy := cgroupHierarchy{version: 1, mountPoint: filepath.Join(root, cpuPath), root: "/", path: "/docker/abc"}
+	want := map[string]cgroupHierarchy{
+		"memory":       memoryHierarchy,
+		"cpu":          cpuHierarchy,
+		"cpuacct":      cpuHierarchy,
+		"name=systemd": {version: 1, mountPoint: filepath.Join(root, "sys/fs/cgroup/systemd"), root: "/", path: "/docker/abc"},
+	}
+	// pids 没有挂载，不包括在内
+	if !reflect.DeepEqual(hierarchies, want) {
+		t.Fatalf("hierarchies = %+v, want %+v", hierarchies, want)
+	}
+	if mode := cgroupMode(hierarchies); mode != "v1" {
+		t.Errorf("cgroupMode = %q, want \"v1\"", mode)
+	}
+
+	dirs, version := relativeCgroupDirs(root, hierarchies, "cpu")
+	wantDirs := []string{"/" + cpuPath + "/docker/abc", "/" + cpuPath + "/docker", "/" + cpuPath}
+	if !reflect.DeepEqual(dirs, wantDirs) || version != 1 {
+		t.Errorf("cgroupDirs(cpu) = %q, v%d, want %q, v1", dirs, version, wantDirs)
+	}
+
+	// 上级 cgroup 的配额同样生效，-1 表示不限制
+	if quota := readCgroupCPUQuota(hierarchies); describeFloat(quota) != "0.5" {
+		t.Errorf("readCgroupCPUQuota = %s, want 0.5", describeFloat(quota))
+	}
+	// 不小于 cgroupUnlimited 的限制表示不限制
+	if limit := readCgroupMemoryLimit(hierarchies); describeUint(limit) != "536870912" {
+		t.Errorf("readCgroupMemoryLimit = %s, want 536870912", describeUint(limit))
+	}
+
+	// v1 的非活跃文件缓存以 total_inactive_file 为准，包含子 cgroup
+	limit, used := readCgroupMemory()
+	if describeUint(limit) != "536870912" || describeUint(used) != "200000000" {
+		t.Errorf("readCgroupMemory = %s, %s, want 536870912, 200000000", describeUint(limit), describeUint(used))
+	}
+	quota, seconds := readCgroupCPU()
+	if describeFloat(quota) != "0.5" || describeFloat(seconds) != "3" {
+		t.Errorf("readCgroupCPU = %s, %s, want 0.5, 3", describeFloat(quota), describeFloat(seconds))
+	}
+}
+
+func TestCgroupV1Unlimited(t *testing.T) {
+	useCgroupFixture(t,
+		"4:memory:/user.slice\n",
+		"33 23 0:29 / {root}/sys/fs/cgroup/memory rw - cgroup cgroup rw,memory\n",
+		map[string]string{
+			"sys/fs/cgroup/memory/memory.limit_in_bytes":            "9223372036854771712",
+			"sys/fs/cgroup/memory/user.slice/memory.limit_in_bytes": "9223372036854771712",
+			"sys/fs/cgroup/memory/user.slice/memory.usage_in_bytes": "1000",
+		},
+	)
+
+	if limit := readCgroupMemoryLimit(readCgroupHierarchies()); limit != nil {
+		t.Errorf("readCgroupMemoryLimit = %d, want nil", *limit)
+	}
+	// 不在容器中且没有限制时不报告 cgroup 的数值
+	if limit, used := readCgroupMemory(); limit != nil || used != nil {
+		t.Errorf("readCgroupMemory = %s, %s, want nil, nil", describeUint(limit), describeUint(used))
+	}
+}
+
+func TestCgroupHybrid(t *testing.T) {
+	root := useCgroupFixture(t,
+		"5:memory:/user.slice\n1:name=systemd:/user.slice/session-1.scope\n0::/user.slice/session-1.scope\n",
+		cgroupMountinfoOther+
+			"31 23 0:27 / {root}/sys/fs/cgroup rw shared:5 - tmpfs tmpfs ro,mode=755\n"+
+			"32 31 0:28 / {root}/sys/fs/cgroup/unified rw,nosuid shared:6 - cgroup2 cgroup2 rw,nsdelegate\n"+
+			"33 31 0:29 / {root}/sys/fs/cgroup/systemd rw,nosuid shared:7 - cgroup cgroup rw,xattr,name=systemd\n"+
+			"34 31 0:30 / {root}/sys/fs/cgroup/memory rw,nosuid shared:8 - cgroup cgroup rw,memory\n",
+		map[string]string{
+			// 混合模式中 v2 层级不带控制器
+			"sys/fs/cgroup/unified/cgroup.controllers":                      "",
+			"sys/fs/cgroup/unified/user.slice/session-1.scope/cpu.stat":     "usage_usec 4000000",
+			"sys/fs/cgroup/memory/user.slice/memory.limit_in_bytes":         "1073741824",
+			"sys/fs/cgroup/memory/user.slice/memory.usage_in_bytes":         "2000",
+			"sys/fs/cgroup/systemd/user.slice/session-1.scope/cgroup.procs": "1",
+		},
+	)
+
+	hierarchies := readCgroupHierarchies()
+	if len(hierarchies) != 3 || hierarchies[""].version != 2 || hierarchies["memory"].version != 1 {
+		t.Fatalf("hierarchies = %+v, want v2, memory and name=systemd", hierarchies)
+	}
+	if mode := cgroupMode(hierarchies); mode != "hybrid" {
+		t.Errorf("cgroupMode = %q, want \"hybrid\"", mode)
+	}
+
+	dirs, version := relativeCgroupDirs(root, hierarchies, "memory")
+	if want := []string{"/sys/fs/cgroup/memory/user.slice", "/sys/fs/cgroup/memory"}; !reflect.DeepEqual(dirs, want) || version != 1 {
+		t.Errorf("cgroupDirs(memory) = %q, v%d, want %q, v1", dirs, version, want)
+	}
+	// cpu 控制器既不在 v1 层级也不在 v2 层级
+	if dirs, version := cgroupDirs(hierarchies, "cpu"); dirs != nil || version != 0 {
+		t.Errorf("cgroupDirs(cpu) = %q, v%d, want none", dirs, version)
+	}
+	if quota := readCgroupCPUQuota(hierarchies); quota != nil {
+		t.Errorf("readCgroupCPUQuota = %v, want nil", *quota)
+	}
+
+	limit, used := readCgroupMemory()
+	if describeUint(limit) != "1073741824" || describeUint(used) != "2000" {
+		t.Errorf("readCgroupMemory = %s, %s, want 1073741824, 2000", describeUint(limit), describeUint(used))
+	}
+	// 没有配额且不在容器中时不报告
+	if quota, seconds := readCgroupCPU(); quota != nil || seconds != nil {
+		t.Errorf("readCgroupCPU = %s, %s, want nil, nil", describeFloat(quota), describeFloat(seconds))
+	}
+
+	// 在容器中时即使没有配额也报告 CPU 时间，来自 v2 层级的 cpu.stat
+	writeSensorFiles(t, filepath.Join(root, "missing"), map[string]string{".dockerenv": ""})
+	if quota, seconds := readCgroupCPU(); quota != nil || describeFloat(seconds) != "4" {
+		t.Errorf("readCgroupCPU in container = %s, %s, want nil, 4", describeFloat(quota), describeFloat(seconds))
+	}
+}
+
+func TestCgroupNamespacedMount(t *testing.T) {
+	// 未使用 cgroup 命名空间的容器中，挂载的是层级的子目录 /docker/abc，进程路径需去掉该前缀
+	const mountinfo = "40 35 0:26 /docker/abc {root}/sys/fs/cgroup ro,nosuid,nodev,noexec,relatime - cgroup2 cgroup rw,nsdelegate\n"
+	files := map[string]string{
+		"sys/fs/cgroup/cgroup.controllers": "cpu memory pids",
+		"sys/fs/cgroup/memory.max":         "268435456",
+		"sys/fs/cgroup/cpu.max":            "max 100000",
+		"sys/fs/cgroup/app/memory.max":     "max",
+		"sys/fs/cgroup/app/memory.current": "1000",
+		"sys/fs/cgroup/app/cpu.stat":       "usage_usec 1000000",
+		"sys/fs/cgroup/other/cgroup.procs": "",
+		"sys/fs/cgroup/memory.current":     "5000",
+		"sys/fs/cgroup/cpu.stat":           "usage_usec 9000000",
+	}
+
+	tests := []struct {
+		name   string
+		cgroup string
+		dirs   []string
+		used   string
+	}{
+		{"nested in mount root", "0::/docker/abc/app\n", []string{"/sys/fs/cgroup/app", "/sys/fs/cgroup"}, "1000"},
+		{"at mount root", "0::/docker/abc\n", []string{"/sys/fs/cgroup"}, "5000"},
+		// 进程在挂载范围之外，只能使用挂载点
+		{"outside mount root", "0::/system.slice/other.service\n", []string{"/sys/fs/cgroup"}, "5000"},
+		// 使用 cgroup 命名空间时，命名空间之外的路径以 '/..' 开头
+		{"outside cgroup namespace", "0::/../../user.slice\n", []string{"/sys/fs/cgroup"}, "5000"},
+		// 进程所在的 cgroup 目录不存在时跳过
+		{"missing leaf", "0::/docker/abc/gone.scope\n", []string{"/sys/fs/cgroup"}, "5000"},
+	}
+	for _, tt := range tests {
+		root := useCgroupFixture(t, tt.cgroup, mountinfo, files)
+		hierarchies := readCgroupHierarchies()
+		if hierarchy := hierarchies[""]; hierarchy.root != "/docker/abc" {
+			t.Errorf("%s: hierarchy root = %q, want \"/docker/abc\"", tt.name, hierarchy.root)
+		}
+		if dirs, version := relativeCgroupDirs(root, hierarchies, "memory"); !reflect.DeepEqual(dirs, tt.dirs) || version != 2 {
+			t.Errorf("%s: cgroupDirs(memory) = %q, v%d, want %q, v2", tt.name, dirs, version, tt.dirs)
+		}
+		if limit, used := readCgroupMemory(); describeUint(limit) != "268435456" || describeUint(used) != tt.used {
+			t.Errorf("%s: readCgroupMemory = %s, %s, want 268435456, %s", tt.name, describeUint(limit), describeUint(used), tt.used)
+		}
+	}
+}
+
+func TestCgroupNotMounted(t *testing.T) {
+	useCgroupFixture(t, "0::/\n", cgroupMountinfoOther, nil)
+
+	hierarchies := readCgroupHierarchies()
+	if len(hierarchies) != 0 || cgroupMode(hierarchies) != "" {
+		t.Errorf("hierarchies = %+v, mode %q, want none", hierarchies, cgroupMode(hierarchies))
+	}
+	if quota := readCgroupCPUQuota(hierarchies); quota != nil {
+		t.Errorf("readCgroupCPUQuota = %v, want nil", *quota)
+	}
+	if limit, used := readCgroupMemory(); limit != nil || used != nil {
+		t.Errorf("readCgroupMemory = %s, %s, want nil, nil", describeUint(limit), describeUint(used))
+	}
+
+	procSelfMountinfoFile = filepath.Join(t.TempDir(), "missing")
+	if hierarchies := readCgroupHierarchies(); len(hierarchies) != 0 {
+		t.Errorf("missing mountinfo: hierarchies = %+v, want none", hierarchies)
+	}
+}
@@ -10,9 +10,26 @@ Description: 单位和格式数据转换
 package general
 
 import (
+	"fmt"
+	"math"
 	"strings"
 )
 
+// 自动选择存储数据单位
+const (
+	DataUnitAuto        = "auto"         // 自动选择二进制单位（KiB、MiB、GiB...）
+	DataUnitAutoDecimal = "auto-decimal" // 自动选择十进制单位（KB、MB、GB...）
+)
+
+// PercentUnitPercent 百分比单位
+const PercentUnitPercent = "%"
+
+var (
+	binaryDataUnits  = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB"} // 二进制单位，进率 1024
+	decimalDataUnits = []string{"B", "KB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB"}         // 十进制单位，进率 1000
+	percentUnits     = map[string]float64{PercentUnitPercent: 100, "‰": 1000}                // 百分比单位及其倍率
+)
+
 // UpperFirstChar 最大化字符串的第一个字母
 //
 // 参数：
@@ -59,3 +76,107 @@ func Human(size float64, initialUnit string) (float64, string) {
 
 	return size, initialUnit
 }
+
+// ParseDataUnit 解析存储数据单位
+//
+//   - 不区分大小写，例如 'gib' 解析为 'GiB'，'gb' 解析为 'GB'
+//
+// 参数：
+//   - unit: 存储数据单位，'auto'、'auto-decimal' 或固定单位（B、KiB...YiB、KB...YB）
+//
+// 返回：
+//   - 规范化的存储数据单位
+//   - 错误信息，单位无效时返回错误
+func ParseDataUnit(unit string) (string, error) {
+	for _, validUnit := range append([]string{DataUnitAuto, DataUnitAutoDecimal}, append(binaryDataUnits, decimalDataUnits[1:]...)...) {
+		if strings.EqualFold(unit, validUnit) {
+			return validUnit, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown data unit '%s', expected 'auto', 'auto-decimal', %s or %s", unit, strings.Join(binaryDataUnits, "/"), strings.Join(decimalDataUnits[1:], "/"))
+}
+
+// FormatDataSize 按存储数据单位格式化存储数据
+//
+// 参数：
+//   - size: 存储数据，单位为 Byte
+//   - unit: 规范化的存储数据单位，无效单位按 'auto' 处理
+//   - precision: 小数位数
+//
+// 返回：
+//   - 格式化后的存储数据，例如 '15.5 GiB'
+func FormatDataSize(size float64, unit string, precision int) string {
+	var convertedUnit string
+	switch unit {
+	case DataUnitAutoDecimal:
+		convertedUnit = decimalDataUnits[0]
+		for _, decimalUnit := range decimalDataUnits[1:] {
+			if size < 1000 {
+				break
+			}
+			size /= 1000
+			convertedUnit = decimalUnit
+		}
+	default:
+		if index := indexOf(binaryDataUnits, unit); index >= 0 {
+			size /= math.Pow(1024, float64(index))
+			convertedUnit = unit
+		} else if index := indexOf(decimalDataUnits, unit); index >= 0 {
+			size /= math.Pow(1000, float64(index))
+			convertedUnit = unit
+		} else {
+			size, convertedUnit = Human(size, "B")
+		}
+	}
+
+	return fmt.Sprintf("%.*f %s", precision, size, convertedUnit)
+}
+
+// ParsePercentUnit 解析百分比单位
+//
+// 参数：
+//   - unit: 百分比单位，'%' 或 '‰'
+//
+// 返回：
+//   - 百分比单位
+//   - 错误信息，单位无效时返回错误
+func ParsePercentUnit(unit string) (string, error) {
+	if _, ok := percentUnits[unit]; ok {
+		return unit, nil
+	}
+	return "", fmt.Errorf("Unknown percent unit '%s', expected '%%' or '‰'", unit)
+}
+
+// FormatPercent 按百分比单位格式化比例
+//
+// 参数：
+//   - percent: 百分数，例如 12.5 表示 12.5%
+//   - unit: 百分比单位，无效单位按 '%' 处理
+//   - precision: 小数位数
+//
+// 返回：
+//   - 格式化后的比例，例如 '12.5%' 或 '125.0‰'
+func FormatPercent(percent float64, unit string, precision int) string {
+	multiple, ok := percentUnits[unit]
+	if !ok {
+		unit, multiple = PercentUnitPercent, percentUnits[PercentUnitPercent]
+	}
+	return fmt.Sprintf("%.*f%s", precision, percent*multiple/100, unit)
+}
+
+// indexOf 查找字符串在切片中的位置
+//
+// 参数：
+//   - list: 字符串切片
+//   - str: 待查找的字符串
+//
+// 返回：
+//   - 位置，未找到时为 -1
+func indexOf(list []string, str string) int {
+	for index, item := range list {
+		if item == str {
+			return index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,131 @@
+/*
+File: define_convert_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-12 10:18:37
+
+Description: 测试存储数据单位和百分比单位的解析及格式化
+*/
+
+package general
+
+import "testing"
+
+func TestParseDataUnit(t *testing.T) {
+	tests := []struct {
+		unit    string
+		want    string
+		wantErr bool
+	}{
+		{"auto", DataUnitAuto, false},
+		{"AUTO", DataUnitAuto, false},
+		{"auto-decimal", DataUnitAutoDecimal, false},
+		{"Auto-Decimal", DataUnitAutoDecimal, false},
+		{"B", "B", false},
+		{"b", "B", false},
+		{"KiB", "KiB", false},
+		{"kib", "KiB", false},
+		{"KB", "KB", false},
+		{"kb", "KB", false},
+		{"GiB", "GiB", false},
+		{"gb", "GB", false},
+		{"YiB", "YiB", false},
+		{"YB", "YB", false},
+		{"", "", true},
+		{"K", "", true},
+		{"iB", "", true},
+		{"GiBs", "", true},
+		{"kibibyte", "", true},
+		{"auto decimal", "", true},
+		{" GiB", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDataUnit(tt.unit)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseDataUnit(%q) error = %v, wantErr %v", tt.unit, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDataUnit(%q) = %q, want %q", tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDataSize(t *testing.T) {
+	tests := []struct {
+		size      float64
+		unit      string
+		precision int
+		want      string
+	}{
+		// 自动选择二进制单位
+		{0, DataUnitAuto, 1, "0.0 B"},
+		{1000, DataUnitAuto, 0, "1000 B"},
+		{1536, DataUnitAuto, 1, "1.5 KiB"},
+		{1.5 * (1 << 40), DataUnitAuto, 1, "1.5 TiB"},
+		// 自动选择十进制单位
+		{999, DataUnitAutoDecimal, 1, "999.0 B"},
+		{1500, DataUnitAutoDecimal, 1, "1.5 KB"},
+		{16 << 30, DataUnitAutoDecimal, 1, "17.2 GB"},
+		// 固定单位，KiB 与 KB 的进率不同
+		{1024, "KiB", 3, "1.000 KiB"},
+		{1024, "KB", 3, "1.024 KB"},
+		{1000, "KiB", 4, "0.9766 KiB"},
+		{16 << 30, "GiB", 1, "16.0 GiB"},
+		{16 << 30, "GB", 1, "17.2 GB"},
+		{512, "B", 0, "512 B"},
+		{1 << 20, "GiB", 3, "0.001 GiB"},
+		// 无效单位按 'auto' 处理
+		{2048, "bogus", 1, "2.0 KiB"},
+		{2048, "", 1, "2.0 KiB"},
+	}
+	for _, tt := range tests {
+		if got := FormatDataSize(tt.size, tt.unit, tt.precision); got != tt.want {
+			t.Errorf("FormatDataSize(%v, %q, %d) = %q, want %q", tt.size, tt.unit, tt.precision, got, tt.want)
+		}
+	}
+}
+
+func TestParsePercentUnit(t *testing.T) {
+	tests := []struct {
+		unit    string
+		wantErr bool
+	}{
+		{"%", false},
+		{"‰", false},
+		{"", true},
+		{"%%", true},
+		{"percent", true},
+		{"‱", true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePercentUnit(tt.unit)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePercentUnit(%q) error = %v, wantErr %v", tt.unit, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.unit {
+			t.Errorf("ParsePercentUnit(%q) = %q, want %q", tt.unit, got, tt.unit)
+		}
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		percent   float64
+		unit      string
+		precision int
+		want      string
+	}{
+		{12.5, "%", 1, "12.5%"},
+		{12.5, "‰", 1, "125.0‰"},
+		{100, "%", 0, "100%"},
+		{0, "‰", 2, "0.00‰"},
+		{12.5, "bogus", 1, "12.5%"},
+	}
+	for _, tt := range tests {
+		if got := FormatPercent(tt.percent, tt.unit, tt.precision); got != tt.want {
+			t.Errorf("FormatPercent(%v, %q, %d) = %q, want %q", tt.percent, tt.unit, tt.precision, got, tt.want)
+		}
+	}
+}
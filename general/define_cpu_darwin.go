@@ -0,0 +1,18 @@
+//go:build darwin
+
+/*
+File: define_cpu_darwin.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-03 11:26:40
+
+Description: 处理器的详细信息
+*/
+
+package general
+
+// fillCPUDetails 补全处理器的详细信息，macOS 未提供 cpufreq 和 sysfs 数据，保持空值
+//
+// 参数：
+//   - info: 处理器信息
+func fillCPUDetails(info *CPUInfo) {}
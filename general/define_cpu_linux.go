@@ -0,0 +1,236 @@
+//go:build linux
+
+/*
+File: define_cpu_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-03 09:48:21
+
+Description: 读取处理器的详细信息
+
+- 每个逻辑处理器的频率和调频策略来自 /sys/devices/system/cpu/cpu<N>/cpufreq
+- 各级缓存来自 /sys/devices/system/cpu/cpu<N>/cache，同一缓存被多个逻辑处理器共享时只计算一次
+- 微码版本和指令集标志来自 /proc/cpuinfo，漏洞缓解状态来自 /sys/devices/system/cpu/vulnerabilities
+*/
+
+package general
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	sysCPUPath  = "/sys/devices/system/cpu" // 处理器的 sysfs 目录
+	cpuinfoFile = "/proc/cpuinfo"           // 处理器信息
+)
+
+// cpuDirPattern 匹配逻辑处理器目录名
+var cpuDirPattern = regexp.MustCompile(`^cpu(\d+)$`)
+
+// fillCPUDetails 补全处理器的详细信息
+//
+// 参数：
+//   - info: 处理器信息
+func fillCPUDetails(info *CPUInfo) {
+	info.CoreDetails = readCPUCores()
+	info.CacheL1d, info.CacheL1i, info.CacheL2, info.CacheL3 = readCPUCaches()
+	info.Vulnerabilities = readCPUVulnerabilities()
+
+	// 各核心的调频策略通常相同，不同时全部列出
+	var governors []string
+	for _, core := range info.CoreDetails {
+		if core.Governor != "" && indexOf(governors, core.Governor) < 0 {
+			governors = append(governors, core.Governor)
+		}
+	}
+	info.Governor = strings.Join(governors, ", ")
+
+	properties := readCPUInfoProperties()
+	info.Microcode = properties["microcode"]
+	flags := strings.Fields(properties["flags"])
+	for _, flag := range flags {
+		switch {
+		case flag == "vmx":
+			info.Virtualization = "VT-x"
+		case flag == "svm":
+			info.Virtualization = "AMD-V"
+		case strings.HasPrefix(flag, "avx"):
+			info.AVX = append(info.AVX, flag)
+		}
+	}
+}
+
+// readCPUCores 读取每个在线逻辑处理器的拓扑和频率
+//
+// 返回：
+//   - 逻辑处理器信息，按编号排序
+func readCPUCores() []CPUCore {
+	entries, err := os.ReadDir(sysCPUPath)
+	if err != nil {
+		return nil
+	}
+
+	var cores []CPUCore
+	for _, entry := range entries {
+		matches := cpuDirPattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+		cpuPath := filepath.Join(sysCPUPath, entry.Name())
+		// cpu0 通常没有 online 文件，其他处理器离线时为 0
+		if readSensorValue(cpuPath, "online") == "0" {
+			continue
+		}
+
+		core := CPUCore{
+			CoreID:   -1,
+			SocketID: -1,
+			Governor: readSensorValue(cpuPath, "cpufreq/scaling_governor"),
+		}
+		core.CPU, _ = strconv.Atoi(matches[1])
+		if coreID, err := strconv.Atoi(readSensorValue(cpuPath, "topology/core_id")); err == nil {
+			core.CoreID = coreID
+		}
+		if socketID, err := strconv.Atoi(readSensorValue(cpuPath, "topology/physical_package_id")); err == nil {
+			core.SocketID = socketID
+		}
+		// kHz 换算为 MHz，优先使用硬件报告的当前频率
+		for _, file := range []string{"cpufreq/cpuinfo_cur_freq", "cpufreq/scaling_cur_freq"} {
+			if frequency, ok := readSensorNumber(cpuPath, file, 1000); ok {
+				core.CurrentFrequency = frequency
+				break
+			}
+		}
+		core.MinFrequency, _ = readSensorNumber(cpuPath, "cpufreq/cpuinfo_min_freq", 1000)
+		core.MaxFrequency, _ = readSensorNumber(cpuPath, "cpufreq/cpuinfo_max_freq", 1000)
+
+		cores = append(cores, core)
+	}
+	sort.Slice(cores, func(i, j int) bool {
+		return cores[i].CPU < cores[j].CPU
+	})
+
+	return cores
+}
+
+// readCPUCaches 读取各级缓存的总容量
+//
+// 返回：
+//   - L1 数据缓存容量，单位为 Byte
+//   - L1 指令缓存容量
+//   - L2 缓存容量
+//   - L3 缓存容量
+func readCPUCaches() (uint64, uint64, uint64, uint64) {
+	var l1d, l1i, l2, l3 uint64
+
+	indexPaths, _ := filepath.Glob(filepath.Join(sysCPUPath, "cpu[0-9]*", "cache", "index[0-9]*"))
+	counted := make(map[string]bool) // 已计算的缓存，键为级别、类型和共享该缓存的处理器
+	for _, indexPath := range indexPaths {
+		level := readSensorValue(indexPath, "level")
+		cacheType := readSensorValue(indexPath, "type")
+		key := level + "/" + cacheType + "/" + readSensorValue(indexPath, "shared_cpu_list")
+		if counted[key] {
+			continue
+		}
+		counted[key] = true
+
+		size := parseCacheSize(readSensorValue(indexPath, "size"))
+		switch {
+		case level == "1" && cacheType == "Data":
+			l1d += size
+		case level == "1" && cacheType == "Instruction":
+			l1i += size
+		case level == "2":
+			l2 += size
+		case level == "3":
+			l3 += size
+		}
+	}
+
+	return l1d, l1i, l2, l3
+}
+
+// parseCacheSize 解析 sysfs 中的缓存容量，例如 '32K'、'16M'
+//
+// 参数：
+//   - size: 缓存容量
+//
+// 返回：
+//   - 缓存容量，单位为 Byte，无法解析时为 0
+func parseCacheSize(size string) uint64 {
+	multiplier := uint64(1)
+	switch {
+	case strings.HasSuffix(size, "K"):
+		multiplier = 1 << 10
+	case strings.HasSuffix(size, "M"):
+		multiplier = 1 << 20
+	case strings.HasSuffix(size, "G"):
+		multiplier = 1 << 30
+	}
+	value, err := strconv.ParseUint(strings.TrimRight(size, "KMG"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return value * multiplier
+}
+
+// readCPUVulnerabilities 读取处理器漏洞及其缓解状态
+//
+// 返回：
+//   - 漏洞及其缓解状态，按漏洞名排序
+func readCPUVulnerabilities() []CPUVulnerability {
+	vulnerabilitiesPath := filepath.Join(sysCPUPath, "vulnerabilities")
+	entries, err := os.ReadDir(vulnerabilitiesPath)
+	if err != nil {
+		return nil
+	}
+
+	var vulnerabilities []CPUVulnerability
+	for _, entry := range entries {
+		vulnerabilities = append(vulnerabilities, CPUVulnerability{
+			Name:   entry.Name(),
+			Status: readSensorValue(vulnerabilitiesPath, entry.Name()),
+		})
+	}
+	return vulnerabilities
+}
+
+// readCPUInfoProperties 读取 /proc/cpuinfo 中第一个处理器的属性
+//
+// 返回：
+//   - 属性，键为属性名，例如 'microcode'、'flags'，ARM 处理器的 'Features' 也记为 'flags'
+func readCPUInfoProperties() map[string]string {
+	properties := make(map[string]string)
+
+	file, err := os.Open(cpuinfoFile)
+	if err != nil {
+		return properties
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024) // flags 行可能很长
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" && len(properties) > 0 {
+			break // 处理器之间以空行分隔
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if key == "Features" {
+			key = "flags"
+		}
+		properties[key] = strings.TrimSpace(value)
+	}
+
+	return properties
+}
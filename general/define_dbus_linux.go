@@ -0,0 +1,622 @@
+//go:build linux
+
+/*
+File: define_dbus_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-06 09:12:40
+
+Description: 最小化的 D-Bus 客户端，仅支持方法调用
+
+- 通过 Unix 套接字连接系统总线或会话总线，使用 EXTERNAL 机制（即进程的 uid）认证
+- 只发送方法调用并等待对应的返回或错误，期间收到的信号等其他消息直接丢弃
+- 参数只支持字符串（'s'）和字符串数组（'as'），返回值按签名通用解码：
+  基本类型解码为对应的 Go 类型，数组解码为 []any，结构体和字典项解码为 []any，变体解码为其中的值
+*/
+
+package general
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	dbusSystemBusAddress = "unix:path=/run/dbus/system_bus_socket" // 系统总线的默认地址
+	dbusTimeout          = 2 * time.Second                         // 每次方法调用的超时时间
+)
+
+// D-Bus 消息类型
+const (
+	dbusMessageMethodCall   = 1 // 方法调用
+	dbusMessageMethodReturn = 2 // 方法返回
+	dbusMessageError        = 3 // 错误
+)
+
+// D-Bus 消息头字段
+const (
+	dbusFieldPath        = 1 // 对象路径
+	dbusFieldInterface   = 2 // 接口名
+	dbusFieldMember      = 3 // 方法名
+	dbusFieldErrorName   = 4 // 错误名
+	dbusFieldReplySerial = 5 // 所返回消息的序号
+	dbusFieldDestination = 6 // 目标连接名
+	dbusFieldSignature   = 8 // 消息体签名
+)
+
+// dbusFlagNoAutoStart 消息标志，目标连接名不存在时不自动启动对应的服务
+const dbusFlagNoAutoStart = 0x2
+
+// dbusConn D-Bus 连接
+type dbusConn struct {
+	conn   net.Conn      // 与总线的连接
+	reader *bufio.Reader // 读取缓冲
+	serial uint32        // 上一条消息的序号
+}
+
+// dbusError D-Bus 返回的错误
+type dbusError struct {
+	Name    string // 错误名，例如 'org.freedesktop.DBus.Error.ServiceUnknown'
+	Message string // 错误说明
+}
+
+// Error 实现 error 接口
+func (e *dbusError) Error() string {
+	if e.Message == "" {
+		return e.Name
+	}
+	return e.Name + ": " + e.Message
+}
+
+// dbusBusAddress 获取总线地址
+//
+// 参数：
+//   - manager: 'system' 为系统总线，'user' 为会话总线
+//
+// 返回：
+//   - 总线地址，可能包含多个以 ';' 分隔的地址
+func dbusBusAddress(manager string) string {
+	if manager == "user" {
+		if address := os.Getenv("DBUS_SESSION_BUS_ADDRESS"); address != "" {
+			return address
+		}
+		if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+			return "unix:path=" + filepath.Join(runtimeDir, "bus")
+		}
+		return "unix:path=" + filepath.Join("/run/user", strconv.Itoa(os.Getuid()), "bus")
+	}
+	if address := os.Getenv("DBUS_SYSTEM_BUS_ADDRESS"); address != "" {
+		return address
+	}
+	return dbusSystemBusAddress
+}
+
+// dialDBus 连接总线并完成认证和注册
+//
+// 参数：
+//   - manager: 'system' 为系统总线，'user' 为会话总线
+//
+// 返回：
+//   - D-Bus 连接
+//   - 错误信息
+func dialDBus(manager string) (*dbusConn, error) {
+	var lastErr error = fmt.Errorf("no supported D-Bus address")
+	for _, address := range strings.Split(dbusBusAddress(manager), ";") {
+		transport, params, _ := strings.Cut(address, ":")
+		if transport != "unix" {
+			continue
+		}
+		var socket string
+		for _, param := range strings.Split(params, ",") {
+			key, value, _ := strings.Cut(param, "=")
+			switch key {
+			case "path":
+				socket = value
+			case "abstract":
+				socket = "@" + value
+			}
+		}
+		if socket == "" {
+			continue
+		}
+
+		conn, err := net.DialTimeout("unix", socket, dbusTimeout)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		bus := &dbusConn{conn: conn, reader: bufio.NewReader(conn)}
+		if err := bus.auth(); err != nil {
+			conn.Close()
+			lastErr = err
+			continue
+		}
+		// 调用其他方法前必须先注册
+		if _, err := bus.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello"); err != nil {
+			conn.Close()
+			lastErr = err
+			continue
+		}
+		return bus, nil
+	}
+	return nil, lastErr
+}
+
+// auth 使用 EXTERNAL 机制认证
+//
+// 返回：
+//   - 错误信息
+func (bus *dbusConn) auth() error {
+	bus.conn.SetDeadline(time.Now().Add(dbusTimeout))
+	defer bus.conn.SetDeadline(time.Time{})
+
+	// 认证前需先发送一个空字节，uid 以十六进制编码的十进制字符串表示
+	uid := hex.EncodeToString([]byte(strconv.Itoa(os.Getuid())))
+	if _, err := io.WriteString(bus.conn, "\x00AUTH EXTERNAL "+uid+"\r\n"); err != nil {
+		return err
+	}
+	line, err := bus.reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return fmt.Errorf("D-Bus authentication rejected: %s", strings.TrimSpace(line))
+	}
+	_, err = io.WriteString(bus.conn, "BEGIN\r\n")
+	return err
+}
+
+// Close 关闭连接
+func (bus *dbusConn) Close() error {
+	return bus.conn.Close()
+}
+
+// Call 调用方法并等待返回
+//
+// 参数：
+//   - destination: 目标连接名
+//   - path: 对象路径
+//   - iface: 接口名
+//   - member: 方法名
+//   - args: 方法参数，只支持 string 和 []string
+//
+// 返回：
+//   - 返回值
+//   - 错误信息，方法返回错误时为 *dbusError
+func (bus *dbusConn) Call(destination, path, iface, member string, args ...any) ([]any, error) {
+	bus.serial++
+	message, err := encodeDBusCall(bus.serial, destination, path, iface, member, args)
+	if err != nil {
+		return nil, err
+	}
+
+	bus.conn.SetDeadline(time.Now().Add(dbusTimeout))
+	defer bus.conn.SetDeadline(time.Time{})
+	if _, err := bus.conn.Write(message); err != nil {
+		return nil, err
+	}
+
+	for {
+		messageType, fields, body, err := bus.readMessage()
+		if err != nil {
+			return nil, err
+		}
+		if messageType != dbusMessageMethodReturn && messageType != dbusMessageError {
+			continue
+		}
+		if replySerial, _ := fields[dbusFieldReplySerial].(uint32); replySerial != bus.serial {
+			continue
+		}
+		if messageType == dbusMessageError {
+			dbusErr := &dbusError{}
+			dbusErr.Name, _ = fields[dbusFieldErrorName].(string)
+			if len(body) > 0 {
+				dbusErr.Message, _ = body[0].(string)
+			}
+			return nil, dbusErr
+		}
+		return body, nil
+	}
+}
+
+// GetProperty 读取对象的属性
+//
+// 参数：
+//   - destination: 目标连接名
+//   - path: 对象路径
+//   - iface: 属性所属的接口名
+//   - property: 属性名
+//
+// 返回：
+//   - 属性值
+//   - 错误信息
+func (bus *dbusConn) GetProperty(destination, path, iface, property string) (any, error) {
+	body, err := bus.Call(destination, path, "org.freedesktop.DBus.Properties", "Get", iface, property)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("empty reply for property %s", property)
+	}
+	return body[0], nil
+}
+
+// readMessage 读取一条消息
+//
+// 返回：
+//   - 消息类型
+//   - 消息头字段，键为字段代码
+//   - 消息体
+//   - 错误信息
+func (bus *dbusConn) readMessage() (byte, map[byte]any, []any, error) {
+	// 固定部分为字节序、消息类型、标志、协议版本、消息体长度、序号和消息头字段数组的长度
+	fixed := make([]byte, 16)
+	if _, err := io.ReadFull(bus.reader, fixed); err != nil {
+		return 0, nil, nil, err
+	}
+	var order binary.ByteOrder
+	switch fixed[0] {
+	case 'l':
+		order = binary.LittleEndian
+	case 'B':
+		order = binary.BigEndian
+	default:
+		return 0, nil, nil, fmt.Errorf("invalid D-Bus endianness %q", fixed[0])
+	}
+	bodyLength := order.Uint32(fixed[4:8])
+	fieldsLength := order.Uint32(fixed[12:16])
+	headerLength := alignTo(16+int(fieldsLength), 8)
+	if headerLength+int(bodyLength) > 128<<20 {
+		return 0, nil, nil, fmt.Errorf("D-Bus message too large")
+	}
+
+	message := make([]byte, headerLength+int(bodyLength))
+	copy(message, fixed)
+	if _, err := io.ReadFull(bus.reader, message[16:]); err != nil {
+		return 0, nil, nil, err
+	}
+
+	decoder := &dbusDecoder{data: message[:headerLength], order: order, pos: 12}
+	rawFields, err := decoder.decode("a(yv)")
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	fields := make(map[byte]any)
+	for _, rawField := range rawFields.([]any) {
+		field := rawField.([]any)
+		fields[field[0].(byte)] = field[1]
+	}
+
+	var body []any
+	if signature, _ := fields[dbusFieldSignature].(string); signature != "" {
+		// 消息体从 8 字节对齐处开始，对齐以整条消息的开头为基准
+		decoder = &dbusDecoder{data: message, order: order, pos: headerLength}
+		for _, signatureType := range splitDBusSignature(signature) {
+			value, err := decoder.decode(signatureType)
+			if err != nil {
+				return 0, nil, nil, err
+			}
+			body = append(body, value)
+		}
+	}
+
+	return fixed[1], fields, body, nil
+}
+
+// encodeDBusCall 编码方法调用消息，使用小端字节序
+//
+// 参数：
+//   - serial: 消息序号
+//   - destination: 目标连接名
+//   - path: 对象路径
+//   - iface: 接口名
+//   - member: 方法名
+//   - args: 方法参数，只支持 string 和 []string
+//
+// 返回：
+//   - 编码后的消息
+//   - 错误信息
+func encodeDBusCall(serial uint32, destination, path, iface, member string, args []any) ([]byte, error) {
+	body := &dbusEncoder{}
+	var signature strings.Builder
+	for _, arg := range args {
+		switch value := arg.(type) {
+		case string:
+			signature.WriteString("s")
+			body.writeString(value)
+		case []string:
+			signature.WriteString("as")
+			body.align(4)
+			lengthPos := len(body.data)
+			body.writeUint32(0)
+			start := len(body.data) // string 按 4 字节对齐，数组长度之后无需填充
+			for _, element := range value {
+				body.writeString(element)
+			}
+			binary.LittleEndian.PutUint32(body.data[lengthPos:], uint32(len(body.data)-start))
+		default:
+			return nil, fmt.Errorf("unsupported D-Bus argument type %T", arg)
+		}
+	}
+
+	header := &dbusEncoder{}
+	header.data = append(header.data, 'l', dbusMessageMethodCall, dbusFlagNoAutoStart, 1)
+	header.writeUint32(uint32(len(body.data)))
+	header.writeUint32(serial)
+	lengthPos := len(header.data)
+	header.writeUint32(0)
+	start := len(header.data)
+	writeField := func(code byte, fieldSignature, value string) {
+		header.align(8)
+		header.data = append(header.data, code)
+		header.writeSignature(fieldSignature)
+		if fieldSignature == "g" {
+			header.writeSignature(value)
+		} else {
+			header.writeString(value)
+		}
+	}
+	writeField(dbusFieldPath, "o", path)
+	writeField(dbusFieldDestination, "s", destination)
+	if iface != "" {
+		writeField(dbusFieldInterface, "s", iface)
+	}
+	writeField(dbusFieldMember, "s", member)
+	if signature.Len() > 0 {
+		writeField(dbusFieldSignature, "g", signature.String())
+	}
+	binary.LittleEndian.PutUint32(header.data[lengthPos:], uint32(len(header.data)-start))
+	header.align(8)
+
+	return append(header.data, body.data...), nil
+}
+
+// alignTo 将位置向上对齐
+//
+// 参数：
+//   - pos: 位置
+//   - alignment: 对齐字节数
+//
+// 返回：
+//   - 对齐后的位置
+func alignTo(pos, alignment int) int {
+	return (pos + alignment - 1) / alignment * alignment
+}
+
+// dbusEncoder 小端字节序的 D-Bus 编码器
+type dbusEncoder struct {
+	data []byte // 已编码的数据
+}
+
+// align 填充到指定对齐位置
+func (e *dbusEncoder) align(alignment int) {
+	for len(e.data)%alignment != 0 {
+		e.data = append(e.data, 0)
+	}
+}
+
+// writeUint32 编码 uint32
+func (e *dbusEncoder) writeUint32(value uint32) {
+	e.align(4)
+	e.data = binary.LittleEndian.AppendUint32(e.data, value)
+}
+
+// writeString 编码字符串或对象路径
+func (e *dbusEncoder) writeString(value string) {
+	e.writeUint32(uint32(len(value)))
+	e.data = append(e.data, value...)
+	e.data = append(e.data, 0)
+}
+
+// writeSignature 编码签名
+func (e *dbusEncoder) writeSignature(value string) {
+	e.data = append(e.data, byte(len(value)))
+	e.data = append(e.data, value...)
+	e.data = append(e.data, 0)
+}
+
+// dbusDecoder D-Bus 解码器
+type dbusDecoder struct {
+	data  []byte           // 待解码的数据，对齐以其开头为基准
+	order binary.ByteOrder // 字节序
+	pos   int              // 当前位置
+}
+
+// read 对齐后读取指定长度的数据
+func (d *dbusDecoder) read(alignment, length int) ([]byte, error) {
+	d.pos = alignTo(d.pos, alignment)
+	if d.pos+length > len(d.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	value := d.data[d.pos : d.pos+length]
+	d.pos += length
+	return value, nil
+}
+
+// decode 按签名解码一个完整类型的值
+//
+// 参数：
+//   - signature: 单个完整类型的签名
+//
+// 返回：
+//   - 解码后的值
+//   - 错误信息
+func (d *dbusDecoder) decode(signature string) (any, error) {
+	switch signature[0] {
+	case 'y':
+		value, err := d.read(1, 1)
+		if err != nil {
+			return nil, err
+		}
+		return value[0], nil
+	case 'b':
+		value, err := d.read(4, 4)
+		if err != nil {
+			return nil, err
+		}
+		return d.order.Uint32(value) != 0, nil
+	case 'n', 'q':
+		value, err := d.read(2, 2)
+		if err != nil {
+			return nil, err
+		}
+		if signature[0] == 'n' {
+			return int16(d.order.Uint16(value)), nil
+		}
+		return d.order.Uint16(value), nil
+	case 'i', 'u', 'h':
+		value, err := d.read(4, 4)
+		if err != nil {
+			return nil, err
+		}
+		if signature[0] == 'i' {
+			return int32(d.order.Uint32(value)), nil
+		}
+		return d.order.Uint32(value), nil
+	case 'x', 't', 'd':
+		value, err := d.read(8, 8)
+		if err != nil {
+			return nil, err
+		}
+		switch signature[0] {
+		case 'x':
+			return int64(d.order.Uint64(value)), nil
+		case 'd':
+			return math.Float64frombits(d.order.Uint64(value)), nil
+		}
+		return d.order.Uint64(value), nil
+	case 's', 'o':
+		lengthBytes, err := d.read(4, 4)
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.read(1, int(d.order.Uint32(lengthBytes))+1)
+		if err != nil {
+			return nil, err
+		}
+		return string(value[:len(value)-1]), nil
+	case 'g':
+		lengthBytes, err := d.read(1, 1)
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.read(1, int(lengthBytes[0])+1)
+		if err != nil {
+			return nil, err
+		}
+		return string(value[:len(value)-1]), nil
+	case 'v':
+		valueSignature, err := d.decode("g")
+		if err != nil {
+			return nil, err
+		}
+		types := splitDBusSignature(valueSignature.(string))
+		if len(types) != 1 {
+			return nil, fmt.Errorf("invalid D-Bus variant signature %q", valueSignature)
+		}
+		return d.decode(types[0])
+	case 'a':
+		lengthBytes, err := d.read(4, 4)
+		if err != nil {
+			return nil, err
+		}
+		elementSignature := signature[1:]
+		// 数组长度不包含第一个元素前的填充
+		d.pos = alignTo(d.pos, dbusAlignment(elementSignature[0]))
+		end := d.pos + int(d.order.Uint32(lengthBytes))
+		if end > len(d.data) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		elements := []any{}
+		for d.pos < end {
+			element, err := d.decode(elementSignature)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, element)
+		}
+		return elements, nil
+	case '(', '{':
+		d.pos = alignTo(d.pos, 8)
+		var fields []any
+		for _, fieldSignature := range splitDBusSignature(signature[1 : len(signature)-1]) {
+			field, err := d.decode(fieldSignature)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field)
+		}
+		return fields, nil
+	}
+	return nil, fmt.Errorf("unsupported D-Bus type %q", signature[0])
+}
+
+// dbusAlignment 获取类型的对齐字节数
+//
+// 参数：
+//   - code: 类型代码
+//
+// 返回：
+//   - 对齐字节数
+func dbusAlignment(code byte) int {
+	switch code {
+	case 'n', 'q':
+		return 2
+	case 'b', 'i', 'u', 'h', 's', 'o', 'a':
+		return 4
+	case 'x', 't', 'd', '(', '{':
+		return 8
+	}
+	return 1
+}
+
+// splitDBusSignature 将签名拆分为完整类型
+//
+// 参数：
+//   - signature: 签名，例如 'a(yv)s'
+//
+// 返回：
+//   - 完整类型，例如 ['a(yv)', 's']，签名不完整时丢弃最后的部分
+func splitDBusSignature(signature string) []string {
+	var types []string
+	for start := 0; start < len(signature); {
+		end := start
+		// 跳过数组前缀
+		for end < len(signature) && signature[end] == 'a' {
+			end++
+		}
+		if end >= len(signature) {
+			break
+		}
+		if signature[end] == '(' || signature[end] == '{' {
+			depth := 0
+			for ; end < len(signature); end++ {
+				switch signature[end] {
+				case '(', '{':
+					depth++
+				case ')', '}':
+					depth--
+				}
+				if depth == 0 {
+					break
+				}
+			}
+			if end >= len(signature) {
+				break
+			}
+		}
+		types = append(types, signature[start:end+1])
+		start = end + 1
+	}
+	return types
+}
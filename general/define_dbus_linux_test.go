@@ -0,0 +1,497 @@
+//go:build linux
+
+/*
+File: define_dbus_linux_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-11 16:05:18
+
+Description: 测试 D-Bus 消息的编码和解码，以及通过 net.Pipe 模拟的总线进行方法调用
+*/
+
+package general
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// dbusVariant 测试中待编码的变体
+type dbusVariant struct {
+	signature string // 值的签名
+	value     any    // 值
+}
+
+// encodeDBusValue 按签名编码一个完整类型的值，使用小端字节序，供测试构造任意消息
+//
+// 参数：
+//   - e: 编码器
+//   - signature: 单个完整类型的签名
+//   - value: 值，数组和结构体为 []any，变体为 dbusVariant
+func encodeDBusValue(e *dbusEncoder, signature string, value any) {
+	switch signature[0] {
+	case 'y':
+		e.data = append(e.data, value.(byte))
+	case 'b':
+		var flag uint32
+		if value.(bool) {
+			flag = 1
+		}
+		e.writeUint32(flag)
+	case 'n':
+		e.align(2)
+		e.data = binary.LittleEndian.AppendUint16(e.data, uint16(value.(int16)))
+	case 'q':
+		e.align(2)
+		e.data = binary.LittleEndian.AppendUint16(e.data, value.(uint16))
+	case 'i':
+		e.writeUint32(uint32(value.(int32)))
+	case 'u', 'h':
+		e.writeUint32(value.(uint32))
+	case 'x':
+		e.align(8)
+		e.data = binary.LittleEndian.AppendUint64(e.data, uint64(value.(int64)))
+	case 't':
+		e.align(8)
+		e.data = binary.LittleEndian.AppendUint64(e.data, value.(uint64))
+	case 'd':
+		e.align(8)
+		e.data = binary.LittleEndian.AppendUint64(e.data, math.Float64bits(value.(float64)))
+	case 's', 'o':
+		e.writeString(value.(string))
+	case 'g':
+		e.writeSignature(value.(string))
+	case 'v':
+		variant := value.(dbusVariant)
+		e.writeSignature(variant.signature)
+		encodeDBusValue(e, variant.signature, variant.value)
+	case 'a':
+		e.align(4)
+		lengthPos := len(e.data)
+		e.writeUint32(0)
+		e.align(dbusAlignment(signature[1]))
+		start := len(e.data)
+		for _, element := range value.([]any) {
+			encodeDBusValue(e, signature[1:], element)
+		}
+		binary.LittleEndian.PutUint32(e.data[lengthPos:], uint32(len(e.data)-start))
+	case '(', '{':
+		e.align(8)
+		for index, fieldSignature := range splitDBusSignature(signature[1 : len(signature)-1]) {
+			encodeDBusValue(e, fieldSignature, value.([]any)[index])
+		}
+	default:
+		panic("unsupported D-Bus type " + signature)
+	}
+}
+
+// encodeDBusReply 编码总线发往客户端的消息
+//
+// 参数：
+//   - messageType: 消息类型
+//   - serial: 消息序号
+//   - replySerial: 所返回消息的序号
+//   - errorName: 错误名，为空时不写入
+//   - signature: 消息体签名
+//   - body: 消息体
+//
+// 返回：
+//   - 编码后的消息
+func encodeDBusReply(messageType byte, serial, replySerial uint32, errorName, signature string, body ...any) []byte {
+	bodyEncoder := &dbusEncoder{}
+	for index, valueSignature := range splitDBusSignature(signature) {
+		encodeDBusValue(bodyEncoder, valueSignature, body[index])
+	}
+
+	fields := []any{[]any{byte(dbusFieldReplySerial), dbusVariant{"u", replySerial}}}
+	if errorName != "" {
+		fields = append(fields, []any{byte(dbusFieldErrorName), dbusVariant{"s", errorName}})
+	}
+	if signature != "" {
+		fields = append(fields, []any{byte(dbusFieldSignature), dbusVariant{"g", signature}})
+	}
+	header := &dbusEncoder{}
+	header.data = append(header.data, 'l', messageType, 0, 1)
+	header.writeUint32(uint32(len(bodyEncoder.data)))
+	header.writeUint32(serial)
+	encodeDBusValue(header, "a(yv)", fields)
+	header.align(8)
+
+	return append(header.data, bodyEncoder.data...)
+}
+
+// fakeDBusReply 模拟总线对一次方法调用的应答
+type fakeDBusReply struct {
+	errorName string // 错误名，不为空时返回错误
+	signature string // 消息体签名
+	body      []any  // 消息体
+}
+
+// fakeDBusCall 模拟总线收到的方法调用
+type fakeDBusCall struct {
+	path   string // 对象路径
+	iface  string // 接口名
+	member string // 方法名
+	args   []any  // 参数
+}
+
+// dialFakeDBus 通过 net.Pipe 连接模拟的总线
+//
+//   - 每次应答前先发送一条信号和一条序号不匹配的返回，客户端应跳过它们
+//
+// 参数：
+//   - t: 测试对象
+//   - handler: 处理方法调用的函数
+//
+// 返回：
+//   - 与模拟总线的 D-Bus 连接
+func dialFakeDBus(t *testing.T, handler func(call fakeDBusCall) fakeDBusReply) *dbusConn {
+	client, server := net.Pipe()
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	go func() {
+		peer := &dbusConn{conn: server, reader: bufio.NewReader(server)}
+		for serial := uint32(1); ; serial++ {
+			messageType, fields, args, err := peer.readMessage()
+			if err != nil {
+				return
+			}
+			if messageType != dbusMessageMethodCall {
+				continue
+			}
+			call := fakeDBusCall{args: args}
+			call.path, _ = fields[dbusFieldPath].(string)
+			call.iface, _ = fields[dbusFieldInterface].(string)
+			call.member, _ = fields[dbusFieldMember].(string)
+			reply := handler(call)
+
+			replyType := byte(dbusMessageMethodReturn)
+			if reply.errorName != "" {
+				replyType = dbusMessageError
+			}
+			messages := [][]byte{
+				encodeDBusReply(4, 1000+serial, 0, "", "s", "signal"),
+				encodeDBusReply(dbusMessageMethodReturn, 2000+serial, serial+100, "", "s", "stale"),
+				encodeDBusReply(replyType, 3000+serial, serial, reply.errorName, reply.signature, reply.body...),
+			}
+			for _, message := range messages {
+				if _, err := server.Write(message); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return &dbusConn{conn: client, reader: bufio.NewReader(client)}
+}
+
+// readDBusMessage 从字节中读取一条消息
+func readDBusMessage(message []byte) (byte, map[byte]any, []any, error) {
+	bus := &dbusConn{reader: bufio.NewReader(bytes.NewReader(message))}
+	return bus.readMessage()
+}
+
+func TestEncodeDBusCall(t *testing.T) {
+	message, err := encodeDBusCall(7, "org.example.Service", "/org/example/Object", "org.example.Interface", "Method", []any{"first", []string{"a", "bc", ""}, []string{}, "last"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serial := binary.LittleEndian.Uint32(message[8:12]); serial != 7 {
+		t.Errorf("serial = %d, want 7", serial)
+	}
+	if message[2] != dbusFlagNoAutoStart {
+		t.Errorf("flags = %#x, want %#x", message[2], dbusFlagNoAutoStart)
+	}
+
+	messageType, fields, body, err := readDBusMessage(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if messageType != dbusMessageMethodCall {
+		t.Errorf("message type = %d, want %d", messageType, dbusMessageMethodCall)
+	}
+	wantFields := map[byte]any{
+		dbusFieldPath:        "/org/example/Object",
+		dbusFieldDestination: "org.example.Service",
+		dbusFieldInterface:   "org.example.Interface",
+		dbusFieldMember:      "Method",
+		dbusFieldSignature:   "sasass",
+	}
+	if !reflect.DeepEqual(fields, wantFields) {
+		t.Errorf("fields = %v, want %v", fields, wantFields)
+	}
+	wantBody := []any{"first", []any{"a", "bc", ""}, []any{}, "last"}
+	if !reflect.DeepEqual(body, wantBody) {
+		t.Errorf("body = %#v, want %#v", body, wantBody)
+	}
+}
+
+func TestEncodeDBusCallWithoutArgs(t *testing.T) {
+	message, err := encodeDBusCall(1, "org.freedesktop.DBus", "/org/freedesktop/DBus", "", "Hello", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(message)%8 != 0 {
+		t.Errorf("message length %d is not padded to 8 bytes", len(message))
+	}
+	_, fields, body, err := readDBusMessage(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fields[dbusFieldInterface]; ok {
+		t.Error("empty interface should not be written")
+	}
+	if _, ok := fields[dbusFieldSignature]; ok {
+		t.Error("signature should not be written without arguments")
+	}
+	if body != nil {
+		t.Errorf("body = %v, want nil", body)
+	}
+}
+
+func TestEncodeDBusCallUnsupportedArg(t *testing.T) {
+	if _, err := encodeDBusCall(1, "a.b", "/", "a.b", "M", []any{42}); err == nil {
+		t.Error("expected an error for an int argument")
+	}
+}
+
+func TestDBusDecodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		signature string
+		value     any
+		want      any // 为 nil 时与 value 相同
+	}{
+		{"y", byte(0xFE), nil},
+		{"b", true, nil},
+		{"b", false, nil},
+		{"n", int16(-2), nil},
+		{"q", uint16(65535), nil},
+		{"i", int32(-100000), nil},
+		{"u", uint32(4000000000), nil},
+		{"h", uint32(3), nil},
+		{"x", int64(-1 << 40), nil},
+		{"t", uint64(1 << 63), nil},
+		{"d", 3.25, nil},
+		{"s", "", nil},
+		{"s", "héllo", nil},
+		{"o", "/org/freedesktop/systemd1/unit/sshd_2eservice", nil},
+		{"g", "a{sv}", nil},
+		{"v", dbusVariant{"s", "running"}, "running"},
+		{"v", dbusVariant{"t", uint64(1725600000123456)}, uint64(1725600000123456)},
+		{"v", dbusVariant{"as", []any{"a", "b"}}, []any{"a", "b"}},
+		{"at", []any{uint64(1), uint64(2)}, nil},
+		{"at", []any{}, nil},
+		{"a{sv}", []any{[]any{"Id", dbusVariant{"s", "x"}}, []any{"Size", dbusVariant{"u", uint32(9)}}}, []any{[]any{"Id", "x"}, []any{"Size", uint32(9)}}},
+		{"(ybt)", []any{byte(1), true, uint64(2)}, nil},
+		{"a(ssssssouso)", []any{[]any{"a.service", "A", "loaded", "failed", "failed", "", "/a", uint32(0), "", "/"}}, nil},
+		{"aas", []any{[]any{"x"}, []any{}, []any{"y", "z"}}, nil},
+	}
+	for _, tt := range tests {
+		// 以一个字节开头，检验对齐填充
+		encoder := &dbusEncoder{data: []byte{0xAA}}
+		encodeDBusValue(encoder, tt.signature, tt.value)
+		decoder := &dbusDecoder{data: encoder.data, order: binary.LittleEndian, pos: 1}
+		got, err := decoder.decode(tt.signature)
+		if err != nil {
+			t.Errorf("decode %q: %v", tt.signature, err)
+			continue
+		}
+		want := tt.want
+		if want == nil {
+			want = tt.value
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("decode %q = %#v, want %#v", tt.signature, got, want)
+		}
+		if decoder.pos != len(encoder.data) {
+			t.Errorf("decode %q consumed %d bytes, want %d", tt.signature, decoder.pos, len(encoder.data))
+		}
+	}
+}
+
+func TestDBusDecodeBigEndian(t *testing.T) {
+	data := []byte{0x00, 0x00, 0x00, 0x2A, 0x00, 0x00, 0x00, 0x02, 'h', 'i', 0x00}
+	decoder := &dbusDecoder{data: data, order: binary.BigEndian}
+	if value, err := decoder.decode("u"); err != nil || value != uint32(42) {
+		t.Errorf("decode u = %v, %v, want 42", value, err)
+	}
+	if value, err := decoder.decode("s"); err != nil || value != "hi" {
+		t.Errorf("decode s = %v, %v, want \"hi\"", value, err)
+	}
+}
+
+func TestDBusDecodeMalformed(t *testing.T) {
+	encode := func(signature string, value any) []byte {
+		encoder := &dbusEncoder{}
+		encodeDBusValue(encoder, signature, value)
+		return encoder.data
+	}
+
+	tests := []struct {
+		name      string
+		signature string
+		data      []byte
+	}{
+		{"empty byte", "y", nil},
+		{"short uint32", "u", []byte{1, 2, 3}},
+		{"short uint64", "t", []byte{1, 2, 3, 4, 5, 6, 7}},
+		{"string length beyond data", "s", encode("s", "hello")[:7]},
+		{"string terminator missing", "s", encode("s", "hello")[:9]},
+		{"signature beyond data", "g", []byte{10, 'a', 's'}},
+		{"array length beyond data", "as", encode("as", []any{"a", "b"})[:12]},
+		{"array element beyond array", "at", []byte{4, 0, 0, 0, 0, 0, 0, 0, 1, 2, 3, 4}},
+		{"variant with two types", "v", encode("g", "ss")},
+		{"variant with empty signature", "v", encode("g", "")},
+		{"struct field missing", "(su)", encode("s", "a")},
+		{"unsupported type", "z", []byte{0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		decoder := &dbusDecoder{data: tt.data, order: binary.LittleEndian}
+		if value, err := decoder.decode(tt.signature); err == nil {
+			t.Errorf("%s: decode %q = %#v, want an error", tt.name, tt.signature, value)
+		}
+	}
+}
+
+func TestDBusReadMessageMalformed(t *testing.T) {
+	valid := encodeDBusReply(dbusMessageMethodReturn, 1, 1, "", "s", "value")
+	if _, _, body, err := readDBusMessage(valid); err != nil || !reflect.DeepEqual(body, []any{"value"}) {
+		t.Fatalf("valid message: body = %v, err = %v", body, err)
+	}
+
+	invalidOrder := bytes.Clone(valid)
+	invalidOrder[0] = 'x'
+	tooLarge := bytes.Clone(valid)
+	binary.LittleEndian.PutUint32(tooLarge[4:8], 200<<20)
+	// 消息体长度与数据一致，但不足以解码签名中的类型
+	shortBody := encodeDBusReply(dbusMessageMethodReturn, 1, 1, "", "t", uint64(1))
+	binary.LittleEndian.PutUint32(shortBody[4:8], 4)
+	shortBody = shortBody[:len(shortBody)-4]
+
+	tests := []struct {
+		name    string
+		message []byte
+		wantErr error // 为 nil 时只要求返回错误
+	}{
+		{"empty", nil, io.EOF},
+		{"short fixed part", valid[:10], io.ErrUnexpectedEOF},
+		{"truncated header", valid[:20], io.ErrUnexpectedEOF},
+		{"truncated body", valid[:len(valid)-3], io.ErrUnexpectedEOF},
+		{"invalid byte order", invalidOrder, nil},
+		{"too large", tooLarge, nil},
+		{"body shorter than signature", shortBody, io.ErrUnexpectedEOF},
+	}
+	for _, tt := range tests {
+		_, _, _, err := readDBusMessage(tt.message)
+		switch {
+		case err == nil:
+			t.Errorf("%s: expected an error", tt.name)
+		case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSplitDBusSignature(t *testing.T) {
+	tests := []struct {
+		signature string
+		want      []string
+	}{
+		{"", nil},
+		{"s", []string{"s"}},
+		{"sas", []string{"s", "as"}},
+		{"a(yv)s", []string{"a(yv)", "s"}},
+		{"a{sv}aa{sv}", []string{"a{sv}", "aa{sv}"}},
+		{"(s(ii))u", []string{"(s(ii))", "u"}},
+		{"sa", []string{"s"}},
+		{"s(is", []string{"s"}},
+	}
+	for _, tt := range tests {
+		if got := splitDBusSignature(tt.signature); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitDBusSignature(%q) = %q, want %q", tt.signature, got, tt.want)
+		}
+	}
+}
+
+func TestDBusCall(t *testing.T) {
+	var calls []fakeDBusCall
+	bus := dialFakeDBus(t, func(call fakeDBusCall) fakeDBusReply {
+		calls = append(calls, call)
+		switch call.member {
+		case "Echo":
+			return fakeDBusReply{signature: "sas", body: call.args}
+		case "Get":
+			return fakeDBusReply{signature: "v", body: []any{dbusVariant{"s", "running"}}}
+		case "Empty":
+			return fakeDBusReply{}
+		}
+		return fakeDBusReply{errorName: "org.freedesktop.DBus.Error.UnknownMethod", signature: "s", body: []any{"no such method"}}
+	})
+
+	body, err := bus.Call("org.example", "/org/example", "org.example.Echo", "Echo", "hello", []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []any{"hello", []any{"a", "b"}}; !reflect.DeepEqual(body, want) {
+		t.Errorf("Echo = %#v, want %#v", body, want)
+	}
+
+	value, err := bus.GetProperty("org.example", "/org/example/unit", "org.example.Unit", "State")
+	if err != nil || value != "running" {
+		t.Errorf("GetProperty = %v, %v, want \"running\"", value, err)
+	}
+	if last := calls[len(calls)-1]; last.iface != "org.freedesktop.DBus.Properties" || last.path != "/org/example/unit" || !reflect.DeepEqual(last.args, []any{"org.example.Unit", "State"}) {
+		t.Errorf("GetProperty sent %+v", last)
+	}
+
+	if _, err := bus.Call("org.example", "/", "org.example", "Empty"); err != nil {
+		t.Errorf("Empty: %v", err)
+	}
+
+	_, err = bus.Call("org.example", "/", "org.example", "Missing")
+	var dbusErr *dbusError
+	if !errors.As(err, &dbusErr) {
+		t.Fatalf("Missing: error = %v, want *dbusError", err)
+	}
+	if dbusErr.Name != "org.freedesktop.DBus.Error.UnknownMethod" || dbusErr.Message != "no such method" {
+		t.Errorf("Missing: error = %+v", dbusErr)
+	}
+	if !strings.HasSuffix(err.Error(), ": no such method") {
+		t.Errorf("Missing: error text = %q", err.Error())
+	}
+}
+
+func TestDBusCallEmptyProperty(t *testing.T) {
+	bus := dialFakeDBus(t, func(call fakeDBusCall) fakeDBusReply {
+		return fakeDBusReply{}
+	})
+	if _, err := bus.GetProperty("org.example", "/", "org.example", "State"); err == nil {
+		t.Error("expected an error for an empty property reply")
+	}
+}
+
+func TestDBusCallConnectionClosed(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	go func() {
+		// 读取调用后不应答直接断开
+		io.ReadFull(server, make([]byte, 16))
+		server.Close()
+	}()
+
+	bus := &dbusConn{conn: client, reader: bufio.NewReader(client)}
+	if _, err := bus.Call("org.example", "/", "org.example", "Method"); err == nil {
+		t.Error("expected an error when the bus closes the connection")
+	}
+}
@@ -0,0 +1,21 @@
+//go:build darwin
+
+/*
+File: define_dimm_darwin.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-02 11:04:37
+
+Description: 内存插槽和内存条信息
+*/
+
+package general
+
+// readMemoryModules 读取内存插槽和内存条信息，macOS 未提供 SMBIOS 数据，返回空值
+//
+// 返回：
+//   - 内存插槽和内存条信息
+//   - 错误信息
+func readMemoryModules() (memoryInventory, error) {
+	return memoryInventory{}, nil
+}
@@ -0,0 +1,296 @@
+//go:build linux
+
+/*
+File: define_dimm_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-02 09:20:15
+
+Description: 从 SMBIOS 读取内存插槽和内存条信息
+
+- /sys/firmware/dmi/entries/<类型>-<序号>/raw 为 SMBIOS 结构的原始数据：格式化区域之后是以 NUL 结尾的字符串集
+- 类型 16（Physical Memory Array）描述插槽数和纠错类型，类型 17（Memory Device）描述每个插槽上的内存条
+- raw 文件通常只有 root 可读
+*/
+
+package general
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// dmiEntriesPath SMBIOS 结构的 sysfs 目录
+var dmiEntriesPath = "/sys/firmware/dmi/entries"
+
+// SMBIOS 结构类型
+const (
+	smbiosPhysicalMemoryArray = 16 // Physical Memory Array
+	smbiosMemoryDevice        = 17 // Memory Device
+)
+
+// smbiosErrorCorrection Physical Memory Array 的纠错类型
+var smbiosErrorCorrection = map[byte]string{
+	0x03: "None",
+	0x04: "Parity",
+	0x05: "Single-bit ECC",
+	0x06: "Multi-bit ECC",
+	0x07: "CRC",
+}
+
+// smbiosMemoryType Memory Device 的内存类型
+var smbiosMemoryType = map[byte]string{
+	0x07: "RAM",
+	0x0F: "SDRAM",
+	0x12: "DDR",
+	0x13: "DDR2",
+	0x18: "DDR3",
+	0x1A: "DDR4",
+	0x1B: "LPDDR",
+	0x1C: "LPDDR2",
+	0x1D: "LPDDR3",
+	0x1E: "LPDDR4",
+	0x20: "HBM",
+	0x21: "HBM2",
+	0x22: "DDR5",
+	0x23: "LPDDR5",
+	0x24: "HBM3",
+}
+
+// smbiosFileReader 读取 SMBIOS 结构原始数据的函数
+var smbiosFileReader = os.ReadFile
+
+// memoryArray 内存插槽信息，硬件不会在运行时变化，只读取一次
+var memoryArray struct {
+	once      sync.Once
+	inventory memoryInventory // 内存插槽和内存条信息
+	err       error           // 读取错误
+}
+
+// readMemoryModules 读取内存插槽和内存条信息，只在首次调用时读取 SMBIOS，无权读取时发送一次通知
+//
+// 返回：
+//   - 内存插槽和内存条信息
+//   - 错误信息
+func readMemoryModules() (memoryInventory, error) {
+	memoryArray.once.Do(func() {
+		var inventory memoryInventory
+		inventory.slots, inventory.ecc, inventory.modules, memoryArray.err = readSMBIOSMemory()
+		for _, module := range inventory.modules {
+			if module.Populated {
+				inventory.slotsPopulated++
+			}
+		}
+		inventory.slots = max(inventory.slots, len(inventory.modules))
+		memoryArray.inventory = inventory
+		if errors.Is(memoryArray.err, os.ErrPermission) {
+			Notify("Memory module information from SMBIOS requires root permission")
+		}
+	})
+	return memoryArray.inventory, memoryArray.err
+}
+
+// readSMBIOSMemory 读取 SMBIOS 类型 16 和 17 的结构
+//
+// 返回：
+//   - 插槽数
+//   - 纠错类型
+//   - 内存条信息
+//   - 错误信息，没有 SMBIOS 数据（例如部分虚拟机）时不视为错误
+func readSMBIOSMemory() (int, string, []MemoryModule, error) {
+	var (
+		slots           int
+		errorCorrection string
+		modules         []MemoryModule
+	)
+
+	arrays, err := readSMBIOSEntries(smbiosPhysicalMemoryArray)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	systemArrays := make(map[uint16]bool) // 系统内存的插槽组，键为结构句柄
+	for _, array := range arrays {
+		// 0x05 Use 为 0x03 表示系统内存，0x06 为纠错类型，0x0D 为插槽数
+		if len(array.data) < 0x0F || array.data[0x05] != 0x03 {
+			continue
+		}
+		systemArrays[array.handle] = true
+		slots += int(binary.LittleEndian.Uint16(array.data[0x0D:0x0F]))
+		if errorCorrection == "" {
+			errorCorrection = smbiosErrorCorrection[array.data[0x06]]
+		}
+	}
+
+	devices, err := readSMBIOSEntries(smbiosMemoryDevice)
+	if err != nil {
+		return slots, errorCorrection, nil, err
+	}
+	for _, device := range devices {
+		if len(device.data) < 0x15 {
+			continue
+		}
+		if arrayHandle := binary.LittleEndian.Uint16(device.data[0x04:0x06]); len(systemArrays) > 0 && !systemArrays[arrayHandle] {
+			continue
+		}
+		modules = append(modules, parseMemoryDevice(device))
+	}
+
+	return slots, errorCorrection, modules, nil
+}
+
+// parseMemoryDevice 解析 SMBIOS 类型 17 的结构
+//
+// 参数：
+//   - device: 类型 17 的结构
+//
+// 返回：
+//   - 内存条信息，插槽为空时只有插槽名
+func parseMemoryDevice(device smbiosEntry) MemoryModule {
+	data := device.data
+	word := func(offset int) uint16 {
+		if len(data) < offset+2 {
+			return 0
+		}
+		return binary.LittleEndian.Uint16(data[offset : offset+2])
+	}
+	dword := func(offset int) uint32 {
+		if len(data) < offset+4 {
+			return 0
+		}
+		return binary.LittleEndian.Uint32(data[offset : offset+4])
+	}
+
+	module := MemoryModule{
+		Locator:     device.text(0x10),
+		BankLocator: device.text(0x11),
+	}
+
+	// 0x0C Size：0 为空插槽，0xFFFF 为未知，0x7FFF 表示实际大小在 0x1C Extended Size（MiB），最高位为 1 时单位为 KiB，否则为 MiB
+	switch size := word(0x0C); {
+	case size == 0:
+		return module
+	case size == 0xFFFF:
+	case size == 0x7FFF:
+		module.Size = uint64(dword(0x1C)&0x7FFFFFFF) << 20
+	case size&0x8000 != 0:
+		module.Size = uint64(size&0x7FFF) << 10
+	default:
+		module.Size = uint64(size) << 20
+	}
+	module.Populated = true
+
+	module.Type = smbiosMemoryType[data[0x12]]
+	// 0x20 Configured Memory Speed，0xFFFF 表示实际速率在 0x58 Extended Configured Memory Speed；未配置时使用 0x15 Speed
+	module.Speed = int(word(0x20))
+	if module.Speed == 0xFFFF {
+		module.Speed = int(dword(0x58))
+	}
+	if module.Speed == 0 {
+		module.Speed = int(word(0x15))
+	}
+	module.Manufacturer = device.text(0x17)
+	module.PartNumber = device.text(0x1A)
+	// 0x08 Total Width 大于 0x0A Data Width 时有校验位，0xFFFF 为未知
+	if totalWidth, dataWidth := word(0x08), word(0x0A); totalWidth != 0xFFFF && dataWidth != 0xFFFF {
+		module.ECC = totalWidth > dataWidth
+	}
+
+	return module
+}
+
+// smbiosEntry SMBIOS 结构
+type smbiosEntry struct {
+	handle  uint16   // 结构句柄
+	data    []byte   // 格式化区域，包括 4 字节的结构头
+	strings []string // 字符串集
+}
+
+// text 读取格式化区域中字符串编号指向的字符串
+//
+// 参数：
+//   - offset: 字符串编号在格式化区域中的偏移
+//
+// 返回：
+//   - 字符串，编号为 0、超出范围或为占位值时为空
+func (entry smbiosEntry) text(offset int) string {
+	if offset >= len(entry.data) {
+		return ""
+	}
+	index := int(entry.data[offset])
+	if index == 0 || index > len(entry.strings) {
+		return ""
+	}
+	value := strings.TrimSpace(entry.strings[index-1])
+	// 厂商常用的占位值
+	switch strings.ToLower(value) {
+	case "unknown", "not specified", "to be filled by o.e.m.", "none":
+		return ""
+	}
+	return value
+}
+
+// readSMBIOSEntries 读取指定类型的 SMBIOS 结构
+//
+// 参数：
+//   - entryType: 结构类型
+//
+// 返回：
+//   - 结构列表，按序号排列
+//   - 错误信息
+func readSMBIOSEntries(entryType int) ([]smbiosEntry, error) {
+	prefix := strconv.Itoa(entryType) + "-"
+	paths, _ := filepath.Glob(filepath.Join(dmiEntriesPath, prefix+"*", "raw"))
+	// 目录名为 '<类型>-<序号>'，按序号而非字典序排列
+	sequence := func(path string) int {
+		index, _ := strconv.Atoi(strings.TrimPrefix(filepath.Base(filepath.Dir(path)), prefix))
+		return index
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return sequence(paths[i]) < sequence(paths[j])
+	})
+
+	var entries []smbiosEntry
+	for _, path := range paths {
+		raw, err := smbiosFileReader(path)
+		if err != nil {
+			return entries, err
+		}
+		if entry, ok := parseSMBIOSEntry(raw); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// parseSMBIOSEntry 解析 SMBIOS 结构的原始数据
+//
+// 参数：
+//   - raw: 原始数据
+//
+// 返回：
+//   - SMBIOS 结构
+//   - 数据有效返回 true，否则返回 false
+func parseSMBIOSEntry(raw []byte) (smbiosEntry, bool) {
+	if len(raw) < 4 || int(raw[1]) > len(raw) || raw[1] < 4 {
+		return smbiosEntry{}, false
+	}
+	length := int(raw[1])
+	entry := smbiosEntry{
+		handle: binary.LittleEndian.Uint16(raw[2:4]),
+		data:   raw[:length],
+	}
+	// 字符串集以两个 NUL 结尾
+	for _, value := range strings.Split(string(raw[length:]), "\x00") {
+		if value == "" {
+			break
+		}
+		entry.strings = append(entry.strings, value)
+	}
+	return entry, true
+}
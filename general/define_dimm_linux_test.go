@@ -0,0 +1,164 @@
+//go:build linux
+
+/*
+File: define_dimm_linux_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-11 14:20:52
+
+Description: 测试 SMBIOS 内存插槽信息的解析和缓存
+*/
+
+package general
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// resetMemoryArray 清空内存插槽信息的缓存，测试结束后恢复 SMBIOS 读取路径、读取函数和通知
+//
+// 参数：
+//   - t: 测试对象
+func resetMemoryArray(t *testing.T) {
+	oldPath, oldReader, oldNotifier := dmiEntriesPath, smbiosFileReader, Notifier
+	reset := func() {
+		memoryArray.once = sync.Once{}
+		memoryArray.inventory = memoryInventory{}
+		memoryArray.err = nil
+	}
+	reset()
+	Notifier = nil
+	t.Cleanup(func() {
+		reset()
+		dmiEntriesPath, smbiosFileReader, Notifier = oldPath, oldReader, oldNotifier
+	})
+}
+
+// writeSMBIOSEntry 写入一个 SMBIOS 结构的原始数据
+//
+// 参数：
+//   - t: 测试对象
+//   - dirPath: SMBIOS 结构目录
+//   - name: 结构目录名，例如 '17-0'
+//   - data: 格式化区域，结构头中的长度按数据长度填写
+//   - texts: 字符串集
+func writeSMBIOSEntry(t *testing.T, dirPath, name string, data []byte, texts ...string) {
+	t.Helper()
+	data[1] = byte(len(data))
+	raw := slices.Clone(data)
+	for _, text := range texts {
+		raw = append(append(raw, text...), 0)
+	}
+	raw = append(raw, 0)
+	if len(texts) == 0 {
+		raw = append(raw, 0)
+	}
+
+	entryPath := filepath.Join(dirPath, name)
+	if err := os.MkdirAll(entryPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(entryPath, "raw"), raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// useSMBIOSFixture 构造一个双插槽、只安装一条内存的 SMBIOS 目录并替换读取路径
+//
+// 参数：
+//   - t: 测试对象
+func useSMBIOSFixture(t *testing.T) {
+	dirPath := t.TempDir()
+
+	array := make([]byte, 0x0F)
+	array[0] = smbiosPhysicalMemoryArray
+	binary.LittleEndian.PutUint16(array[2:4], 0x1000)
+	array[0x05] = 0x03 // 系统内存
+	array[0x06] = 0x03 // 无纠错
+	binary.LittleEndian.PutUint16(array[0x0D:0x0F], 2)
+	writeSMBIOSEntry(t, dirPath, "16-0", array)
+
+	// memoryDevice 构造类型 17 的格式化区域
+	memoryDevice := func(handle, size uint16) []byte {
+		device := make([]byte, 0x28)
+		device[0] = smbiosMemoryDevice
+		binary.LittleEndian.PutUint16(device[2:4], handle)
+		binary.LittleEndian.PutUint16(device[0x04:0x06], 0x1000)
+		binary.LittleEndian.PutUint16(device[0x08:0x0A], 64)
+		binary.LittleEndian.PutUint16(device[0x0A:0x0C], 64)
+		binary.LittleEndian.PutUint16(device[0x0C:0x0E], size)
+		device[0x10], device[0x11] = 1, 2
+		return device
+	}
+	populated := memoryDevice(0x1100, 16384)
+	populated[0x12] = 0x1A // DDR4
+	binary.LittleEndian.PutUint16(populated[0x15:0x17], 3200)
+	populated[0x17], populated[0x1A] = 3, 4
+	binary.LittleEndian.PutUint16(populated[0x20:0x22], 2933)
+	writeSMBIOSEntry(t, dirPath, "17-0", populated, "DIMM_A1", "BANK 0", "Samsung", "M378A2K43DB1-CWE ")
+	writeSMBIOSEntry(t, dirPath, "17-1", memoryDevice(0x1101, 0), "DIMM_A2", "BANK 1")
+
+	dmiEntriesPath = dirPath
+}
+
+func TestReadMemoryModules(t *testing.T) {
+	resetMemoryArray(t)
+	useSMBIOSFixture(t)
+
+	reads := 0
+	smbiosFileReader = func(name string) ([]byte, error) {
+		reads++
+		return os.ReadFile(name)
+	}
+
+	inventory, err := readMemoryModules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inventory.slots != 2 || inventory.slotsPopulated != 1 || inventory.ecc != "None" {
+		t.Errorf("got %d slots, %d populated, ECC %q, want 2 slots, 1 populated, ECC \"None\"", inventory.slots, inventory.slotsPopulated, inventory.ecc)
+	}
+	want := []MemoryModule{
+		{Locator: "DIMM_A1", BankLocator: "BANK 0", Populated: true, Size: 16 << 30, Type: "DDR4", Speed: 2933, Manufacturer: "Samsung", PartNumber: "M378A2K43DB1-CWE"},
+		{Locator: "DIMM_A2", BankLocator: "BANK 1"},
+	}
+	if !slices.Equal(inventory.modules, want) {
+		t.Errorf("modules = %+v, want %+v", inventory.modules, want)
+	}
+
+	// watch 模式中每次刷新都会调用，SMBIOS 只在首次调用时读取
+	firstReads := reads
+	for range 3 {
+		if again, _ := readMemoryModules(); again.slots != inventory.slots || len(again.modules) != len(inventory.modules) {
+			t.Errorf("cached inventory changed: %+v", again)
+		}
+	}
+	if reads != firstReads {
+		t.Errorf("SMBIOS was read %d more times after the first call", reads-firstReads)
+	}
+}
+
+func TestReadMemoryModulesPermissionDenied(t *testing.T) {
+	resetMemoryArray(t)
+	useSMBIOSFixture(t)
+
+	smbiosFileReader = func(name string) ([]byte, error) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+	}
+
+	for range 3 {
+		if _, err := readMemoryModules(); !errors.Is(err, os.ErrPermission) {
+			t.Fatalf("readMemoryModules error = %v, want permission denied", err)
+		}
+	}
+	if len(Notifier) != 1 {
+		t.Errorf("got %d notifications, want 1: %q", len(Notifier), Notifier)
+	}
+}
@@ -0,0 +1,360 @@
+//go:build linux
+
+/*
+File: define_dpkg_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-19 10:12:36
+
+Description: 解析 dpkg/apt 数据库，Debian 系专用
+
+- /var/lib/dpkg/status 记录所有包的状态，每个包一段，段之间以空行分隔
+- /var/lib/apt/extended_states 记录自动安装（作为依赖安装）的包
+*/
+
+package general
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	dpkgStatusFile        = "/var/lib/dpkg/status"         // dpkg 状态数据库
+	aptExtendedStatesFile = "/var/lib/apt/extended_states" // apt 扩展状态数据库
+)
+
+// dpkgPackage dpkg 状态数据库中的一个包
+type dpkgPackage struct {
+	Name          string // 包名
+	Architecture  string // 架构
+	Version       string // 版本
+	Status        string // 状态，例如 'install ok installed'
+	InstalledSize uint64 // 安装大小，单位为 KiB
+}
+
+// installed 包是否已安装
+//
+// 返回：
+//   - 已安装返回 true，否则返回 false
+func (pkg dpkgPackage) installed() bool {
+	fields := strings.Fields(pkg.Status)
+	return len(fields) == 3 && fields[2] == "installed"
+}
+
+// readDebianStanzas 读取 Debian 控制文件格式的文件，每段解析为一个字段映射
+//
+//   - 以空白开头的行为上一字段的续行，只保留首行的值
+//
+// 参数：
+//   - filePath: 文件路径
+//
+// 返回：
+//   - 所有段
+//   - 错误信息
+func readDebianStanzas(filePath string) ([]map[string]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		stanzas []map[string]string
+		stanza  = make(map[string]string)
+	)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024) // 部分包的描述行很长
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.TrimSpace(line) == "":
+			if len(stanza) > 0 {
+				stanzas = append(stanzas, stanza)
+				stanza = make(map[string]string)
+			}
+		case line[0] == ' ' || line[0] == '\t':
+			continue
+		default:
+			if key, value, found := strings.Cut(line, ":"); found {
+				stanza[key] = strings.TrimSpace(value)
+			}
+		}
+	}
+	if len(stanza) > 0 {
+		stanzas = append(stanzas, stanza)
+	}
+
+	return stanzas, scanner.Err()
+}
+
+// parseDpkgStatus 解析 dpkg 状态数据库
+//
+// 参数：
+//   - statusPath: dpkg 状态数据库路径
+//
+// 返回：
+//   - 数据库中的所有包，包括已卸载但保留配置文件的包
+//   - 错误信息
+func parseDpkgStatus(statusPath string) ([]dpkgPackage, error) {
+	stanzas, err := readDebianStanzas(statusPath)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := make([]dpkgPackage, 0, len(stanzas))
+	for _, stanza := range stanzas {
+		installedSize, _ := strconv.ParseUint(stanza["Installed-Size"], 10, 64)
+		packages = append(packages, dpkgPackage{
+			Name:          stanza["Package"],
+			Architecture:  stanza["Architecture"],
+			Version:       stanza["Version"],
+			Status:        stanza["Status"],
+			InstalledSize: installedSize,
+		})
+	}
+
+	return packages, nil
+}
+
+// parseAptExtendedStates 解析 apt 扩展状态数据库
+//
+// 参数：
+//   - extendedStatesPath: apt 扩展状态数据库路径
+//
+// 返回：
+//   - 自动安装的包，键为包名，值为该包自动安装的架构集合
+//   - 错误信息
+func parseAptExtendedStates(extendedStatesPath string) (map[string]map[string]bool, error) {
+	stanzas, err := readDebianStanzas(extendedStatesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	autoInstalled := make(map[string]map[string]bool)
+	for _, stanza := range stanzas {
+		if stanza["Auto-Installed"] != "1" {
+			continue
+		}
+		name := stanza["Package"]
+		if autoInstalled[name] == nil {
+			autoInstalled[name] = make(map[string]bool)
+		}
+		autoInstalled[name][stanza["Architecture"]] = true
+	}
+
+	return autoInstalled, nil
+}
+
+// isAutoInstalled 判断包是否为自动安装
+//
+//   - 架构无关（all）的包在 apt 扩展状态数据库中记录为本机架构，因此任一架构自动安装即视为自动安装
+//
+// 参数：
+//   - autoInstalled: 自动安装的包
+//   - pkg: 待判断的包
+//
+// 返回：
+//   - 自动安装返回 true，否则返回 false
+func isAutoInstalled(autoInstalled map[string]map[string]bool, pkg dpkgPackage) bool {
+	architectures := autoInstalled[pkg.Name]
+	if pkg.Architecture == "all" {
+		return len(architectures) > 0
+	}
+	return architectures[pkg.Architecture]
+}
+
+// countDpkgPackages 统计已安装包的数据
+//
+// 参数：
+//   - statusPath: dpkg 状态数据库路径
+//   - extendedStatesPath: apt 扩展状态数据库路径，不存在时所有包均视为单独指定安装
+//
+// 返回：
+//   - 已安装包的数据
+//   - 错误信息
+func countDpkgPackages(statusPath, extendedStatesPath string) (PackageInfo, error) {
+	var packageData PackageInfo
+
+	packages, err := parseDpkgStatus(statusPath)
+	if err != nil {
+		return packageData, err
+	}
+	autoInstalled, err := parseAptExtendedStates(extendedStatesPath)
+	if err != nil && !os.IsNotExist(err) {
+		return packageData, err
+	}
+
+	for _, pkg := range packages {
+		if !pkg.installed() {
+			continue
+		}
+		packageData.PackageTotalCount++
+		packageData.PackageTotalSize += pkg.InstalledSize * 1024
+		if isAutoInstalled(autoInstalled, pkg) {
+			packageData.AsDependencyCount++
+		} else {
+			packageData.AsExplicitCount++
+		}
+	}
+
+	return packageData, nil
+}
+
+// latestDpkgKernel 查找已安装的最新内核
+//
+//   - 只统计形如 'linux-image-6.1.0-26-amd64' 的内核包，忽略 'linux-image-amd64' 等元包和调试符号包
+//
+// 参数：
+//   - statusPath: dpkg 状态数据库路径
+//
+// 返回：
+//   - 最新内核的版本号，即包名中的内核版本部分，与 'uname -r' 格式一致，没有内核包时为空
+//   - 错误信息
+func latestDpkgKernel(statusPath string) (string, error) {
+	packages, err := parseDpkgStatus(statusPath)
+	if err != nil {
+		return "", err
+	}
+
+	var latestKernel, latestVersion string
+	for _, pkg := range packages {
+		if !pkg.installed() || !strings.HasPrefix(pkg.Name, "linux-image-") {
+			continue
+		}
+		release := strings.TrimPrefix(pkg.Name, "linux-image-")
+		release = strings.TrimPrefix(release, "unsigned-") // Ubuntu 的未签名内核
+		if release == "" || !isDigit(release[0]) || strings.HasSuffix(release, "-dbg") || strings.HasSuffix(release, "-dbgsym") {
+			continue
+		}
+		if latestKernel == "" || compareDebianVersion(pkg.Version, latestVersion) > 0 {
+			latestKernel = release
+			latestVersion = pkg.Version
+		}
+	}
+
+	return latestKernel, nil
+}
+
+// compareDebianVersion 按 Debian 规则比较两个版本号
+//
+//   - 版本号格式为 [epoch:]upstream_version[-debian_revision]
+//
+// 参数：
+//   - a: 版本号 a
+//   - b: 版本号 b
+//
+// 返回：
+//   - a 较新返回 1，b 较新返回 -1，相同返回 0
+func compareDebianVersion(a, b string) int {
+	splitVersion := func(version string) (int, string, string) {
+		epoch := 0
+		if before, after, found := strings.Cut(version, ":"); found {
+			epoch, _ = strconv.Atoi(before)
+			version = after
+		}
+		revision := ""
+		if index := strings.LastIndex(version, "-"); index >= 0 {
+			revision = version[index+1:]
+			version = version[:index]
+		}
+		return epoch, version, revision
+	}
+
+	epochA, upstreamA, revisionA := splitVersion(a)
+	epochB, upstreamB, revisionB := splitVersion(b)
+	switch {
+	case epochA > epochB:
+		return 1
+	case epochA < epochB:
+		return -1
+	}
+	if result := compareDebianVersionPart(upstreamA, upstreamB); result != 0 {
+		return result
+	}
+	return compareDebianVersionPart(revisionA, revisionB)
+}
+
+// compareDebianVersionPart 按 Debian 规则比较版本号的一部分，非数字段与数字段交替比较
+//
+// 参数：
+//   - a: 版本号 a 的一部分
+//   - b: 版本号 b 的一部分
+//
+// 返回：
+//   - a 较新返回 1，b 较新返回 -1，相同返回 0
+func compareDebianVersionPart(a, b string) int {
+	// order 非数字字符的排序权重：'~' 最小，比结尾还小；字母小于其他符号
+	order := func(c byte) int {
+		switch {
+		case c == '~':
+			return -1
+		case isDigit(c):
+			return 0
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z':
+			return int(c)
+		default:
+			return int(c) + 256
+		}
+	}
+
+	for a != "" || b != "" {
+		// 比较非数字段
+		for (a != "" && !isDigit(a[0])) || (b != "" && !isDigit(b[0])) {
+			orderA, orderB := 0, 0
+			if a != "" {
+				orderA = order(a[0])
+			}
+			if b != "" {
+				orderB = order(b[0])
+			}
+			if orderA != orderB {
+				if orderA > orderB {
+					return 1
+				}
+				return -1
+			}
+			a, b = a[1:], b[1:]
+		}
+
+		// 比较数字段，忽略前导零
+		digitsA, digitsB := 0, 0
+		for digitsA < len(a) && isDigit(a[digitsA]) {
+			digitsA++
+		}
+		for digitsB < len(b) && isDigit(b[digitsB]) {
+			digitsB++
+		}
+		numberA := strings.TrimLeft(a[:digitsA], "0")
+		numberB := strings.TrimLeft(b[:digitsB], "0")
+		if len(numberA) != len(numberB) {
+			if len(numberA) > len(numberB) {
+				return 1
+			}
+			return -1
+		}
+		if numberA != numberB {
+			if numberA > numberB {
+				return 1
+			}
+			return -1
+		}
+		a, b = a[digitsA:], b[digitsB:]
+	}
+
+	return 0
+}
+
+// isDigit 判断字符是否为数字
+//
+// 参数：
+//   - c: 字符
+//
+// 返回：
+//   - 是数字返回 true，否则返回 false
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,133 @@
+//go:build linux
+
+/*
+File: define_dpkg_linux_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-10 09:20:14
+
+Description: 测试 dpkg/apt 数据库的解析，使用 testdata/dpkg 中的样例数据库，无需 Debian 系统
+*/
+
+package general
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+var (
+	dpkgStatusFixture         = filepath.Join("testdata", "dpkg", "status")
+	aptExtendedStatesFixture  = filepath.Join("testdata", "dpkg", "extended_states")
+	dpkgInstalledSizeFixture  = uint64(7164+12988+12000+3000+400000+400000+13+900000) * 1024
+	dpkgInstalledCountFixture = 8
+)
+
+func TestCountDpkgPackages(t *testing.T) {
+	packageInfo, err := countDpkgPackages(dpkgStatusFixture, aptExtendedStatesFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 已卸载但保留配置文件（rc）的 nano 和 linux-image-6.1.0-28-amd64 不计入
+	if packageInfo.PackageTotalCount != dpkgInstalledCountFixture {
+		t.Errorf("PackageTotalCount = %d, want %d", packageInfo.PackageTotalCount, dpkgInstalledCountFixture)
+	}
+	if packageInfo.PackageTotalSize != dpkgInstalledSizeFixture {
+		t.Errorf("PackageTotalSize = %d, want %d", packageInfo.PackageTotalSize, dpkgInstalledSizeFixture)
+	}
+	// libc6:amd64 自动安装，libc6:i386 单独安装；架构无关的 tzdata 在扩展状态中记为 amd64，视为自动安装
+	if packageInfo.AsDependencyCount != 2 {
+		t.Errorf("AsDependencyCount = %d, want 2", packageInfo.AsDependencyCount)
+	}
+	if packageInfo.AsExplicitCount != dpkgInstalledCountFixture-2 {
+		t.Errorf("AsExplicitCount = %d, want %d", packageInfo.AsExplicitCount, dpkgInstalledCountFixture-2)
+	}
+}
+
+func TestCountDpkgPackagesWithoutExtendedStates(t *testing.T) {
+	packageInfo, err := countDpkgPackages(dpkgStatusFixture, filepath.Join(t.TempDir(), "extended_states"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packageInfo.AsDependencyCount != 0 || packageInfo.AsExplicitCount != dpkgInstalledCountFixture {
+		t.Errorf("got %d explicit and %d dependency packages, want all %d explicit", packageInfo.AsExplicitCount, packageInfo.AsDependencyCount, dpkgInstalledCountFixture)
+	}
+}
+
+func TestCountDpkgPackagesMissingStatus(t *testing.T) {
+	if _, err := countDpkgPackages(filepath.Join(t.TempDir(), "status"), aptExtendedStatesFixture); err == nil {
+		t.Error("expected an error for a missing status database")
+	}
+}
+
+func TestParseAptExtendedStates(t *testing.T) {
+	autoInstalled, err := parseAptExtendedStates(aptExtendedStatesFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		pkg  dpkgPackage
+		want bool
+	}{
+		{dpkgPackage{Name: "libc6", Architecture: "amd64"}, true},
+		{dpkgPackage{Name: "libc6", Architecture: "i386"}, false},
+		{dpkgPackage{Name: "tzdata", Architecture: "all"}, true},
+		{dpkgPackage{Name: "bash", Architecture: "amd64"}, false},
+		{dpkgPackage{Name: "vim", Architecture: "amd64"}, false},
+	}
+	for _, tt := range tests {
+		if got := isAutoInstalled(autoInstalled, tt.pkg); got != tt.want {
+			t.Errorf("isAutoInstalled(%s:%s) = %v, want %v", tt.pkg.Name, tt.pkg.Architecture, got, tt.want)
+		}
+	}
+}
+
+func TestLatestDpkgKernel(t *testing.T) {
+	// linux-image-amd64 元包、-dbg 调试符号包和 rc 状态的内核包版本更高，但都应被忽略
+	kernel, err := latestDpkgKernel(dpkgStatusFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kernel != "6.1.0-26-amd64" {
+		t.Errorf("latestDpkgKernel = %q, want %q", kernel, "6.1.0-26-amd64")
+	}
+}
+
+func TestCompareDebianVersion(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.1", -1},
+		{"1.10", "1.9", 1},
+		{"001.2", "1.2", 0},
+		{"1.0a", "1.0", 1},
+		{"1.0+b1", "1.0", 1},
+		{"1.0+b1", "1.0a", 1},
+		// '~' 排在结尾之前
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~~", "1.0~", -1},
+		{"1.0-1~bpo12+1", "1.0-1", -1},
+		// 纪元优先于上游版本，省略的纪元为 0
+		{"1:0.9", "2.0", 1},
+		{"0:2.0", "2.0", 0},
+		{"2:1.0", "10:0.1", -1},
+		// 修订号
+		{"2.0-1", "2.0-10", -1},
+		{"2.0", "2.0-0", 0},
+		{"6.1.112-1", "6.1.106-3", 1},
+		{"2.36-9+deb12u8", "2.36-9+deb12u10", -1},
+	}
+	for _, tt := range tests {
+		if got := compareDebianVersion(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareDebianVersion(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := compareDebianVersion(tt.b, tt.a); got != -tt.want {
+			t.Errorf("compareDebianVersion(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
@@ -0,0 +1,729 @@
+/*
+File: define_formatter.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-12 10:21:36
+
+Description: 格式化抓取到的信息
+
+- 抓取器返回原始数据，格式化后的数据以输出项名为键，供输出使用
+*/
+
+package general
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gookit/color"
+)
+
+// FormatLoadInfo 格式化负载信息
+//
+// 参数：
+//   - info: 负载信息
+//
+// 返回：
+//   - 格式化后的负载信息
+func FormatLoadInfo(info LoadInfo) map[string]string {
+	return map[string]string{
+		"Load1":        color.Sprintf("%.2f", info.Load1),
+		"Load5":        color.Sprintf("%.2f", info.Load5),
+		"Load15":       color.Sprintf("%.2f", info.Load15),
+		"Process":      color.Sprintf("%d", info.Process),
+		"TopProcesses": strings.Join(ComposeTopProcesses(info.TopProcesses), "\n"),
+
+		"PressureCPU":    formatOptional(strings.Join(ComposePressure(info.PressureCPU), "\n")),
+		"PressureMemory": formatOptional(strings.Join(ComposePressure(info.PressureMemory), "\n")),
+		"PressureIO":     formatOptional(strings.Join(ComposePressure(info.PressureIO), "\n")),
+	}
+}
+
+// pressureWarnThreshold 10 秒平均停顿时间占比达到该值（%）时视为资源紧张
+const pressureWarnThreshold = 10
+
+// ComposePressure 组合资源压力，第一行为列名，之后 some 和 full 各一行
+//
+//   - 10 秒平均值达到阈值时，some 行以 Warn 颜色显示，full 行以 Danger 颜色显示
+//
+// 参数：
+//   - pressure: 资源压力
+//
+// 返回：
+//   - 组合后的资源压力，不支持 PSI 时为空
+func ComposePressure(pressure *Pressure) []string {
+	if pressure == nil {
+		return nil
+	}
+
+	formatString := "%-4v %7v %7v %7v"
+	composed := []string{color.Sprintf(formatString, "", "AVG10", "AVG60", "AVG300")}
+	compose := func(kind string, stall PressureStall, highlight func(a ...any) string) string {
+		line := color.Sprintf(formatString, kind, color.Sprintf("%.2f%%", stall.Avg10), color.Sprintf("%.2f%%", stall.Avg60), color.Sprintf("%.2f%%", stall.Avg300))
+		if stall.Avg10 >= pressureWarnThreshold {
+			line = highlight(line)
+		}
+		return line
+	}
+	composed = append(composed, compose("some", pressure.Some, WarnText))
+	if pressure.Full != nil {
+		composed = append(composed, compose("full", *pressure.Full, DangerText))
+	}
+
+	return composed
+}
+
+// ComposeTopProcesses 组合进程排行，第一行为列名，之后每个进程一行
+//
+// 参数：
+//   - processes: 进程排行
+//
+// 返回：
+//   - 组合后的进程排行，没有进程时为空
+func ComposeTopProcesses(processes []ProcessInfo) []string {
+	if len(processes) == 0 {
+		return nil
+	}
+
+	formatString := "%7v %6v %10v  %v"
+	composed := []string{color.Sprintf(formatString, "PID", "CPU%", "RSS", "NAME")}
+	for _, proc := range processes {
+		rss, rssUnit := Human(float64(proc.RSS), "B")
+		composed = append(composed, color.Sprintf(formatString, proc.PID, color.Sprintf("%.1f", proc.CPUPercent), color.Sprintf("%.1f %s", rss, rssUnit), proc.Name))
+	}
+
+	return composed
+}
+
+// FormatMemoryInfo 格式化内存信息
+//
+// 参数：
+//   - info: 内存信息
+//   - dataUnit: 存储数据单位
+//   - percentUnit: 百分比数据单位
+//   - resourceView: 主视图，有 cgroup 用量时决定总量、用量、使用率和可用量中主机和 cgroup 的数值哪个在前
+//
+// 返回：
+//   - 格式化后的内存信息
+func FormatMemoryInfo(info MemoryInfo, dataUnit, percentUnit, resourceView string) map[string]string {
+	formatted := map[string]string{
+		"MemoryTotal":       FormatDataSize(float64(info.Total), dataUnit, 1),
+		"MemoryUsed":        FormatDataSize(float64(info.Used), dataUnit, 1),
+		"MemoryUsedPercent": FormatPercent(info.UsedPercent, percentUnit, 1),
+		"MemoryFree":        FormatDataSize(float64(info.Free), dataUnit, 1),
+		"MemoryShared":      FormatDataSize(float64(info.Shared), dataUnit, 1),
+		"MemoryBuffCache":   FormatDataSize(float64(info.BuffCache), dataUnit, 1),
+		"MemoryAvail":       FormatDataSize(float64(info.Avail), dataUnit, 1),
+
+		"MemorySlots":          color.Sprintf("%d", info.Slots),
+		"MemorySlotsPopulated": color.Sprintf("%d", info.SlotsPopulated),
+		"MemoryECC":            formatOptional(info.ECC),
+		"MemoryModules":        strings.Join(ComposeMemoryModules(info.Modules, dataUnit), "\n"),
+
+		"MemoryOOMKills": formatOptionalValue(info.OOMKills, "%d"),
+		"MemoryLastOOMVictim": func() string {
+			victim := info.LastOOMVictim
+			if victim == nil {
+				return "--/--"
+			}
+			if victim.Time == 0 {
+				return color.Sprintf("%s (%d)", victim.Name, victim.PID)
+			}
+			return color.Sprintf("%s (%d) %s", victim.Name, victim.PID, UnixTime2TimeString(victim.Time))
+		}(),
+
+		"MemoryCgroupLimit":       "--/--",
+		"MemoryCgroupUsed":        "--/--",
+		"MemoryCgroupUsedPercent": "--/--",
+	}
+	if info.CgroupUsed == nil {
+		return formatted
+	}
+
+	// cgroup 的有效内存总量为其限制与主机内存总量中的较小者
+	total, used := info.Total, *info.CgroupUsed
+	if info.CgroupLimit != nil && *info.CgroupLimit < total {
+		total = *info.CgroupLimit
+	}
+	avail := uint64(0)
+	if used < total {
+		avail = min(total-used, info.Avail)
+	}
+	usedPercent := 0.0
+	if total > 0 {
+		usedPercent = float64(used) / float64(total) * 100
+	}
+
+	cgroup := map[string]string{
+		"MemoryTotal":       FormatDataSize(float64(total), dataUnit, 1),
+		"MemoryUsed":        FormatDataSize(float64(used), dataUnit, 1),
+		"MemoryUsedPercent": FormatPercent(usedPercent, percentUnit, 1),
+		"MemoryAvail":       FormatDataSize(float64(avail), dataUnit, 1),
+	}
+	formatted["MemoryCgroupLimit"] = "unlimited"
+	if info.CgroupLimit != nil {
+		formatted["MemoryCgroupLimit"] = FormatDataSize(float64(*info.CgroupLimit), dataUnit, 1)
+	}
+	formatted["MemoryCgroupUsed"] = cgroup["MemoryUsed"]
+	formatted["MemoryCgroupUsedPercent"] = cgroup["MemoryUsedPercent"]
+	for item, value := range cgroup {
+		formatted[item] = composeResourceView(formatted[item], value, resourceView)
+	}
+
+	return formatted
+}
+
+// composeResourceView 组合主机和 cgroup 的数值，主视图的数值在前，另一视图的数值在括号中
+//
+// 参数：
+//   - host: 主机的数值
+//   - cgroup: cgroup 的数值
+//   - resourceView: 主视图，'host' 或 'cgroup'
+//
+// 返回：
+//   - 组合后的数值
+func composeResourceView(host, cgroup, resourceView string) string {
+	if resourceView == ResourceViewCgroup {
+		return color.Sprintf("%s (host %s)", cgroup, host)
+	}
+	return color.Sprintf("%s (cgroup %s)", host, cgroup)
+}
+
+// ComposeMemoryModules 组合内存插槽列表，第一行为列名，之后每个插槽一行，空插槽只显示插槽名
+//
+// 参数：
+//   - modules: 内存插槽及其上的内存条
+//   - dataUnit: 存储数据单位
+//
+// 返回：
+//   - 组合后的内存插槽列表，没有插槽信息时为空
+func ComposeMemoryModules(modules []MemoryModule, dataUnit string) []string {
+	if len(modules) == 0 {
+		return nil
+	}
+
+	formatString := "%-12v %10v %-6v %10v %-4v  %-14v %v"
+	composed := []string{color.Sprintf(formatString, "SLOT", "SIZE", "TYPE", "SPEED", "ECC", "MANUFACTURER", "PART NUMBER")}
+	for _, module := range modules {
+		locator := formatOptional(module.Locator)
+		if !module.Populated {
+			composed = append(composed, strings.TrimRight(color.Sprintf(formatString, locator, "empty", "", "", "", "", ""), " "))
+			continue
+		}
+		speed := "--/--"
+		if module.Speed > 0 {
+			speed = color.Sprintf("%d MT/s", module.Speed)
+		}
+		size := "--/--"
+		if module.Size > 0 {
+			size = FormatDataSize(float64(module.Size), dataUnit, 0)
+		}
+		composed = append(composed, color.Sprintf(formatString, locator, size, formatOptional(module.Type), speed, strconv.FormatBool(module.ECC), formatOptional(module.Manufacturer), formatOptional(module.PartNumber)))
+	}
+
+	return composed
+}
+
+// FormatSwapInfo 格式化交换分区信息
+//
+// 参数：
+//   - info: 交换分区信息
+//   - dataUnit: 存储数据单位
+//
+// 返回：
+//   - 格式化后的交换分区信息
+func FormatSwapInfo(info SwapInfo, dataUnit string) map[string]string {
+	return map[string]string{
+		"SwapStatus": info.Status,
+		"SwapTotal":  FormatDataSize(float64(info.Total), dataUnit, 1),
+		"SwapFree":   FormatDataSize(float64(info.Free), dataUnit, 1),
+	}
+}
+
+// FormatBIOSInfo 格式化 BIOS 信息
+//
+// 参数：
+//   - info: BIOS 信息
+//
+// 返回：
+//   - 格式化后的 BIOS 信息
+func FormatBIOSInfo(info BIOSInfo) map[string]string {
+	return map[string]string{
+		"BIOSVendor":  info.Vendor,
+		"BIOSVersion": info.Version,
+		"BIOSDate":    info.Date,
+	}
+}
+
+// FormatBoardInfo 格式化主板信息
+//
+// 参数：
+//   - info: 主板信息
+//
+// 返回：
+//   - 格式化后的主板信息
+func FormatBoardInfo(info BoardInfo) map[string]string {
+	return map[string]string{
+		"BoardVendor":  info.Vendor,
+		"BoardName":    info.Name,
+		"BoardVersion": info.Version,
+	}
+}
+
+// FormatCPUInfo 格式化 CPU 信息
+//
+// 参数：
+//   - info: CPU 信息
+//   - cacheUnit: 缓存数据单位
+//   - percentUnit: 百分比数据单位
+//   - resourceView: 主视图，有 cgroup 使用率时决定总使用率中主机和 cgroup 的数值哪个在前
+//
+// 返回：
+//   - 格式化后的 CPU 信息，自动单位时缓存容量取整，固定单位时保留一位小数
+func FormatCPUInfo(info CPUInfo, cacheUnit, percentUnit, resourceView string) map[string]string {
+	precision := 1
+	if cacheUnit == DataUnitAuto || cacheUnit == DataUnitAutoDecimal {
+		precision = 0
+	}
+
+	return map[string]string{
+		"CPUModel":   info.Model,
+		"CPUNumber":  color.Sprintf("%d", info.Number),
+		"CPUCores":   color.Sprintf("%d", info.Cores),
+		"CPUThreads": color.Sprintf("%d", info.Threads),
+		"CPUCache":   FormatDataSize(float64(info.Cache)*1024, cacheUnit, precision),
+
+		"CPUGovernor":        formatOptional(info.Governor),
+		"CPUMicrocode":       formatOptional(info.Microcode),
+		"CPUVirtualization":  formatOptional(info.Virtualization),
+		"CPUAVX":             formatOptional(strings.Join(info.AVX, " ")),
+		"CPUCacheL1d":        formatCacheSize(info.CacheL1d, cacheUnit, precision),
+		"CPUCacheL1i":        formatCacheSize(info.CacheL1i, cacheUnit, precision),
+		"CPUCacheL2":         formatCacheSize(info.CacheL2, cacheUnit, precision),
+		"CPUCacheL3":         formatCacheSize(info.CacheL3, cacheUnit, precision),
+		"CPUVulnerabilities": strings.Join(ComposeCPUVulnerabilities(info.Vulnerabilities), "\n"),
+		"CPUCoreDetails":     strings.Join(ComposeCPUCores(info.CoreDetails), "\n"),
+
+		"CPUUsage": func() string {
+			usage := FormatPercent(info.Total, percentUnit, 1)
+			if info.CgroupUsage == nil {
+				return usage
+			}
+			return composeResourceView(usage, FormatPercent(*info.CgroupUsage, percentUnit, 1), resourceView)
+		}(),
+		"CPUUsageUser":   FormatPercent(info.User, percentUnit, 1),
+		"CPUUsageSystem": FormatPercent(info.System, percentUnit, 1),
+		"CPUUsageIowait": FormatPercent(info.Iowait, percentUnit, 1),
+		"CPUUsageSteal":  FormatPercent(info.Steal, percentUnit, 1),
+		"CPUCoreUsage":   strings.Join(ComposeCPUCoreUsage(info.PerCPU, percentUnit), "\n"),
+		"CPUCgroupQuota": func() string {
+			switch {
+			case info.CgroupQuota != nil:
+				return color.Sprintf("%.2f CPUs", *info.CgroupQuota)
+			case info.CgroupUsage != nil:
+				return "unlimited"
+			}
+			return "--/--"
+		}(),
+		"CPUCgroupUsage": func() string {
+			if info.CgroupUsage == nil {
+				return "--/--"
+			}
+			return FormatPercent(*info.CgroupUsage, percentUnit, 1)
+		}(),
+	}
+}
+
+// ComposeCPUCoreUsage 组合逻辑处理器使用率列表，第一行为列名，之后每个逻辑处理器一行
+//
+// 参数：
+//   - usages: 逻辑处理器使用率
+//   - percentUnit: 百分比数据单位
+//
+// 返回：
+//   - 组合后的使用率列表，没有使用率数据时为空
+func ComposeCPUCoreUsage(usages []CPUCoreUsage, percentUnit string) []string {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	formatString := "%-6v %8v %8v %8v %8v %8v"
+	composed := []string{color.Sprintf(formatString, "CPU", "USAGE", "USER", "SYSTEM", "IOWAIT", "STEAL")}
+	for _, usage := range usages {
+		composed = append(composed, color.Sprintf(formatString, usage.CPU, FormatPercent(usage.Total, percentUnit, 1), FormatPercent(usage.User, percentUnit, 1), FormatPercent(usage.System, percentUnit, 1), FormatPercent(usage.Iowait, percentUnit, 1), FormatPercent(usage.Steal, percentUnit, 1)))
+	}
+
+	return composed
+}
+
+// formatCacheSize 格式化缓存容量
+//
+// 参数：
+//   - size: 缓存容量，单位为 Byte
+//   - cacheUnit: 缓存数据单位
+//   - precision: 小数位数
+//
+// 返回：
+//   - 格式化后的缓存容量，没有该级缓存时为 '--/--'
+func formatCacheSize(size uint64, cacheUnit string, precision int) string {
+	if size == 0 {
+		return "--/--"
+	}
+	return FormatDataSize(float64(size), cacheUnit, precision)
+}
+
+// ComposeCPUVulnerabilities 组合处理器漏洞列表，每个漏洞一行，仍受影响的漏洞以 Danger 颜色显示
+//
+// 参数：
+//   - vulnerabilities: 漏洞及其缓解状态
+//
+// 返回：
+//   - 组合后的漏洞列表，没有漏洞信息时为空
+func ComposeCPUVulnerabilities(vulnerabilities []CPUVulnerability) []string {
+	if len(vulnerabilities) == 0 {
+		return nil
+	}
+
+	formatString := "%-28v %v"
+	composed := make([]string, 0, len(vulnerabilities))
+	for _, vulnerability := range vulnerabilities {
+		line := color.Sprintf(formatString, vulnerability.Name, vulnerability.Status)
+		if strings.HasPrefix(vulnerability.Status, "Vulnerable") {
+			line = DangerText(line)
+		}
+		composed = append(composed, line)
+	}
+
+	return composed
+}
+
+// ComposeCPUCores 组合逻辑处理器列表，第一行为列名，之后每个逻辑处理器一行
+//
+// 参数：
+//   - cores: 逻辑处理器信息
+//
+// 返回：
+//   - 组合后的逻辑处理器列表，没有逻辑处理器信息时为空
+func ComposeCPUCores(cores []CPUCore) []string {
+	if len(cores) == 0 {
+		return nil
+	}
+
+	id := func(value int) string {
+		if value < 0 {
+			return "--/--"
+		}
+		return strconv.Itoa(value)
+	}
+	frequency := func(value float64) string {
+		if value == 0 {
+			return "--/--"
+		}
+		return color.Sprintf("%.0f MHz", value)
+	}
+
+	formatString := "%-4v %6v %6v %10v %10v %10v  %v"
+	composed := []string{color.Sprintf(formatString, "CPU", "CORE", "SOCKET", "CUR", "MIN", "MAX", "GOVERNOR")}
+	for _, core := range cores {
+		composed = append(composed, color.Sprintf(formatString, core.CPU, id(core.CoreID), id(core.SocketID), frequency(core.CurrentFrequency), frequency(core.MinFrequency), frequency(core.MaxFrequency), formatOptional(core.Governor)))
+	}
+
+	return composed
+}
+
+// FormatProductInfo 格式化产品信息
+//
+// 参数：
+//   - info: 产品信息
+//
+// 返回：
+//   - 格式化后的产品信息
+func FormatProductInfo(info ProductInfo) map[string]string {
+	return map[string]string{
+		"ProductVendor": info.Vendor,
+		"ProductName":   info.Name,
+	}
+}
+
+// FormatStorageInfo 格式化存储设备信息
+//
+// 参数：
+//   - info: 存储设备信息
+//   - dataUnit: 存储数据单位
+//
+// 返回：
+//   - 格式化后的存储设备信息
+func FormatStorageInfo(info StorageInfo, dataUnit string) map[string]string {
+	return map[string]string{
+		"StorageName":      info.Name,
+		"StorageDriver":    info.Driver,
+		"StorageVendor":    info.Vendor,
+		"StorageModel":     info.Model,
+		"StorageType":      info.Type,
+		"StorageRemovable": strconv.FormatBool(info.Removable),
+		"StorageSerial":    info.Serial,
+		"StorageSize":      FormatDataSize(float64(info.Size), dataUnit, 1),
+
+		"StorageFSType":     info.FSType,
+		"StorageMountPoint": info.MountPoint,
+		"StoragePartitions": strings.Join(ComposeStorageTree(info, dataUnit), "\n"),
+
+		"StorageHealth":             formatOptional(info.Health),
+		"StorageTemperature":        formatOptionalValue(info.Temperature, "%d °C"),
+		"StoragePowerOnHours":       formatOptionalValue(info.PowerOnHours, "%d h"),
+		"StorageReallocatedSectors": formatOptionalValue(info.ReallocatedSectors, "%d"),
+		"StoragePercentageUsed":     formatOptionalValue(info.PercentageUsed, "%d%%"),
+		"StorageMediaErrors":        formatOptionalValue(info.MediaErrors, "%d"),
+	}
+}
+
+// formatOptional 格式化可能缺失的字符串
+//
+// 参数：
+//   - value: 字符串
+//
+// 返回：
+//   - 字符串，为空时为 '--/--'
+func formatOptional(value string) string {
+	if value == "" {
+		return "--/--"
+	}
+	return value
+}
+
+// formatOptionalValue 格式化可能缺失的数值
+//
+// 参数：
+//   - value: 数值或布尔值，缺失时为 nil
+//   - format: 格式
+//
+// 返回：
+//   - 格式化后的数值，缺失时为 '--/--'
+func formatOptionalValue[T int | int64 | uint64 | float64 | bool](value *T, format string) string {
+	if value == nil {
+		return "--/--"
+	}
+	return color.Sprintf(format, *value)
+}
+
+// ComposeStorageTree 组合磁盘的块设备树，第一行为磁盘本身，其下为分区及堆叠在其上的设备
+//
+//   - 每行依次为设备名、卷标、类型、下层设备（多于一个时）、文件系统类型、容量和挂载点，缺失的字段不显示
+//
+// 参数：
+//   - info: 存储设备信息
+//   - dataUnit: 存储数据单位
+//
+// 返回：
+//   - 组合后的块设备树
+func ComposeStorageTree(info StorageInfo, dataUnit string) []string {
+	root := BlockDevice{
+		Name:       info.Name,
+		Type:       "disk",
+		FSType:     info.FSType,
+		Size:       info.Size,
+		MountPoint: info.MountPoint,
+	}
+	composed := []string{describeBlockDevice(root, dataUnit)}
+
+	children := append(append([]BlockDevice{}, info.Partitions...), info.Holders...)
+	return append(composed, composeBlockTree(children, "", dataUnit)...)
+}
+
+// composeBlockTree 递归组合块设备树
+//
+// 参数：
+//   - devices: 同一层的块设备
+//   - prefix: 该层的行前缀
+//   - dataUnit: 存储数据单位
+//
+// 返回：
+//   - 组合后的块设备树
+func composeBlockTree(devices []BlockDevice, prefix string, dataUnit string) []string {
+	var composed []string
+	for index, device := range devices {
+		branch, indent := "├─ ", "│  "
+		if index == len(devices)-1 {
+			branch, indent = "└─ ", "   "
+		}
+		composed = append(composed, prefix+branch+describeBlockDevice(device, dataUnit))
+		composed = append(composed, composeBlockTree(device.Holders, prefix+indent, dataUnit)...)
+	}
+	return composed
+}
+
+// describeBlockDevice 描述一个块设备
+//
+// 参数：
+//   - device: 块设备
+//   - dataUnit: 存储数据单位
+//
+// 返回：
+//   - 块设备的描述
+func describeBlockDevice(device BlockDevice, dataUnit string) string {
+	fields := []string{device.Name}
+	if device.Label != "" {
+		fields = append(fields, "["+device.Label+"]")
+	}
+	fields = append(fields, device.Type)
+	if len(device.Slaves) > 1 {
+		fields = append(fields, "("+strings.Join(device.Slaves, "+")+")")
+	}
+	if device.FSType != "" {
+		fields = append(fields, device.FSType)
+	}
+	if device.Size > 0 {
+		fields = append(fields, FormatDataSize(float64(device.Size), dataUnit, 1))
+	}
+	if device.MountPoint != "" {
+		fields = append(fields, device.MountPoint)
+	}
+	return strings.Join(fields, "  ")
+}
+
+// FormatFilesystemInfo 格式化已挂载文件系统信息
+//
+// 参数：
+//   - info: 已挂载文件系统信息
+//   - dataUnit: 存储数据单位
+//   - percentUnit: 百分比数据单位
+//
+// 返回：
+//   - 格式化后的已挂载文件系统信息，不支持 inode 的文件系统 inode 使用率为 '--'
+func FormatFilesystemInfo(info FilesystemInfo, dataUnit string, percentUnit string) map[string]string {
+	inodeUsedPercent := "--"
+	if info.InodesTotal > 0 {
+		inodeUsedPercent = FormatPercent(info.InodeUsedPercent, percentUnit, 1)
+	}
+
+	return map[string]string{
+		"FilesystemMountPoint":       info.MountPoint,
+		"FilesystemDevice":           info.Device,
+		"FilesystemType":             info.Type,
+		"FilesystemSize":             FormatDataSize(float64(info.Size), dataUnit, 1),
+		"FilesystemUsed":             FormatDataSize(float64(info.Used), dataUnit, 1),
+		"FilesystemAvail":            FormatDataSize(float64(info.Avail), dataUnit, 1),
+		"FilesystemUsedPercent":      FormatPercent(info.UsedPercent, percentUnit, 1),
+		"FilesystemInodesTotal":      color.Sprintf("%d", info.InodesTotal),
+		"FilesystemInodesUsed":       color.Sprintf("%d", info.InodesUsed),
+		"FilesystemInodeUsedPercent": inodeUsedPercent,
+	}
+}
+
+// FormatOSInfo 格式化系统信息
+//
+// 参数：
+//   - info: 系统信息
+//
+// 返回：
+//   - 格式化后的系统信息
+func FormatOSInfo(info OSInfo) map[string]string {
+	return map[string]string{
+		"OS":            info.OS,
+		"Arch":          info.Arch,
+		"CurrentKernel": info.CurrentKernel,
+		"LatestKernel":  info.LatestKernel,
+		"Platform":      info.Platform,
+		"Hostname":      info.Hostname,
+		"TimeZone":      info.TimeZone,
+	}
+}
+
+// FormatTimeInfo 格式化时间信息
+//
+// 参数：
+//   - info: 时间信息
+//
+// 返回：
+//   - 格式化后的时间信息
+func FormatTimeInfo(info TimeInfo) map[string]string {
+	day, hour, minute, second := UnixTime2DayHourMinuteSecond(int64(info.Uptime))
+
+	return map[string]string{
+		"BootTime":  UnixTime2TimeString(int64(info.BootTime)),
+		"Uptime":    color.Sprintf("%vd %vh %vm %vs", day, hour, minute, second),
+		"StartTime": info.StartTime,
+
+		"BootFirmware":      formatBootSeconds(info.Firmware),
+		"BootLoader":        formatBootSeconds(info.Loader),
+		"BootKernel":        formatBootSeconds(info.Kernel),
+		"BootInitrd":        formatBootSeconds(info.Initrd),
+		"BootUserspace":     formatBootSeconds(info.Userspace),
+		"BootBlame":         strings.Join(ComposeBootBlame(info.Blame), "\n"),
+		"BootCriticalChain": strings.Join(ComposeCriticalChain(info.CriticalChain), "\n"),
+	}
+}
+
+// formatBootSeconds 格式化启动用时
+//
+// 参数：
+//   - seconds: 秒数，缺失时为 nil
+//
+// 返回：
+//   - 精确到毫秒的用时，例如 '1m2.345s'、'700ms'，缺失时为 '--/--'
+func formatBootSeconds(seconds *float64) string {
+	if seconds == nil {
+		return "--/--"
+	}
+	return time.Duration(*seconds * float64(time.Second)).Round(time.Millisecond).String()
+}
+
+// ComposeBootBlame 组合单元启动用时排行，第一行为列名，之后每个单元一行
+//
+// 参数：
+//   - units: 单元的启动用时
+//
+// 返回：
+//   - 组合后的排行，没有单元时为空
+func ComposeBootBlame(units []BootUnit) []string {
+	if len(units) == 0 {
+		return nil
+	}
+
+	formatString := "%10v  %v"
+	composed := []string{color.Sprintf(formatString, "TIME", "UNIT")}
+	for _, unit := range units {
+		composed = append(composed, color.Sprintf(formatString, formatBootSeconds(unit.Time), unit.Unit))
+	}
+
+	return composed
+}
+
+// ComposeCriticalChain 组合关键链，第一行为列名，之后每个单元一行，每深入一级多缩进一格
+//
+// 参数：
+//   - units: 关键链上的单元
+//
+// 返回：
+//   - 组合后的关键链，没有单元时为空
+func ComposeCriticalChain(units []BootUnit) []string {
+	if len(units) == 0 {
+		return nil
+	}
+
+	formatString := "%10v %10v  %v"
+	composed := []string{color.Sprintf(formatString, "ACTIVATED", "TIME", "UNIT")}
+	for depth, unit := range units {
+		composed = append(composed, color.Sprintf(formatString, formatBootSeconds(unit.Activated), formatBootSeconds(unit.Time), strings.Repeat(" ", depth)+unit.Unit))
+	}
+
+	return composed
+}
+
+// FormatUserInfo 格式化用户信息
+//
+// 参数：
+//   - info: 用户信息
+//
+// 返回：
+//   - 格式化后的用户信息
+func FormatUserInfo(info CurrentUserInfo) map[string]string {
+	return map[string]string{
+		"User":        info.User,
+		"UserName":    info.UserName,
+		"UserUid":     info.Uid,
+		"UserGid":     info.Gid,
+		"UserHomeDir": info.HomeDir,
+	}
+}
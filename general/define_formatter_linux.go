@@ -0,0 +1,372 @@
+//go:build linux
+
+/*
+File: define_formatter_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-12 10:48:05
+
+Description: 格式化抓取到的信息
+*/
+
+package general
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gookit/color"
+)
+
+// FormatGPUInfo 格式化显卡信息
+//
+// 参数：
+//   - info: 显卡信息
+//
+// 返回：
+//   - 格式化后的显卡信息
+func FormatGPUInfo(info GPUInfo) map[string]string {
+	return map[string]string{
+		"GPUAddress":         info.Address,
+		"GPUDriver":          info.Driver,
+		"GPUProduct":         info.Product,
+		"GPUVendor":          info.Vendor,
+		"GPUSubsystemVendor": info.SubsystemVendor,
+		"GPURevision":        info.Revision,
+		"GPUBootVGA":         strconv.FormatBool(info.BootVGA),
+	}
+}
+
+// FormatNicInfo 格式化网卡信息
+//
+// 参数：
+//   - info: 网卡信息
+//   - dataUnit: 存储数据单位
+//
+// 返回：
+//   - 格式化后的网卡信息，多个地址、网关和 DNS 服务器以换行符分隔
+func FormatNicInfo(info NicInfo, dataUnit string) map[string]string {
+	return map[string]string{
+		"NicName":       info.Name,
+		"NicMacAddress": formatOptional(info.MacAddress),
+		"NicDriver":     formatOptional(info.Driver),
+		"NicVendor":     formatOptional(info.Vendor),
+		"NicProduct":    formatOptional(info.Product),
+		"NicPCIAddress": formatOptional(info.PCIAddress),
+		"NicSpeed":      formatOptional(info.Speed),
+		"NicDuplex":     formatOptional(info.Duplex),
+		"NicVirtual":    strconv.FormatBool(info.Virtual),
+
+		"NicState": func() string {
+			state := formatOptional(info.State)
+			if info.Carrier != nil && !*info.Carrier {
+				state += " (no carrier)"
+			}
+			return state
+		}(),
+		"NicCarrier": func() string {
+			if info.Carrier == nil {
+				return "--/--"
+			}
+			return strconv.FormatBool(*info.Carrier)
+		}(),
+		"NicMTU":      strconv.Itoa(info.MTU),
+		"NicIPv4":     formatOptional(strings.Join(info.IPv4, "\n")),
+		"NicIPv6":     formatOptional(strings.Join(info.IPv6, "\n")),
+		"NicGateways": formatOptional(strings.Join(info.Gateways, "\n")),
+		"NicDNS":      formatOptional(strings.Join(info.DNS, "\n")),
+		"NicRXBytes":  FormatDataSize(float64(info.RXBytes), dataUnit, 1),
+		"NicTXBytes":  FormatDataSize(float64(info.TXBytes), dataUnit, 1),
+
+		"NicSSID":  formatOptional(info.SSID),
+		"NicBSSID": formatOptional(info.BSSID),
+		"NicFrequency": func() string {
+			if info.Frequency == 0 {
+				return "--/--"
+			}
+			return color.Sprintf("%d MHz", info.Frequency)
+		}(),
+		"NicChannel": func() string {
+			if info.Channel == 0 {
+				return "--/--"
+			}
+			return strconv.Itoa(info.Channel)
+		}(),
+		"NicSignal": func() string {
+			if info.Signal == nil {
+				return "--/--"
+			}
+			return color.Sprintf("%d dBm", *info.Signal)
+		}(),
+		"NicBitrate": func() string {
+			if info.Bitrate == nil {
+				return "--/--"
+			}
+			return color.Sprintf("%.1f Mbit/s", *info.Bitrate)
+		}(),
+		"NicRegDomain": formatOptional(info.RegDomain),
+	}
+}
+
+// FormatPackageInfo 格式化安装包信息
+//
+// 参数：
+//   - info: 安装包信息
+//   - dataUnit: 存储数据单位
+//
+// 返回：
+//   - 格式化后的安装包信息
+func FormatPackageInfo(info PackageInfo, dataUnit string) map[string]string {
+	return map[string]string{
+		"PackageAsExplicitCount":   color.Sprintf("%d", info.AsExplicitCount),
+		"PackageAsDependencyCount": color.Sprintf("%d", info.AsDependencyCount),
+		"PackageTotalCount":        color.Sprintf("%d", info.PackageTotalCount),
+		"PackageTotalSize":         FormatDataSize(float64(info.PackageTotalSize), dataUnit, 2),
+	}
+}
+
+// ComposeUpdatablePackageList 组合可更新包列表，各来源的可更新包以其分隔符开头，来源之间空一行
+//
+// 参数：
+//   - list: 按来源区分的可更新包列表
+//   - archDividing: Arch Linux 官方仓库可更新包的开始符
+//   - aurDividing: AUR 可更新包的开始符
+//
+// 返回：
+//   - 组合后的可更新包列表
+func ComposeUpdatablePackageList(list UpdatablePackageList, archDividing, aurDividing string) []string {
+	composed := []string{archDividing}
+	composed = append(composed, list.Arch...)
+	composed = append(composed, "", aurDividing)
+	composed = append(composed, list.Aur...)
+
+	return composed
+}
+
+// FormatUpdateInfo 格式化更新信息
+//
+// 参数：
+//   - daemonInfo: 更新检测服务信息
+//   - packageInfo: 可更新包信息
+//   - archDividing: Arch Linux 官方仓库可更新包的开始符
+//   - aurDividing: AUR 可更新包的开始符
+//
+// 返回：
+//   - 格式化后的更新信息，可更新包列表以换行符分隔
+func FormatUpdateInfo(daemonInfo UpdateDaemonInfo, packageInfo UpdatablePackageInfo, archDividing, aurDividing string) map[string]string {
+	return map[string]string{
+		"UpdateCheckDaemonStatus":  daemonInfo.Status,
+		"LastCheckTime":            packageInfo.LastCheckTime,
+		"UpdatablePackageQuantity": color.Sprintf("%d", packageInfo.Quantity),
+		"UpdatablePackageList":     strings.Join(ComposeUpdatablePackageList(packageInfo.PackageList, archDividing, aurDividing), "\n"),
+	}
+}
+
+// sensorWarnRatio 读数达到临界值的该比例时视为接近临界
+const sensorWarnRatio = 0.9
+
+// sensorUnits 各类型读数的单位及格式
+var sensorUnits = map[string]struct {
+	Unit   string // 单位
+	Format string // 数值格式
+}{
+	"temp":  {"°C", "%.1f"},
+	"fan":   {"RPM", "%.0f"},
+	"in":    {"V", "%.2f"},
+	"curr":  {"A", "%.2f"},
+	"power": {"W", "%.2f"},
+}
+
+// FormatSensorInfo 格式化传感器信息
+//
+// 参数：
+//   - info: 传感器信息
+//
+// 返回：
+//   - 格式化后的传感器信息，读数以换行符分隔
+func FormatSensorInfo(info SensorInfo) map[string]string {
+	return map[string]string{
+		"SensorChip":     info.Chip,
+		"SensorDevice":   info.Device,
+		"SensorReadings": strings.Join(ComposeSensorReadings(info.Readings), "\n"),
+	}
+}
+
+// ComposeSensorReadings 组合传感器读数，第一行为列名，之后每个读数一行
+//
+//   - 读数达到临界值时以 Danger 颜色显示，达到上限或接近临界值时以 Warn 颜色显示
+//
+// 参数：
+//   - readings: 传感器读数
+//
+// 返回：
+//   - 组合后的读数，没有读数时为空
+func ComposeSensorReadings(readings []SensorReading) []string {
+	if len(readings) == 0 {
+		return nil
+	}
+
+	formatString := "%-16v %12v %12v %12v"
+	composed := []string{color.Sprintf(formatString, "LABEL", "VALUE", "HIGH", "CRIT")}
+	for _, reading := range readings {
+		line := color.Sprintf(formatString, reading.Label, formatSensorValue(reading.Type, &reading.Value), formatSensorValue(reading.Type, reading.High), formatSensorValue(reading.Type, reading.Critical))
+		switch {
+		case reading.Critical != nil && *reading.Critical > 0 && reading.Value >= *reading.Critical:
+			line = DangerText(line)
+		case reading.High != nil && *reading.High > 0 && reading.Value >= *reading.High,
+			reading.Critical != nil && *reading.Critical > 0 && reading.Value >= *reading.Critical*sensorWarnRatio:
+			line = WarnText(line)
+		}
+		composed = append(composed, line)
+	}
+
+	return composed
+}
+
+// formatSensorValue 格式化传感器数值
+//
+// 参数：
+//   - readingType: 读数类型
+//   - value: 数值，缺失时为 nil
+//
+// 返回：
+//   - 带单位的数值，缺失时为 '--/--'
+func formatSensorValue(readingType string, value *float64) string {
+	if value == nil {
+		return "--/--"
+	}
+	unit, ok := sensorUnits[readingType]
+	if !ok {
+		return color.Sprintf("%.2f", *value)
+	}
+	return color.Sprintf(unit.Format+" %s", *value, unit.Unit)
+}
+
+// FormatBatteryInfo 格式化电池信息
+//
+// 参数：
+//   - info: 电池信息
+//   - percentUnit: 百分比数据单位
+//
+// 返回：
+//   - 格式化后的电池信息
+func FormatBatteryInfo(info BatteryInfo, percentUnit string) map[string]string {
+	percent := func(value *float64) string {
+		if value == nil {
+			return "--/--"
+		}
+		return FormatPercent(*value, percentUnit, 1)
+	}
+
+	return map[string]string{
+		"BatteryAvailability":     info.Availability,
+		"BatteryName":             formatOptional(info.Name),
+		"BatteryStatus":           formatOptional(info.Status),
+		"BatteryCapacity":         percent(info.Capacity),
+		"BatteryEnergyFull":       formatOptionalValue(info.EnergyFull, "%.1f Wh"),
+		"BatteryEnergyFullDesign": formatOptionalValue(info.EnergyFullDesign, "%.1f Wh"),
+		"BatteryHealth":           percent(info.Health),
+		"BatteryCycleCount":       formatOptionalValue(info.CycleCount, "%d"),
+		"BatteryManufacturer":     formatOptional(info.Manufacturer),
+		"BatteryModel":            formatOptional(info.Model),
+		"BatteryACOnline":         formatOptionalValue(info.ACOnline, "%t"),
+	}
+}
+
+// FormatVirtInfo 格式化虚拟化信息
+//
+// 参数：
+//   - info: 虚拟化信息
+//   - dataUnit: 存储数据单位
+//
+// 返回：
+//   - 格式化后的虚拟化信息
+func FormatVirtInfo(info VirtInfo, dataUnit string) map[string]string {
+	return map[string]string{
+		"VirtType":       info.Type,
+		"VirtHypervisor": formatOptional(info.Hypervisor),
+		"VirtContainer":  formatOptional(info.Container),
+		"VirtCgroupMode": formatOptional(info.CgroupMode),
+		"VirtCPUQuota": func() string {
+			if info.CPUQuota == nil {
+				return "unlimited"
+			}
+			return color.Sprintf("%.2f CPUs", *info.CPUQuota)
+		}(),
+		"VirtMemoryLimit": func() string {
+			if info.MemoryLimit == nil {
+				return "unlimited"
+			}
+			return FormatDataSize(float64(*info.MemoryLimit), dataUnit, 1)
+		}(),
+	}
+}
+
+// FormatServiceInfo 格式化 systemd 管理器的服务信息
+//
+//   - 管理器状态为 degraded 等非 running 状态时以 Warn 颜色显示，失败的单元以 Danger 颜色显示
+//
+// 参数：
+//   - info: 服务信息
+//
+// 返回：
+//   - 格式化后的服务信息
+func FormatServiceInfo(info ServiceInfo) map[string]string {
+	return map[string]string{
+		"ServiceManager": info.Manager,
+		"ServiceSystemState": func() string {
+			switch info.SystemState {
+			case "running":
+				return info.SystemState
+			case "":
+				return "--/--"
+			}
+			return WarnText(info.SystemState)
+		}(),
+		"ServiceFailedUnits": func() string {
+			if len(info.FailedUnits) == 0 {
+				return "--/--"
+			}
+			var failedUnits []string
+			for _, name := range info.FailedUnits {
+				failedUnits = append(failedUnits, DangerText(name))
+			}
+			return strings.Join(failedUnits, "\n")
+		}(),
+		"ServiceUnits": strings.Join(ComposeServiceUnits(info.Units), "\n"),
+	}
+}
+
+// ComposeServiceUnits 组合单元状态，第一行为列名，之后每个单元一行
+//
+//   - 活动状态为 failed 的单元以 Danger 颜色显示，不存在的单元以 Warn 颜色显示
+//
+// 参数：
+//   - units: 单元状态
+//
+// 返回：
+//   - 组合后的单元状态，没有单元时为空
+func ComposeServiceUnits(units []ServiceUnit) []string {
+	if len(units) == 0 {
+		return nil
+	}
+
+	formatString := "%-32v %-10v %-10v %-10v %v"
+	composed := []string{color.Sprintf(formatString, "UNIT", "ENABLED", "ACTIVE", "SUB", "SINCE")}
+	for _, unit := range units {
+		since := "--/--"
+		if unit.Since > 0 {
+			since = UnixTime2TimeString(unit.Since)
+		}
+		line := color.Sprintf(formatString, unit.Name, formatOptional(unit.Enabled), formatOptional(unit.ActiveState), formatOptional(unit.SubState), since)
+		switch {
+		case unit.ActiveState == "failed":
+			line = DangerText(line)
+		case unit.LoadState == "not-found":
+			line = WarnText(line)
+		}
+		composed = append(composed, line)
+	}
+
+	return composed
+}
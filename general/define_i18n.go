@@ -11,94 +11,210 @@ package general
 
 // 各部分的名称
 var PartName = map[string]map[string]string{
-	"Product": {"zh": "设备", "en": "Product"},
-	"Board":   {"zh": "主板", "en": "Board"},
-	"BIOS":    {"zh": "BIOS", "en": "BIOS"},
-	"CPU":     {"zh": "处理器", "en": "CPU"},
-	"GPU":     {"zh": "显卡", "en": "GPU"},
-	"Memory":  {"zh": "内存", "en": "Memory"},
-	"Swap":    {"zh": "交换空间", "en": "Swap"},
-	"Disk":    {"zh": "磁盘", "en": "Disk"},
-	"NIC":     {"zh": "网卡", "en": "NIC"},
-	"OS":      {"zh": "系统", "en": "OS"},
-	"Package": {"zh": "安装包", "en": "Package"},
-	"Load":    {"zh": "负载", "en": "Load"},
-	"Time":    {"zh": "时间", "en": "Time"},
-	"User":    {"zh": "用户", "en": "User"},
-	"Update":  {"zh": "更新", "en": "Update"},
+	"Product":    {"zh": "设备", "en": "Product"},
+	"Board":      {"zh": "主板", "en": "Board"},
+	"BIOS":       {"zh": "BIOS", "en": "BIOS"},
+	"CPU":        {"zh": "处理器", "en": "CPU"},
+	"GPU":        {"zh": "显卡", "en": "GPU"},
+	"Memory":     {"zh": "内存", "en": "Memory"},
+	"Swap":       {"zh": "交换空间", "en": "Swap"},
+	"Disk":       {"zh": "磁盘", "en": "Disk"},
+	"Filesystem": {"zh": "文件系统", "en": "Filesystem"},
+	"Sensor":     {"zh": "传感器", "en": "Sensor"},
+	"NIC":        {"zh": "网卡", "en": "NIC"},
+	"OS":         {"zh": "系统", "en": "OS"},
+	"Package":    {"zh": "安装包", "en": "Package"},
+	"Load":       {"zh": "负载", "en": "Load"},
+	"Time":       {"zh": "时间", "en": "Time"},
+	"User":       {"zh": "用户", "en": "User"},
+	"Update":     {"zh": "更新", "en": "Update"},
+	"Virt":       {"zh": "虚拟化", "en": "Virtualization"},
+	"Services":   {"zh": "服务", "en": "Services"},
+}
+
+// 快照比较结果的表头和变化类型
+var DiffName = map[string]map[string]string{
+	"Item":    {"zh": "条目", "en": "Item"},
+	"Old":     {"zh": "旧值", "en": "Old"},
+	"New":     {"zh": "新值", "en": "New"},
+	"Change":  {"zh": "变化", "en": "Change"},
+	"Added":   {"zh": "新增", "en": "Added"},
+	"Removed": {"zh": "移除", "en": "Removed"},
+	"Changed": {"zh": "变更", "en": "Changed"},
 }
 
 // 各部分.条目的名称
 var GenealogyName = map[string]map[string]string{
-	"BIOSVendor":               {"zh": "BIOS 厂商", "en": "Vendor"},
-	"BIOSVersion":              {"zh": "BIOS 版本", "en": "Version"},
-	"BIOSDate":                 {"zh": "BIOS 发布日期", "en": "Release Date"},
-	"BoardVendor":              {"zh": "主板厂商", "en": "Vendor"},
-	"BoardName":                {"zh": "主板名称", "en": "Name"},
-	"BoardVersion":             {"zh": "主板版本", "en": "Version"},
-	"CPUModel":                 {"zh": "处理器型号", "en": "Model"},
-	"CPUNumber":                {"zh": "处理器数量", "en": "Number"},
-	"CPUCores":                 {"zh": "处理器核心", "en": "Cores"},
-	"CPUThreads":               {"zh": "处理器线程", "en": "Threads"},
-	"CPUCache":                 {"zh": "处理器缓存", "en": "Cache"},
-	"GPUAddress":               {"zh": "显卡地址", "en": "Address"},
-	"GPUDriver":                {"zh": "显卡驱动", "en": "Driver"},
-	"GPUProduct":               {"zh": "显卡型号", "en": "Product"},
-	"GPUVendor":                {"zh": "显卡厂商", "en": "Vendor"},
-	"OS":                       {"zh": "操作系统", "en": "OS"},
-	"Arch":                     {"zh": "系统架构", "en": "Arch"},
-	"Kernel":                   {"zh": "内核版本", "en": "Kernel"},
-	"CurrentKernel":            {"zh": "当前内核版本", "en": "Current Kernel"},
-	"LatestKernel":             {"zh": "最新内核版本", "en": "Latest Kernel"},
-	"Platform":                 {"zh": "系统类型", "en": "Platform"},
-	"Hostname":                 {"zh": "主机名称", "en": "Hostname"},
-	"TimeZone":                 {"zh": "时区", "en": "Time zone"},
-	"Load1":                    {"zh": "1分钟平均负载", "en": "Load average (1 min)"},
-	"Load5":                    {"zh": "5分钟平均负载", "en": "Load average (5 min)"},
-	"Load15":                   {"zh": "15分钟平均负载", "en": "Load average (15 min)"},
-	"NicName":                  {"zh": "网卡名称", "en": "Name"},
-	"NicPCIAddress":            {"zh": "PCI 地址", "en": "PCI Address"},
-	"NicMacAddress":            {"zh": "MAC 地址", "en": "MAC Address"},
-	"NicSpeed":                 {"zh": "网卡速率", "en": "Speed"},
-	"NicDuplex":                {"zh": "工作模式", "en": "Duplex"},
-	"NicDriver":                {"zh": "网卡驱动", "en": "Driver"},
-	"NicProduct":               {"zh": "网卡型号", "en": "Product"},
-	"NicVendor":                {"zh": "网卡厂商", "en": "Vendor"},
-	"MemoryTotal":              {"zh": "内存大小", "en": "Total"},
-	"MemoryUsed":               {"zh": "已用内存", "en": "Used"},
-	"MemoryUsedPercent":        {"zh": "内存占用", "en": "Used Percent"},
-	"MemoryFree":               {"zh": "空闲内存", "en": "Free"},
-	"MemoryShared":             {"zh": "共享内存", "en": "Shared"},
-	"MemoryBuffCache":          {"zh": "缓冲内存", "en": "Buff Cache"},
-	"MemoryAvail":              {"zh": "可用内存", "en": "Avail"},
-	"SwapStatus":               {"zh": "交换空间状态", "en": "Swap Status"},
-	"SwapTotal":                {"zh": "交换空间大小", "en": "Total"},
-	"SwapFree":                 {"zh": "空闲交换空间", "en": "Free"},
-	"Process":                  {"zh": "进程数", "en": "Process"},
-	"PackageTotalCount":        {"zh": "已安装包总数", "en": "Installed Package Total Count"},
-	"PackageTotalSize":         {"zh": "已安装包总大小", "en": "Installed Package Total Size"},
-	"PackageAsExplicitCount":   {"zh": "单独指定安装包数量", "en": "As Explicit Package Count"},
-	"PackageAsDependencyCount": {"zh": "作为依赖安装包数量", "en": "As Dependency Package Count"},
-	"ProductVendor":            {"zh": "设备厂商", "en": "Vendor"},
-	"ProductName":              {"zh": "设备名称", "en": "Name"},
-	"StorageName":              {"zh": "磁盘名称", "en": "Name"},
-	"StorageType":              {"zh": "磁盘类型", "en": "Type"},
-	"StorageDriver":            {"zh": "磁盘驱动", "en": "Driver"},
-	"StorageVendor":            {"zh": "磁盘厂商", "en": "Vendor"},
-	"StorageModel":             {"zh": "磁盘型号", "en": "Model"},
-	"StorageSerial":            {"zh": "磁盘序列号", "en": "Serial"},
-	"StorageRemovable":         {"zh": "磁盘可移除", "en": "Removable"},
-	"StorageSize":              {"zh": "磁盘容量", "en": "Size"},
-	"BootTime":                 {"zh": "系统启动时间", "en": "Boot Time"},
-	"Uptime":                   {"zh": "系统运行时长", "en": "Uptime"},
-	"StartTime":                {"zh": "系统启动用时", "en": "Startup Time"},
-	"User":                     {"zh": "用户名称", "en": "User"},
-	"UserName":                 {"zh": "用户昵称", "en": "Username"},
-	"UserUid":                  {"zh": "用户标识", "en": "UID"},
-	"UserGid":                  {"zh": "用户组标识", "en": "GID"},
-	"UserHomeDir":              {"zh": "用户主目录", "en": "Home Dir"},
-	"UpdateCheckDaemonStatus":  {"zh": "更新检测服务", "en": "Update Check Daemon"},
-	"LastCheckTime":            {"zh": "最后检查时间", "en": "Last Check Time"},
-	"UpdatablePackageList":     {"zh": "可更新包列表", "en": "Updatable Package List"},
-	"UpdatablePackageQuantity": {"zh": "可更新包数量", "en": "Updatable Package Quantity"},
+	"BIOSVendor":                 {"zh": "BIOS 厂商", "en": "Vendor"},
+	"BIOSVersion":                {"zh": "BIOS 版本", "en": "Version"},
+	"BIOSDate":                   {"zh": "BIOS 发布日期", "en": "Release Date"},
+	"BoardVendor":                {"zh": "主板厂商", "en": "Vendor"},
+	"BoardName":                  {"zh": "主板名称", "en": "Name"},
+	"BoardVersion":               {"zh": "主板版本", "en": "Version"},
+	"CPUModel":                   {"zh": "处理器型号", "en": "Model"},
+	"CPUNumber":                  {"zh": "处理器数量", "en": "Number"},
+	"CPUCores":                   {"zh": "处理器核心", "en": "Cores"},
+	"CPUThreads":                 {"zh": "处理器线程", "en": "Threads"},
+	"CPUCache":                   {"zh": "处理器缓存", "en": "Cache"},
+	"CPUGovernor":                {"zh": "调频策略", "en": "Governor"},
+	"CPUMicrocode":               {"zh": "微码版本", "en": "Microcode"},
+	"CPUVirtualization":          {"zh": "硬件虚拟化", "en": "Virtualization"},
+	"CPUAVX":                     {"zh": "AVX 指令集", "en": "AVX"},
+	"CPUCacheL1d":                {"zh": "L1 数据缓存", "en": "L1d Cache"},
+	"CPUCacheL1i":                {"zh": "L1 指令缓存", "en": "L1i Cache"},
+	"CPUCacheL2":                 {"zh": "L2 缓存", "en": "L2 Cache"},
+	"CPUCacheL3":                 {"zh": "L3 缓存", "en": "L3 Cache"},
+	"CPUVulnerabilities":         {"zh": "处理器漏洞", "en": "Vulnerabilities"},
+	"CPUCoreDetails":             {"zh": "逻辑处理器", "en": "Per-core"},
+	"CPUUsage":                   {"zh": "处理器使用率", "en": "Usage"},
+	"CPUUsageUser":               {"zh": "用户态", "en": "User"},
+	"CPUUsageSystem":             {"zh": "内核态", "en": "System"},
+	"CPUUsageIowait":             {"zh": "I/O 等待", "en": "IOWait"},
+	"CPUUsageSteal":              {"zh": "虚拟化窃取", "en": "Steal"},
+	"CPUCoreUsage":               {"zh": "逻辑处理器使用率", "en": "Per-core Usage"},
+	"CPUCgroupQuota":             {"zh": "cgroup CPU 配额", "en": "Cgroup Quota"},
+	"CPUCgroupUsage":             {"zh": "cgroup 使用率", "en": "Cgroup Usage"},
+	"GPUAddress":                 {"zh": "显卡地址", "en": "Address"},
+	"GPUDriver":                  {"zh": "显卡驱动", "en": "Driver"},
+	"GPUProduct":                 {"zh": "显卡型号", "en": "Product"},
+	"GPUVendor":                  {"zh": "显卡厂商", "en": "Vendor"},
+	"GPUSubsystemVendor":         {"zh": "板卡厂商", "en": "Subsystem Vendor"},
+	"GPURevision":                {"zh": "修订版本", "en": "Revision"},
+	"GPUBootVGA":                 {"zh": "启动显示", "en": "Boot VGA"},
+	"OS":                         {"zh": "操作系统", "en": "OS"},
+	"Arch":                       {"zh": "系统架构", "en": "Arch"},
+	"Kernel":                     {"zh": "内核版本", "en": "Kernel"},
+	"CurrentKernel":              {"zh": "当前内核版本", "en": "Current Kernel"},
+	"LatestKernel":               {"zh": "最新内核版本", "en": "Latest Kernel"},
+	"Platform":                   {"zh": "系统类型", "en": "Platform"},
+	"Hostname":                   {"zh": "主机名称", "en": "Hostname"},
+	"TimeZone":                   {"zh": "时区", "en": "Time zone"},
+	"Load1":                      {"zh": "1分钟平均负载", "en": "Load average (1 min)"},
+	"Load5":                      {"zh": "5分钟平均负载", "en": "Load average (5 min)"},
+	"Load15":                     {"zh": "15分钟平均负载", "en": "Load average (15 min)"},
+	"NicName":                    {"zh": "网卡名称", "en": "Name"},
+	"NicPCIAddress":              {"zh": "PCI 地址", "en": "PCI Address"},
+	"NicMacAddress":              {"zh": "MAC 地址", "en": "MAC Address"},
+	"NicSpeed":                   {"zh": "网卡速率", "en": "Speed"},
+	"NicDuplex":                  {"zh": "工作模式", "en": "Duplex"},
+	"NicDriver":                  {"zh": "网卡驱动", "en": "Driver"},
+	"NicProduct":                 {"zh": "网卡型号", "en": "Product"},
+	"NicVirtual":                 {"zh": "虚拟网卡", "en": "Virtual"},
+	"NicState":                   {"zh": "链路状态", "en": "State"},
+	"NicCarrier":                 {"zh": "载波", "en": "Carrier"},
+	"NicMTU":                     {"zh": "MTU", "en": "MTU"},
+	"NicIPv4":                    {"zh": "IPv4 地址", "en": "IPv4"},
+	"NicIPv6":                    {"zh": "IPv6 地址", "en": "IPv6"},
+	"NicGateways":                {"zh": "默认网关", "en": "Gateway"},
+	"NicDNS":                     {"zh": "DNS 服务器", "en": "DNS"},
+	"NicRXBytes":                 {"zh": "接收", "en": "RX"},
+	"NicTXBytes":                 {"zh": "发送", "en": "TX"},
+	"NicSSID":                    {"zh": "无线网络", "en": "SSID"},
+	"NicBSSID":                   {"zh": "接入点", "en": "BSSID"},
+	"NicFrequency":               {"zh": "频率", "en": "Frequency"},
+	"NicChannel":                 {"zh": "信道", "en": "Channel"},
+	"NicSignal":                  {"zh": "信号强度", "en": "Signal"},
+	"NicBitrate":                 {"zh": "无线速率", "en": "Bitrate"},
+	"NicRegDomain":               {"zh": "管制域", "en": "Reg Domain"},
+	"NicVendor":                  {"zh": "网卡厂商", "en": "Vendor"},
+	"MemoryTotal":                {"zh": "内存大小", "en": "Total"},
+	"MemoryUsed":                 {"zh": "已用内存", "en": "Used"},
+	"MemoryUsedPercent":          {"zh": "内存占用", "en": "Used Percent"},
+	"MemoryFree":                 {"zh": "空闲内存", "en": "Free"},
+	"MemoryShared":               {"zh": "共享内存", "en": "Shared"},
+	"MemoryBuffCache":            {"zh": "缓冲内存", "en": "Buff Cache"},
+	"MemoryAvail":                {"zh": "可用内存", "en": "Avail"},
+	"MemorySlots":                {"zh": "内存插槽", "en": "Slots"},
+	"MemorySlotsPopulated":       {"zh": "已用插槽", "en": "Populated"},
+	"MemoryECC":                  {"zh": "纠错类型", "en": "ECC"},
+	"MemoryModules":              {"zh": "内存条", "en": "Modules"},
+	"MemoryOOMKills":             {"zh": "OOM 次数", "en": "OOM Kills"},
+	"MemoryLastOOMVictim":        {"zh": "最后 OOM 进程", "en": "Last OOM Victim"},
+	"MemoryCgroupLimit":          {"zh": "cgroup 内存限制", "en": "Cgroup Limit"},
+	"MemoryCgroupUsed":           {"zh": "cgroup 已用内存", "en": "Cgroup Used"},
+	"MemoryCgroupUsedPercent":    {"zh": "cgroup 内存使用率", "en": "Cgroup Used %"},
+	"SwapStatus":                 {"zh": "交换空间状态", "en": "Swap Status"},
+	"SwapTotal":                  {"zh": "交换空间大小", "en": "Total"},
+	"SwapFree":                   {"zh": "空闲交换空间", "en": "Free"},
+	"Process":                    {"zh": "进程数", "en": "Process"},
+	"TopProcesses":               {"zh": "进程排行", "en": "Top Processes"},
+	"PressureCPU":                {"zh": "CPU 压力", "en": "CPU Pressure"},
+	"PressureMemory":             {"zh": "内存压力", "en": "Memory Pressure"},
+	"PressureIO":                 {"zh": "I/O 压力", "en": "IO Pressure"},
+	"PackageTotalCount":          {"zh": "已安装包总数", "en": "Installed Package Total Count"},
+	"PackageTotalSize":           {"zh": "已安装包总大小", "en": "Installed Package Total Size"},
+	"PackageAsExplicitCount":     {"zh": "单独指定安装包数量", "en": "As Explicit Package Count"},
+	"PackageAsDependencyCount":   {"zh": "作为依赖安装包数量", "en": "As Dependency Package Count"},
+	"ProductVendor":              {"zh": "设备厂商", "en": "Vendor"},
+	"ProductName":                {"zh": "设备名称", "en": "Name"},
+	"StorageName":                {"zh": "磁盘名称", "en": "Name"},
+	"StorageType":                {"zh": "磁盘类型", "en": "Type"},
+	"StorageDriver":              {"zh": "磁盘驱动", "en": "Driver"},
+	"StorageVendor":              {"zh": "磁盘厂商", "en": "Vendor"},
+	"StorageModel":               {"zh": "磁盘型号", "en": "Model"},
+	"StorageSerial":              {"zh": "磁盘序列号", "en": "Serial"},
+	"StorageRemovable":           {"zh": "磁盘可移除", "en": "Removable"},
+	"StorageSize":                {"zh": "磁盘容量", "en": "Size"},
+	"StorageFSType":              {"zh": "文件系统类型", "en": "Filesystem"},
+	"StorageMountPoint":          {"zh": "挂载点", "en": "Mount Point"},
+	"StoragePartitions":          {"zh": "分区", "en": "Partitions"},
+	"StorageHealth":              {"zh": "健康状态", "en": "Health"},
+	"StorageTemperature":         {"zh": "磁盘温度", "en": "Temperature"},
+	"StoragePowerOnHours":        {"zh": "通电时间", "en": "Power-On Hours"},
+	"StorageReallocatedSectors":  {"zh": "重映射扇区", "en": "Reallocated Sectors"},
+	"StoragePercentageUsed":      {"zh": "寿命已用", "en": "Percentage Used"},
+	"StorageMediaErrors":         {"zh": "介质错误", "en": "Media Errors"},
+	"BatteryAvailability":        {"zh": "电池状态", "en": "Battery Status"},
+	"BatteryName":                {"zh": "电池名称", "en": "Name"},
+	"BatteryStatus":              {"zh": "充电状态", "en": "Status"},
+	"BatteryCapacity":            {"zh": "剩余电量", "en": "Capacity"},
+	"BatteryEnergyFull":          {"zh": "满充能量", "en": "Energy Full"},
+	"BatteryEnergyFullDesign":    {"zh": "设计能量", "en": "Energy Full Design"},
+	"BatteryHealth":              {"zh": "健康度", "en": "Health"},
+	"BatteryCycleCount":          {"zh": "循环次数", "en": "Cycle Count"},
+	"BatteryManufacturer":        {"zh": "制造商", "en": "Manufacturer"},
+	"BatteryModel":               {"zh": "型号", "en": "Model"},
+	"BatteryACOnline":            {"zh": "电源适配器接通", "en": "AC Online"},
+	"SensorChip":                 {"zh": "芯片", "en": "Chip"},
+	"SensorDevice":               {"zh": "设备", "en": "Device"},
+	"SensorReadings":             {"zh": "读数", "en": "Readings"},
+	"VirtType":                   {"zh": "运行环境", "en": "Type"},
+	"VirtHypervisor":             {"zh": "虚拟机监视器", "en": "Hypervisor"},
+	"VirtContainer":              {"zh": "容器引擎", "en": "Container"},
+	"VirtCgroupMode":             {"zh": "cgroup 模式", "en": "Cgroup Mode"},
+	"VirtCPUQuota":               {"zh": "CPU 配额", "en": "CPU Quota"},
+	"VirtMemoryLimit":            {"zh": "内存限制", "en": "Memory Limit"},
+	"ServiceManager":             {"zh": "管理器", "en": "Manager"},
+	"ServiceSystemState":         {"zh": "管理器状态", "en": "System State"},
+	"ServiceFailedUnits":         {"zh": "失败的单元", "en": "Failed Units"},
+	"ServiceUnits":               {"zh": "关注的单元", "en": "Watched Units"},
+	"FilesystemMountPoint":       {"zh": "挂载点", "en": "Mount Point"},
+	"FilesystemDevice":           {"zh": "设备", "en": "Device"},
+	"FilesystemType":             {"zh": "文件系统类型", "en": "Type"},
+	"FilesystemSize":             {"zh": "容量", "en": "Size"},
+	"FilesystemUsed":             {"zh": "已用", "en": "Used"},
+	"FilesystemAvail":            {"zh": "可用", "en": "Avail"},
+	"FilesystemUsedPercent":      {"zh": "使用率", "en": "Use%"},
+	"FilesystemInodesTotal":      {"zh": "inode 总数", "en": "Inodes"},
+	"FilesystemInodesUsed":       {"zh": "已用 inode", "en": "IUsed"},
+	"FilesystemInodeUsedPercent": {"zh": "inode 使用率", "en": "IUse%"},
+	"BootTime":                   {"zh": "系统启动时间", "en": "Boot Time"},
+	"Uptime":                     {"zh": "系统运行时长", "en": "Uptime"},
+	"StartTime":                  {"zh": "系统启动用时", "en": "Startup Time"},
+	"BootFirmware":               {"zh": "固件用时", "en": "Firmware"},
+	"BootLoader":                 {"zh": "引导程序用时", "en": "Loader"},
+	"BootKernel":                 {"zh": "内核用时", "en": "Kernel"},
+	"BootInitrd":                 {"zh": "initrd 用时", "en": "Initrd"},
+	"BootUserspace":              {"zh": "用户空间用时", "en": "Userspace"},
+	"BootBlame":                  {"zh": "单元启动用时排行", "en": "Blame"},
+	"BootCriticalChain":          {"zh": "关键链", "en": "Critical Chain"},
+	"User":                       {"zh": "用户名称", "en": "User"},
+	"UserName":                   {"zh": "用户昵称", "en": "Username"},
+	"UserUid":                    {"zh": "用户标识", "en": "UID"},
+	"UserGid":                    {"zh": "用户组标识", "en": "GID"},
+	"UserHomeDir":                {"zh": "用户主目录", "en": "Home Dir"},
+	"UpdateCheckDaemonStatus":    {"zh": "更新检测服务", "en": "Update Check Daemon"},
+	"LastCheckTime":              {"zh": "最后检查时间", "en": "Last Check Time"},
+	"UpdatablePackageList":       {"zh": "可更新包列表", "en": "Updatable Package List"},
+	"UpdatablePackageQuantity":   {"zh": "可更新包数量", "en": "Updatable Package Quantity"},
 }
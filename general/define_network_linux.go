@@ -0,0 +1,254 @@
+//go:build linux
+
+/*
+File: define_network_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-29 09:12:08
+
+Description: 读取网卡的逻辑网络状态
+
+- 链路状态、载波和收发字节数来自 /sys/class/net/<网卡>
+- 默认网关来自 /proc/net/route 和 /proc/net/ipv6_route
+- DNS 优先使用 systemd-resolved 为每个网卡记录的服务器，其次为全局配置
+- 无线网卡的连接信息见 define_wireless_linux.go
+*/
+
+package general
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	sysClassNetPath     = "/sys/class/net"                   // 网卡的 sysfs 目录
+	ipv4RouteFile       = "/proc/net/route"                  // IPv4 路由表
+	ipv6RouteFile       = "/proc/net/ipv6_route"             // IPv6 路由表
+	resolvConfFile      = "/etc/resolv.conf"                 // 解析器配置
+	resolvedConfFile    = "/run/systemd/resolve/resolv.conf" // systemd-resolved 的上游服务器
+	resolvedLinkDataDir = "/run/systemd/resolve/netif"       // systemd-resolved 的各网卡状态，文件名为网卡序号
+)
+
+// resolvedStubAddress systemd-resolved 的本地监听地址
+var resolvedStubAddress = map[string]bool{"127.0.0.53": true, "127.0.0.54": true}
+
+// networkReader 读取网卡的逻辑网络状态，缓存全局数据以免重复读取
+type networkReader struct {
+	gateways  map[string][]string // 默认网关，键为网卡名
+	resolvers []string            // 全局 DNS 服务器
+}
+
+// newNetworkReader 创建网络状态读取器
+//
+// 返回：
+//   - 网络状态读取器
+func newNetworkReader() *networkReader {
+	gateways := readDefaultGateways(ipv4RouteFile, parseIPv4Route)
+	for name, ipv6Gateways := range readDefaultGateways(ipv6RouteFile, parseIPv6Route) {
+		gateways[name] = append(gateways[name], ipv6Gateways...)
+	}
+	return &networkReader{
+		gateways:  gateways,
+		resolvers: readResolvers(),
+	}
+}
+
+// fill 补全网卡的逻辑网络状态
+//
+// 参数：
+//   - nic: 网卡信息，已包含网卡名
+func (reader *networkReader) fill(nic *NicInfo) {
+	nicPath := filepath.Join(sysClassNetPath, nic.Name)
+
+	nic.State = readSensorValue(nicPath, "operstate")
+	// 网卡未启用时读取 carrier 会失败
+	if carrier := readSensorValue(nicPath, "carrier"); carrier != "" {
+		hasCarrier := carrier == "1"
+		nic.Carrier = &hasCarrier
+	}
+	nic.RXBytes, _ = strconv.ParseUint(readSensorValue(nicPath, "statistics/rx_bytes"), 10, 64)
+	nic.TXBytes, _ = strconv.ParseUint(readSensorValue(nicPath, "statistics/tx_bytes"), 10, 64)
+	nic.Gateways = reader.gateways[nic.Name]
+
+	iface, err := net.InterfaceByName(nic.Name)
+	if err != nil {
+		return
+	}
+	nic.MTU = iface.MTU
+	if addrs, err := iface.Addrs(); err == nil {
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+			if ipNet.IP.To4() != nil {
+				nic.IPv4 = append(nic.IPv4, ipNet.String())
+			} else {
+				nic.IPv6 = append(nic.IPv6, ipNet.String())
+			}
+		}
+	}
+
+	if isWireless(nic.Name) {
+		wireless := readWirelessInfo(iface)
+		nic.SSID = wireless.SSID
+		nic.BSSID = wireless.BSSID
+		nic.Frequency = wireless.Frequency
+		nic.Channel = wirelessChannel(wireless.Frequency)
+		nic.Signal = wireless.Signal
+		nic.Bitrate = wireless.Bitrate
+		nic.RegDomain = wireless.RegDomain
+	}
+
+	nic.DNS = readLinkDNS(iface.Index)
+	if len(nic.DNS) == 0 && len(nic.IPv4)+len(nic.IPv6) > 0 {
+		nic.DNS = reader.resolvers
+	}
+}
+
+// readDefaultGateways 读取路由表中的默认网关
+//
+// 参数：
+//   - filePath: 路由表文件路径
+//   - parse: 解析一行路由，返回网卡名和网关，不是默认路由时返回 false
+//
+// 返回：
+//   - 默认网关，键为网卡名，没有路由表时为空
+func readDefaultGateways(filePath string, parse func(fields []string) (string, string, bool)) map[string][]string {
+	gateways := make(map[string][]string)
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return gateways
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if name, gateway, ok := parse(strings.Fields(scanner.Text())); ok {
+			gateways[name] = append(gateways[name], gateway)
+		}
+	}
+	return gateways
+}
+
+// parseIPv4Route 解析 /proc/net/route 的一行，字段依次为网卡名、目的地址、网关、标志等，地址为小端序十六进制
+//
+// 参数：
+//   - fields: 该行的字段
+//
+// 返回：
+//   - 网卡名
+//   - 网关
+//   - 是默认路由且有网关时返回 true，否则返回 false
+func parseIPv4Route(fields []string) (string, string, bool) {
+	if len(fields) < 8 || fields[1] != "00000000" || fields[7] != "00000000" {
+		return "", "", false
+	}
+	gateway, err := hex.DecodeString(fields[2])
+	if err != nil || len(gateway) != 4 || binary.LittleEndian.Uint32(gateway) == 0 {
+		return "", "", false
+	}
+	return fields[0], net.IPv4(gateway[3], gateway[2], gateway[1], gateway[0]).String(), true
+}
+
+// parseIPv6Route 解析 /proc/net/ipv6_route 的一行，字段依次为目的地址、前缀长度、源地址、源前缀长度、下一跳、度量值、引用数、使用数、标志、网卡名
+//
+// 参数：
+//   - fields: 该行的字段
+//
+// 返回：
+//   - 网卡名
+//   - 网关
+//   - 是默认路由且有下一跳时返回 true，否则返回 false
+func parseIPv6Route(fields []string) (string, string, bool) {
+	if len(fields) < 10 || fields[1] != "00" || strings.Trim(fields[0], "0") != "" {
+		return "", "", false
+	}
+	gateway, err := hex.DecodeString(fields[4])
+	if err != nil || len(gateway) != net.IPv6len || net.IP(gateway).IsUnspecified() {
+		return "", "", false
+	}
+	return fields[9], net.IP(gateway).String(), true
+}
+
+// readResolvers 读取全局 DNS 服务器
+//
+// 返回：
+//   - DNS 服务器，resolv.conf 指向 systemd-resolved 的本地监听地址时为其上游服务器
+func readResolvers() []string {
+	resolvers := readNameservers(resolvConfFile)
+	for _, resolver := range resolvers {
+		if resolvedStubAddress[resolver] {
+			if upstream := readNameservers(resolvedConfFile); len(upstream) > 0 {
+				return upstream
+			}
+			break
+		}
+	}
+	return resolvers
+}
+
+// readNameservers 读取 resolv.conf 格式文件中的 nameserver
+//
+// 参数：
+//   - filePath: 文件路径
+//
+// 返回：
+//   - DNS 服务器
+func readNameservers(filePath string) []string {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var nameservers []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			nameservers = append(nameservers, fields[1])
+		}
+	}
+	return nameservers
+}
+
+// readLinkDNS 读取 systemd-resolved 为网卡记录的 DNS 服务器
+//
+// 参数：
+//   - index: 网卡序号
+//
+// 返回：
+//   - DNS 服务器，没有 systemd-resolved 或该网卡没有专属服务器时为空
+func readLinkDNS(index int) []string {
+	file, err := os.Open(filepath.Join(resolvedLinkDataDir, strconv.Itoa(index)))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if servers, found := strings.CutPrefix(scanner.Text(), "SERVERS="); found {
+			// 服务器可能带有端口和 SNI，例如 '1.1.1.1:853#cloudflare-dns.com'
+			var dns []string
+			for _, server := range strings.Fields(servers) {
+				server, _, _ = strings.Cut(server, "#")
+				if host, _, err := net.SplitHostPort(server); err == nil {
+					server = host
+				}
+				dns = append(dns, server)
+			}
+			return dns
+		}
+	}
+	return nil
+}
@@ -9,10 +9,25 @@ Description: 消息通知
 
 package general
 
-import "github.com/gookit/color"
+import (
+	"slices"
+
+	"github.com/gookit/color"
+)
 
 var Notifier []string // 通知器
 
+// Notify 添加通知，已有相同的通知时不重复添加，避免 watch 模式每次刷新都追加同一条通知
+//
+// 参数：
+//   - slogan: 通知内容
+func Notify(slogan string) {
+	if slices.Contains(Notifier, slogan) {
+		return
+	}
+	Notifier = append(Notifier, slogan)
+}
+
 // Notification 显示通知
 func Notification() {
 	if len(Notifier) > 0 {
@@ -22,12 +22,14 @@ import (
 func GetLatestKernelVersion() string {
 	var latestKernelVersion string
 
-	id, _ := GetSystemID()
-	switch id {
+	family, _ := GetSystemFamily()
+	switch family {
 	case "arch":
 		latestKernelVersion = getLatestKernelVersionForArch()
 	case "debian":
 		latestKernelVersion = getLatestKernelVersionForDebian()
+	case "rpm":
+		latestKernelVersion = getLatestKernelVersionForRPM()
 	default:
 		latestKernelVersion = getLatestKernelVersionForUnknown()
 	}
@@ -56,13 +58,21 @@ func getLatestKernelVersionForArch() string {
 // 返回：
 //   - 最新内核版本号
 func getLatestKernelVersionForDebian() string {
-	var latestKernelVersion string
-
-	// TODO: 待实现 <07-06-24, YJ> //
+	latestKernelVersion, _ := latestDpkgKernel(dpkgStatusFile)
 
 	return latestKernelVersion
 }
 
+// getLatestKernelVersionForRPM 获取本地最新内核版本，RPM 系专用
+//
+// 返回：
+//   - 最新内核版本号
+func getLatestKernelVersionForRPM() string {
+	packages, _ := queryRPMPackages()
+
+	return latestRPMKernel(packages)
+}
+
 // getLatestKernelVersionForUnknown 获取本地最新内核版本，未支持系统专用
 //
 // 返回：
@@ -13,30 +13,33 @@ package general
 
 import "github.com/Jguer/go-alpm/v2"
 
-type PackageData struct {
-	PackageTotalCount int
-	AsDependencyCount int
-	AsExplicitCount   int
-	PackageTotalSize  float64
-	PackageTotalUnit  string
+// PackageInfo 已安装包信息
+type PackageInfo struct {
+	PackageTotalCount int    `json:"PackageTotalCount"`        // 已安装包总数
+	AsDependencyCount int    `json:"PackageAsDependencyCount"` // 作为依赖安装包总数
+	AsExplicitCount   int    `json:"PackageAsExplicitCount"`   // 单独指定安装包总数
+	PackageTotalSize  uint64 `json:"PackageTotalSize"`         // 已安装包总大小，单位为 Byte
 }
 
 // GetInstalledPackageData 获取已安装包的数据
 //
 // 返回：
-//   - 最新内核版本号
-func GetInstalledPackageData() (PackageData, error) {
-	id, _ := GetSystemID()
+//   - 已安装包的数据
+//   - 错误信息
+func GetInstalledPackageData() (PackageInfo, error) {
+	family, _ := GetSystemFamily()
 
 	var (
-		packageData PackageData
+		packageData PackageInfo
 		err         error
 	)
-	switch id {
+	switch family {
 	case "arch":
 		packageData, err = getInstalledPackageDataForArch()
 	case "debian":
 		packageData, err = getInstalledPackageDataForDebian()
+	case "rpm":
+		packageData, err = getInstalledPackageDataForRPM()
 	default:
 		packageData, err = getInstalledPackageDataForUnknown()
 	}
@@ -47,9 +50,10 @@ func GetInstalledPackageData() (PackageData, error) {
 // getInstalledPackageDataForArch 获取已安装包的数据，Arch 系专用
 //
 // 返回：
-//   - 最新内核版本号
-func getInstalledPackageDataForArch() (PackageData, error) {
-	var packageData PackageData
+//   - 已安装包的数据
+//   - 错误信息
+func getInstalledPackageDataForArch() (PackageInfo, error) {
+	var packageData PackageInfo
 
 	// Alpm初始化，获取句柄
 	handle, err := alpm.Initialize("/", "/var/lib/pacman")
@@ -71,12 +75,12 @@ func getInstalledPackageDataForArch() (PackageData, error) {
 
 	// 计算已安装包的总大小
 	var (
-		totalSize          float64
+		totalSize          uint64
 		asExplicitQuantity int
 		asDepsQuantity     int
 	)
 	for _, pkg := range pkgSlice {
-		totalSize += float64(pkg.ISize())
+		totalSize += uint64(pkg.ISize())
 		if pkg.Reason().String() == "Explicitly installed" {
 			asExplicitQuantity++
 		} else if pkg.Reason().String() == "Installed as a dependency of another package" {
@@ -89,13 +93,10 @@ func getInstalledPackageDataForArch() (PackageData, error) {
 		return packageData, err
 	}
 
-	packageTotalSize, packageTotalUnit := Human(totalSize, "B")
-
 	packageData.PackageTotalCount = totalCount
 	packageData.AsDependencyCount = asDepsQuantity
 	packageData.AsExplicitCount = asExplicitQuantity
-	packageData.PackageTotalSize = packageTotalSize
-	packageData.PackageTotalUnit = packageTotalUnit
+	packageData.PackageTotalSize = totalSize
 
 	return packageData, nil
 }
@@ -103,18 +104,41 @@ func getInstalledPackageDataForArch() (PackageData, error) {
 // getInstalledPackageDataForDebian 获取已安装包的数据，Debian 系专用
 //
 // 返回：
-//   - 最新内核版本号
-func getInstalledPackageDataForDebian() (PackageData, error) {
-	var packageData PackageData
-	// TODO: 待实现 <07-06-24, YJ> //
+//   - 已安装包的数据
+//   - 错误信息
+func getInstalledPackageDataForDebian() (PackageInfo, error) {
+	return countDpkgPackages(dpkgStatusFile, aptExtendedStatesFile)
+}
+
+// getInstalledPackageDataForRPM 获取已安装包的数据，RPM 系专用
+//
+//   - RPM 数据库不记录安装原因，不统计作为依赖和单独指定安装的包数
+//
+// 返回：
+//   - 已安装包的数据
+//   - 错误信息
+func getInstalledPackageDataForRPM() (PackageInfo, error) {
+	var packageData PackageInfo
+
+	packages, err := queryRPMPackages()
+	if err != nil {
+		return packageData, err
+	}
+
+	packageData.PackageTotalCount = len(packages)
+	for _, pkg := range packages {
+		packageData.PackageTotalSize += pkg.Size
+	}
+
 	return packageData, nil
 }
 
 // getInstalledPackageDataForUnknown 获取已安装包的数据，未支持系统专用
 //
 // 返回：
-//   - 最新内核版本号
-func getInstalledPackageDataForUnknown() (PackageData, error) {
-	var packageData PackageData
+//   - 已安装包的数据
+//   - 错误信息
+func getInstalledPackageDataForUnknown() (PackageInfo, error) {
+	var packageData PackageInfo
 	return packageData, nil
 }
@@ -0,0 +1,39 @@
+//go:build darwin
+
+/*
+File: define_pressure_darwin.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-04 11:12:09
+
+Description: 资源压力（PSI）和 OOM 记录
+*/
+
+package general
+
+// readPressure 读取一种资源的压力，macOS 不提供 PSI，返回空值
+//
+// 参数：
+//   - resource: 资源名
+//
+// 返回：
+//   - 资源压力
+func readPressure(resource string) *Pressure {
+	return nil
+}
+
+// readOOMKills 读取 OOM killer 终止的进程数，macOS 没有 OOM killer，返回空值
+//
+// 返回：
+//   - 进程数
+func readOOMKills() *uint64 {
+	return nil
+}
+
+// readLastOOMVictim 读取最后一次被 OOM killer 终止的进程，macOS 没有 OOM killer，返回空值
+//
+// 返回：
+//   - 被终止的进程
+func readLastOOMVictim() *OOMVictim {
+	return nil
+}
@@ -0,0 +1,178 @@
+//go:build linux
+
+/*
+File: define_pressure_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-04 09:36:52
+
+Description: 读取资源压力（PSI）和 OOM 记录
+
+- /proc/pressure/{cpu,memory,io} 记录任务因等待资源而停顿的时间占比，需要内核 4.20 以上且启用 CONFIG_PSI
+- some 为至少一个任务停顿，full 为所有非空闲任务同时停顿，系统级的 CPU full 在内核 5.13 之前不存在
+- OOM 次数来自 /proc/vmstat 的 oom_kill 计数器，最后一次 OOM 的进程来自内核日志 /dev/kmsg
+*/
+
+package general
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/shirou/gopsutil/v3/host"
+)
+
+var (
+	procPressurePath = "/proc/pressure" // PSI 目录
+	procVmstatFile   = "/proc/vmstat"   // 虚拟内存统计
+	kmsgFile         = "/dev/kmsg"      // 内核日志
+)
+
+// readPressure 读取一种资源的压力
+//
+// 参数：
+//   - resource: 资源名，'cpu'、'memory' 或 'io'
+//
+// 返回：
+//   - 资源压力，内核不支持 PSI 时为 nil
+func readPressure(resource string) *Pressure {
+	file, err := os.Open(filepath.Join(procPressurePath, resource))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	// 每行格式为 'some avg10=0.00 avg60=0.00 avg300=0.00 total=0'
+	var pressure *Pressure
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var stall PressureStall
+		for _, field := range fields[1:] {
+			key, value, _ := strings.Cut(field, "=")
+			switch key {
+			case "avg10":
+				stall.Avg10, _ = strconv.ParseFloat(value, 64)
+			case "avg60":
+				stall.Avg60, _ = strconv.ParseFloat(value, 64)
+			case "avg300":
+				stall.Avg300, _ = strconv.ParseFloat(value, 64)
+			case "total":
+				stall.Total, _ = strconv.ParseUint(value, 10, 64)
+			}
+		}
+		switch fields[0] {
+		case "some":
+			if pressure == nil {
+				pressure = &Pressure{}
+			}
+			pressure.Some = stall
+		case "full":
+			if pressure == nil {
+				pressure = &Pressure{}
+			}
+			pressure.Full = &stall
+		}
+	}
+
+	return pressure
+}
+
+// readOOMKills 读取自启动以来 OOM killer 终止的进程数
+//
+// 返回：
+//   - 进程数，内核不提供该计数器（4.13 之前）时为 nil
+func readOOMKills() *uint64 {
+	file, err := os.Open(procVmstatFile)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if value, found := strings.CutPrefix(scanner.Text(), "oom_kill "); found {
+			if count, err := strconv.ParseUint(value, 10, 64); err == nil {
+				return &count
+			}
+		}
+	}
+	return nil
+}
+
+// oomKillPattern 匹配内核日志中 OOM killer 终止进程的记录，例如 'Out of memory: Killed process 1234 (stress)'
+var oomKillPattern = regexp.MustCompile(`Killed process (\d+) \(([^)]*)\)`)
+
+// oomLog 内核日志读取状态，保持文件打开以便每次只读取新增的记录
+var oomLog struct {
+	fd         int        // 内核日志的文件描述符，无法打开时为 -1
+	opened     bool       // 是否已尝试打开
+	lastVictim *OOMVictim // 最后一次被 OOM killer 终止的进程
+}
+
+// readLastOOMVictim 读取内核日志中最后一次被 OOM killer 终止的进程
+//
+//   - 内核日志是环形缓冲区，较早的记录可能已被覆盖
+//   - 启用 kernel.dmesg_restrict 时需要 root 权限，无权读取时发送一次通知
+//
+// 返回：
+//   - 最后一次被终止的进程，没有记录或无法读取时为 nil
+func readLastOOMVictim() *OOMVictim {
+	if !oomLog.opened {
+		oomLog.opened = true
+		// 以非阻塞方式打开，读完已有记录时返回 EAGAIN 而不是等待新记录
+		fd, err := syscall.Open(kmsgFile, syscall.O_RDONLY|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+		if err != nil {
+			if errors.Is(err, os.ErrPermission) {
+				Notify("Last OOM victim from the kernel log requires root permission")
+			}
+			fd = -1
+		}
+		oomLog.fd = fd
+	}
+	if oomLog.fd < 0 {
+		return oomLog.lastVictim
+	}
+
+	bootTime, _ := host.BootTime()
+	// 每次读取返回一条记录，格式为 '优先级,序号,时间戳（微秒）,标志;消息'
+	buffer := make([]byte, 8192)
+	for {
+		n, err := syscall.Read(oomLog.fd, buffer)
+		if errors.Is(err, syscall.EPIPE) {
+			continue // 未读取的记录已被覆盖，继续读取之后的记录
+		}
+		if err != nil || n <= 0 {
+			break
+		}
+
+		prefix, message, found := strings.Cut(string(buffer[:n]), ";")
+		if !found {
+			continue
+		}
+		message, _, _ = strings.Cut(message, "\n") // 之后为续行的键值对
+		matches := oomKillPattern.FindStringSubmatch(message)
+		if matches == nil {
+			continue
+		}
+		victim := &OOMVictim{Name: matches[2]}
+		victim.PID, _ = strconv.Atoi(matches[1])
+		if fields := strings.Split(prefix, ","); len(fields) >= 3 && bootTime > 0 {
+			if timestamp, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+				victim.Time = int64(bootTime) + timestamp/1e6
+			}
+		}
+		oomLog.lastVictim = victim
+	}
+
+	return oomLog.lastVictim
+}
@@ -0,0 +1,243 @@
+//go:build linux
+
+/*
+File: define_rpm_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-20 14:05:52
+
+Description: 查询 RPM 数据库，RPM 系专用
+
+- 通过 rpm 命令查询，兼容 Berkeley DB、NDB 和 SQLite 等不同格式的 RPM 数据库
+*/
+
+package general
+
+import (
+	"strconv"
+	"strings"
+)
+
+// rpmQueryFormat rpm 命令的输出格式，每个包一行，字段以制表符分隔
+const rpmQueryFormat = "%{NAME}\t%{VERSION}\t%{RELEASE}\t%{ARCH}\t%{SIZE}\n"
+
+// rpmKernelPackages 内核包名，Fedora/RHEL 为 kernel 或 kernel-core，openSUSE 为 kernel-default
+var rpmKernelPackages = map[string]bool{
+	"kernel":         true,
+	"kernel-core":    true,
+	"kernel-default": true,
+}
+
+// rpmPackage RPM 数据库中的一个包
+type rpmPackage struct {
+	Name    string // 包名
+	Version string // 版本
+	Release string // 发行号
+	Arch    string // 架构
+	Size    uint64 // 安装大小，单位为 Byte
+}
+
+// queryRPMPackages 查询所有已安装的包
+//
+// 返回：
+//   - 已安装的包
+//   - 错误信息
+func queryRPMPackages() ([]rpmPackage, error) {
+	args := []string{"-qa", "--queryformat", rpmQueryFormat}
+	output, _, err := RunCommandToBuffer("rpm", args)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRPMPackages(output), nil
+}
+
+// parseRPMPackages 解析按 rpmQueryFormat 格式输出的包列表
+//
+//   - gpg-pubkey 是 RPM 数据库中记录导入的签名公钥的伪包，不是真正安装的包
+//
+// 参数：
+//   - output: rpm 命令的输出
+//
+// 返回：
+//   - 包列表，忽略格式不符的行和 gpg-pubkey 伪包
+func parseRPMPackages(output string) []rpmPackage {
+	var packages []rpmPackage
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 5 || fields[0] == "gpg-pubkey" {
+			continue
+		}
+		size, _ := strconv.ParseUint(fields[4], 10, 64)
+		packages = append(packages, rpmPackage{
+			Name:    fields[0],
+			Version: fields[1],
+			Release: fields[2],
+			Arch:    fields[3],
+			Size:    size,
+		})
+	}
+
+	return packages
+}
+
+// latestRPMKernel 查找已安装的最新内核
+//
+// 参数：
+//   - packages: 已安装的包
+//
+// 返回：
+//   - 最新内核的版本号，已换算为 'uname -r' 的格式，没有内核包时为空
+func latestRPMKernel(packages []rpmPackage) string {
+	var latest *rpmPackage
+	for index, pkg := range packages {
+		if !rpmKernelPackages[pkg.Name] {
+			continue
+		}
+		if latest == nil || compareRPMVersion(pkg.Version, latest.Version) > 0 || (compareRPMVersion(pkg.Version, latest.Version) == 0 && compareRPMVersion(pkg.Release, latest.Release) > 0) {
+			latest = &packages[index]
+		}
+	}
+
+	if latest == nil {
+		return ""
+	}
+	return rpmKernelRelease(*latest)
+}
+
+// rpmKernelRelease 将内核包的版本换算为 'uname -r' 的格式
+//
+//   - Fedora/RHEL 为 'VERSION-RELEASE.ARCH'，例如 kernel-core 6.10.6-200.fc40 对应 '6.10.6-200.fc40.x86_64'
+//   - openSUSE 为 'VERSION-RELEASE-FLAVOR'，且 RELEASE 去掉最后一段构建计数，例如 kernel-default 6.4.0-150600.23.22.1 对应 '6.4.0-150600.23.22-default'
+//
+// 参数：
+//   - pkg: 内核包
+//
+// 返回：
+//   - 内核版本号
+func rpmKernelRelease(pkg rpmPackage) string {
+	if flavor, found := strings.CutPrefix(pkg.Name, "kernel-"); found && flavor != "core" {
+		release := pkg.Release
+		if index := strings.LastIndex(release, "."); index >= 0 {
+			release = release[:index]
+		}
+		return pkg.Version + "-" + release + "-" + flavor
+	}
+	return pkg.Version + "-" + pkg.Release + "." + pkg.Arch
+}
+
+// compareRPMVersion 按 rpmvercmp 规则比较两个版本号或发行号
+//
+//   - 字母段与数字段交替比较，数字段大于字母段，'~' 排在结尾之前，'^' 排在结尾之后
+//
+// 参数：
+//   - a: 版本号 a
+//   - b: 版本号 b
+//
+// 返回：
+//   - a 较新返回 1，b 较新返回 -1，相同返回 0
+func compareRPMVersion(a, b string) int {
+	if a == b {
+		return 0
+	}
+
+	isAlnum := func(c byte) bool {
+		return isDigit(c) || ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z')
+	}
+	isSeparator := func(c byte) bool {
+		return !isAlnum(c) && c != '~' && c != '^'
+	}
+
+	for a != "" || b != "" {
+		// 跳过分隔符
+		for a != "" && isSeparator(a[0]) {
+			a = a[1:]
+		}
+		for b != "" && isSeparator(b[0]) {
+			b = b[1:]
+		}
+
+		// '~' 排在任何内容之前，包括结尾
+		if strings.HasPrefix(a, "~") || strings.HasPrefix(b, "~") {
+			if !strings.HasPrefix(a, "~") {
+				return 1
+			}
+			if !strings.HasPrefix(b, "~") {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+
+		// '^' 排在结尾之后，但在其他内容之前
+		if strings.HasPrefix(a, "^") || strings.HasPrefix(b, "^") {
+			if a == "" {
+				return -1
+			}
+			if b == "" {
+				return 1
+			}
+			if !strings.HasPrefix(a, "^") {
+				return 1
+			}
+			if !strings.HasPrefix(b, "^") {
+				return -1
+			}
+			a, b = a[1:], b[1:]
+			continue
+		}
+
+		if a == "" || b == "" {
+			break
+		}
+
+		// 取出同类型的段
+		numeric := isDigit(a[0])
+		sameType := func(c byte) bool {
+			if numeric {
+				return isDigit(c)
+			}
+			return isAlnum(c) && !isDigit(c)
+		}
+		lengthA, lengthB := 0, 0
+		for lengthA < len(a) && sameType(a[lengthA]) {
+			lengthA++
+		}
+		for lengthB < len(b) && sameType(b[lengthB]) {
+			lengthB++
+		}
+		segmentA, segmentB := a[:lengthA], b[:lengthB]
+		a, b = a[lengthA:], b[lengthB:]
+
+		// 段类型不同，数字段较新
+		if segmentB == "" {
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+
+		if numeric {
+			segmentA = strings.TrimLeft(segmentA, "0")
+			segmentB = strings.TrimLeft(segmentB, "0")
+			if len(segmentA) != len(segmentB) {
+				if len(segmentA) > len(segmentB) {
+					return 1
+				}
+				return -1
+			}
+		}
+		if result := strings.Compare(segmentA, segmentB); result != 0 {
+			return result
+		}
+	}
+
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	default:
+		return 1
+	}
+}
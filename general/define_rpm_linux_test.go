@@ -0,0 +1,111 @@
+//go:build linux
+
+/*
+File: define_rpm_linux_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-10 10:32:48
+
+Description: 测试 rpm 查询结果的解析和内核版本换算
+*/
+
+package general
+
+import "testing"
+
+func TestParseRPMPackages(t *testing.T) {
+	output := "bash\t5.2.26\t3.fc40\tx86_64\t8372394\n" +
+		"gpg-pubkey\tfd431d51\t4ae0493b\t(none)\t0\n" +
+		"malformed line\n" +
+		"kernel-core\t6.10.6\t200.fc40\tx86_64\t70000000"
+
+	packages := parseRPMPackages(output)
+	if len(packages) != 2 {
+		t.Fatalf("parseRPMPackages returned %d packages, want 2: %+v", len(packages), packages)
+	}
+	for _, pkg := range packages {
+		if pkg.Name == "gpg-pubkey" {
+			t.Error("gpg-pubkey pseudo-package should be skipped")
+		}
+	}
+	if packages[0].Size != 8372394 {
+		t.Errorf("bash size = %d, want 8372394", packages[0].Size)
+	}
+}
+
+func TestLatestRPMKernel(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages []rpmPackage
+		want     string
+	}{
+		{
+			name: "fedora",
+			packages: []rpmPackage{
+				{Name: "kernel-core", Version: "6.10.6", Release: "200.fc40", Arch: "x86_64"},
+				{Name: "kernel-core", Version: "6.10.10", Release: "200.fc40", Arch: "x86_64"},
+				{Name: "kernel-core", Version: "6.10.10", Release: "100.fc40", Arch: "x86_64"},
+				{Name: "kernel-headers", Version: "6.11.0", Release: "1.fc40", Arch: "x86_64"},
+			},
+			want: "6.10.10-200.fc40.x86_64",
+		},
+		{
+			name: "rhel",
+			packages: []rpmPackage{
+				{Name: "kernel", Version: "5.14.0", Release: "427.13.1.el9_4", Arch: "x86_64"},
+			},
+			want: "5.14.0-427.13.1.el9_4.x86_64",
+		},
+		{
+			name: "opensuse leap",
+			packages: []rpmPackage{
+				{Name: "kernel-default", Version: "6.4.0", Release: "150600.23.22.1", Arch: "x86_64"},
+				{Name: "kernel-default", Version: "6.4.0", Release: "150600.23.17.1", Arch: "x86_64"},
+			},
+			want: "6.4.0-150600.23.22-default",
+		},
+		{
+			name: "opensuse tumbleweed",
+			packages: []rpmPackage{
+				{Name: "kernel-default", Version: "6.10.5", Release: "1.1", Arch: "x86_64"},
+			},
+			want: "6.10.5-1-default",
+		},
+		{
+			name:     "no kernel",
+			packages: []rpmPackage{{Name: "bash", Version: "5.2.26", Release: "3.fc40", Arch: "x86_64"}},
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		if got := latestRPMKernel(tt.packages); got != tt.want {
+			t.Errorf("%s: latestRPMKernel = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCompareRPMVersion(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "1.0.1", -1},
+		{"1.10", "1.9", 1},
+		{"1.0a", "1.0", 1},
+		{"1.0", "1.a", 1},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0^20240101", "1.0", 1},
+		{"1.0^20240101", "1.0.1", -1},
+		{"200.fc40", "100.fc40", 1},
+		{"150600.23.22.1", "150600.23.17.1", 1},
+	}
+	for _, tt := range tests {
+		if got := compareRPMVersion(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareRPMVersion(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := compareRPMVersion(tt.b, tt.a); got != -tt.want {
+			t.Errorf("compareRPMVersion(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
@@ -14,6 +14,7 @@ package general
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -27,12 +28,34 @@ type model struct {
 	TabContent []string // 标签对应的内容
 	ActiveTab  int      // 当前激活的标签
 	Cycle      bool     // 是否允许循环切换
+
+	Interval time.Duration   // 刷新间隔，为 0 时不刷新
+	Refresh  func() []string // 刷新标签内容的函数
 }
 
+// tickMsg 刷新计时到期的消息
+type tickMsg time.Time
+
+// refreshedMsg 标签内容刷新完成的消息
+type refreshedMsg []string
+
 // Init model 结构体的初始化方法，是 BubbleTea 框架中的一个特殊方法
 func (m model) Init() tea.Cmd {
-	// 返回 nil 意味着不需要 I/O 操作
-	return nil
+	// 不需要刷新时返回 nil，意味着不需要 I/O 操作
+	return m.tick()
+}
+
+// tick 开始刷新计时，不需要刷新时返回 nil
+//
+// 返回：
+//   - tea.Cmd: 计时到期后返回 tickMsg
+func (m model) tick() tea.Cmd {
+	if m.Interval <= 0 || m.Refresh == nil {
+		return nil
+	}
+	return tea.Tick(m.Interval, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
 }
 
 // Update model 结构体的更新方法，是 BubbleTea 框架中的一个特殊方法
@@ -45,6 +68,17 @@ func (m model) Init() tea.Cmd {
 //   - tea.Cmd: 一个 I/O 操作，完成后会返回一条消息，如果为 nil 则被视为无操作
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	// 计时到期，在后台刷新标签内容
+	case tickMsg:
+		return m, func() tea.Msg {
+			return refreshedMsg(m.Refresh())
+		}
+	// 刷新完成，更新标签内容并重新计时
+	case refreshedMsg:
+		if len(msg) == len(m.Tabs) {
+			m.TabContent = msg
+		}
+		return m, m.tick()
 	// 监控按键事件
 	case tea.KeyMsg:
 		// 对按下的相应按键做出对应反应
@@ -143,3 +177,28 @@ func TabSelector(tabs, contents []string, cycle bool) error {
 	}
 	return nil
 }
+
+// TabWatcher 标签监视器，与标签选择器相同，但会按间隔刷新标签内容
+//
+// 参数：
+//   - tabs: 所有标签
+//   - contents: 所有标签对应的初始内容
+//   - cycle: 是否允许循环切换
+//   - interval: 刷新间隔
+//   - refresh: 刷新标签内容的函数，返回的内容须与标签一一对应
+//
+// 返回：
+//   - 错误信息
+func TabWatcher(tabs, contents []string, cycle bool, interval time.Duration, refresh func() []string) error {
+	if len(tabs) != len(contents) {
+		return fmt.Errorf("Tabs and contents must have the same length")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("Refresh interval must be greater than 0")
+	}
+	m := model{Tabs: tabs, TabContent: contents, Cycle: cycle, Interval: interval, Refresh: refresh}
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("Error running program: %s", err)
+	}
+	return nil
+}
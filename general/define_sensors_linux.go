@@ -0,0 +1,276 @@
+//go:build linux
+
+/*
+File: define_sensors_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-27 09:26:51
+
+Description: 读取硬件传感器
+
+- /sys/class/hwmon 下每个 hwmonN 为一个芯片（coretemp、k10temp、nvme 等），读数文件为 <类型><序号>_input
+- /sys/class/thermal 下的 thermal_zoneN 为内核温控区域（acpitz、x86_pkg_temp 等），已注册为 hwmon 的区域不再重复读取
+*/
+
+package general
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	sysClassHwmonPath   = "/sys/class/hwmon"   // hwmon 的 sysfs 目录
+	sysClassThermalPath = "/sys/class/thermal" // thermal 的 sysfs 目录
+)
+
+// SensorInfo 一个传感器芯片及其读数
+type SensorInfo struct {
+	Chip     string          `json:"SensorChip"`     // 芯片名，例如 'coretemp'、'acpitz'
+	Device   string          `json:"SensorDevice"`   // 芯片所属设备，例如 'coretemp.0'、'nvme0'、'thermal_zone0'
+	Readings []SensorReading `json:"SensorReadings"` // 读数
+}
+
+// SensorReading 传感器的一个读数
+type SensorReading struct {
+	Label    string   `json:"Label"`    // 读数名，没有时为读数文件前缀，例如 'temp1'
+	Type     string   `json:"Type"`     // 读数类型：'temp'、'fan'、'in'、'curr'、'power'
+	Value    float64  `json:"Value"`    // 当前值，单位为 °C、RPM、V、A、W
+	High     *float64 `json:"High"`     // 上限，没有时为 nil
+	Critical *float64 `json:"Critical"` // 临界值，没有时为 nil
+}
+
+// sensorScales 各类型读数在 sysfs 中的单位换算，温度为毫摄氏度，电压、电流为毫伏、毫安，功率为微瓦
+var sensorScales = map[string]float64{
+	"temp":  1000,
+	"fan":   1,
+	"in":    1000,
+	"curr":  1000,
+	"power": 1000000,
+}
+
+// sensorTypeOrder 读数类型的输出顺序
+var sensorTypeOrder = []string{"temp", "fan", "in", "curr", "power"}
+
+// sensorInputPattern 匹配 hwmon 的读数文件，例如 'temp1_input'、'power1_average'
+var sensorInputPattern = regexp.MustCompile(`^(temp|fan|in|curr|power)(\d+)_(input|average)$`)
+
+// GetSensorInfo 获取硬件传感器信息
+//
+// 返回：
+//   - 传感器信息，每个芯片一个元素，按芯片名排序，没有传感器时为空
+//   - 错误信息
+func GetSensorInfo() ([]SensorInfo, error) {
+	sensors, devices, err := readHwmonSensors()
+	sensors = append(sensors, readThermalSensors(devices)...)
+
+	sort.SliceStable(sensors, func(i, j int) bool {
+		if sensors[i].Chip != sensors[j].Chip {
+			return sensors[i].Chip < sensors[j].Chip
+		}
+		return sensors[i].Device < sensors[j].Device
+	})
+
+	return sensors, err
+}
+
+// readHwmonSensors 读取 hwmon 芯片
+//
+// 返回：
+//   - 传感器信息
+//   - hwmon 芯片所属设备的真实路径，用于排除已注册为 hwmon 的 thermal 区域
+//   - 错误信息，没有 hwmon 目录时不视为错误
+func readHwmonSensors() ([]SensorInfo, map[string]bool, error) {
+	devices := make(map[string]bool)
+
+	entries, err := os.ReadDir(sysClassHwmonPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return nil, devices, err
+	}
+
+	var sensors []SensorInfo
+	for _, entry := range entries {
+		chipPath := filepath.Join(sysClassHwmonPath, entry.Name())
+		sensor := SensorInfo{
+			Chip:   readSensorValue(chipPath, "name"),
+			Device: entry.Name(),
+		}
+		if devicePath, err := filepath.EvalSymlinks(filepath.Join(chipPath, "device")); err == nil {
+			devices[devicePath] = true
+			sensor.Device = filepath.Base(devicePath)
+		}
+		if sensor.Chip == "" {
+			sensor.Chip = entry.Name()
+		}
+		sensor.Readings = readHwmonReadings(chipPath)
+		if len(sensor.Readings) > 0 {
+			sensors = append(sensors, sensor)
+		}
+	}
+
+	return sensors, devices, nil
+}
+
+// readHwmonReadings 读取一个 hwmon 芯片的读数
+//
+// 参数：
+//   - chipPath: 芯片目录
+//
+// 返回：
+//   - 读数，按类型和序号排序
+func readHwmonReadings(chipPath string) []SensorReading {
+	entries, err := os.ReadDir(chipPath)
+	if err != nil {
+		return nil
+	}
+
+	type readingKey struct {
+		Type  string
+		Index int
+	}
+	var keys []readingKey
+	inputs := make(map[readingKey]string) // 读数文件名，同时有 input 和 average 时使用 input
+	for _, entry := range entries {
+		matches := sensorInputPattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+		index, _ := strconv.Atoi(matches[2])
+		key := readingKey{Type: matches[1], Index: index}
+		if _, ok := inputs[key]; !ok {
+			keys = append(keys, key)
+		} else if matches[3] != "input" {
+			continue
+		}
+		inputs[key] = entry.Name()
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Type != keys[j].Type {
+			return indexOf(sensorTypeOrder, keys[i].Type) < indexOf(sensorTypeOrder, keys[j].Type)
+		}
+		return keys[i].Index < keys[j].Index
+	})
+
+	var readings []SensorReading
+	for _, key := range keys {
+		prefix := key.Type + strconv.Itoa(key.Index)
+		scale := sensorScales[key.Type]
+		value, ok := readSensorNumber(chipPath, inputs[key], scale)
+		if !ok {
+			continue // 读数不可用，例如未接风扇
+		}
+		reading := SensorReading{
+			Label: readSensorValue(chipPath, prefix+"_label"),
+			Type:  key.Type,
+			Value: value,
+		}
+		if reading.Label == "" {
+			reading.Label = prefix
+		}
+		if high, ok := readSensorNumber(chipPath, prefix+"_max", scale); ok {
+			reading.High = &high
+		}
+		if critical, ok := readSensorNumber(chipPath, prefix+"_crit", scale); ok {
+			reading.Critical = &critical
+		}
+		readings = append(readings, reading)
+	}
+
+	return readings
+}
+
+// readThermalSensors 读取 thermal 区域
+//
+// 参数：
+//   - hwmonDevices: hwmon 芯片所属设备的真实路径，这些区域已作为 hwmon 芯片读取
+//
+// 返回：
+//   - 传感器信息，每个区域为一个芯片
+func readThermalSensors(hwmonDevices map[string]bool) []SensorInfo {
+	zonePaths, _ := filepath.Glob(filepath.Join(sysClassThermalPath, "thermal_zone*"))
+
+	var sensors []SensorInfo
+	for _, zonePath := range zonePaths {
+		if realPath, err := filepath.EvalSymlinks(zonePath); err == nil && hwmonDevices[realPath] {
+			continue
+		}
+		value, ok := readSensorNumber(zonePath, "temp", sensorScales["temp"])
+		if !ok {
+			continue // 区域已禁用或读取失败
+		}
+		zoneType := readSensorValue(zonePath, "type")
+		reading := SensorReading{Label: zoneType, Type: "temp", Value: value}
+		if reading.Label == "" {
+			reading.Label = "temp"
+		}
+
+		// 触发点类型为 'hot' 时作为上限，'critical' 时作为临界值
+		tripTypes, _ := filepath.Glob(filepath.Join(zonePath, "trip_point_*_type"))
+		for _, tripType := range tripTypes {
+			tripName := strings.TrimSuffix(filepath.Base(tripType), "_type")
+			temperature, ok := readSensorNumber(zonePath, tripName+"_temp", sensorScales["temp"])
+			if !ok {
+				continue
+			}
+			switch readSensorValue(zonePath, filepath.Base(tripType)) {
+			case "hot":
+				reading.High = &temperature
+			case "critical":
+				reading.Critical = &temperature
+			}
+		}
+
+		sensor := SensorInfo{
+			Chip:     zoneType,
+			Device:   filepath.Base(zonePath),
+			Readings: []SensorReading{reading},
+		}
+		if sensor.Chip == "" {
+			sensor.Chip = sensor.Device
+		}
+		sensors = append(sensors, sensor)
+	}
+
+	return sensors
+}
+
+// readSensorValue 读取传感器目录下的文件
+//
+// 参数：
+//   - dirPath: 芯片或区域目录
+//   - fileName: 文件名
+//
+// 返回：
+//   - 文件内容，不存在时为空
+func readSensorValue(dirPath, fileName string) string {
+	value, err := os.ReadFile(filepath.Join(dirPath, fileName))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(value))
+}
+
+// readSensorNumber 读取传感器目录下的数值文件并换算单位
+//
+// 参数：
+//   - dirPath: 芯片或区域目录
+//   - fileName: 文件名
+//   - scale: sysfs 单位与输出单位的比例
+//
+// 返回：
+//   - 换算后的数值
+//   - 读取成功返回 true，文件不存在或不是数值时返回 false
+func readSensorNumber(dirPath, fileName string, scale float64) (float64, bool) {
+	value, err := strconv.ParseFloat(readSensorValue(dirPath, fileName), 64)
+	if err != nil {
+		return 0, false
+	}
+	return value / scale, true
+}
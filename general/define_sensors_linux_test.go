@@ -0,0 +1,234 @@
+//go:build linux
+
+/*
+File: define_sensors_linux_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-10 15:12:09
+
+Description: 测试硬件传感器的读取和读数的着色，在临时目录中构造 hwmon 和 thermal 的 sysfs 结构
+*/
+
+package general
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gookit/color"
+)
+
+// writeSensorFiles 在目录中写入传感器文件
+//
+// 参数：
+//   - t: 测试对象
+//   - dirPath: 目录
+//   - files: 文件名及内容
+func writeSensorFiles(t *testing.T, dirPath string, files map[string]string) {
+	t.Helper()
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dirPath, name), []byte(content+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// symlinkSensorPath 创建符号链接，模拟 sysfs 中指向 devices 目录的链接
+//
+// 参数：
+//   - t: 测试对象
+//   - target: 链接目标
+//   - link: 链接路径
+func symlinkSensorPath(t *testing.T, target, link string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(link), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// useSensorFixture 构造传感器 sysfs 结构并替换读取路径
+//
+//   - hwmon0: coretemp，读数带标签、上限、临界值，也有只有 average 或 input、average 同时存在的读数
+//   - hwmon1: 没有 name 和 device
+//   - hwmon2: acpitz，所属设备为 thermal_zone0，该区域不应重复读取
+//   - thermal_zone1: 带 hot 和 critical 触发点
+//   - thermal_zone2: 温度不可读，应跳过
+//   - thermal_zone3: 没有 type
+//
+// 参数：
+//   - t: 测试对象
+func useSensorFixture(t *testing.T) {
+	root := t.TempDir()
+	hwmonPath := filepath.Join(root, "class", "hwmon")
+	thermalPath := filepath.Join(root, "class", "thermal")
+	devicesPath := filepath.Join(root, "devices")
+
+	coretemp := filepath.Join(devicesPath, "platform", "coretemp.0")
+	writeSensorFiles(t, coretemp, nil)
+	writeSensorFiles(t, filepath.Join(hwmonPath, "hwmon0"), map[string]string{
+		"name":             "coretemp",
+		"temp1_input":      "45000",
+		"temp1_label":      "Package id 0",
+		"temp1_max":        "80000",
+		"temp1_crit":       "100000",
+		"temp1_crit_alarm": "0",
+		"temp2_input":      "50500",
+		"in0_input":        "1200",
+		"in0_average":      "1100",
+		"power1_average":   "15000000",
+		"fan1_input":       "0",
+		"fan2_input":       "",
+	})
+	symlinkSensorPath(t, coretemp, filepath.Join(hwmonPath, "hwmon0", "device"))
+
+	writeSensorFiles(t, filepath.Join(hwmonPath, "hwmon1"), map[string]string{
+		"temp1_input": "30000",
+	})
+
+	zone0 := filepath.Join(devicesPath, "virtual", "thermal", "thermal_zone0")
+	writeSensorFiles(t, zone0, map[string]string{"type": "acpitz", "temp": "40000"})
+	writeSensorFiles(t, filepath.Join(hwmonPath, "hwmon2"), map[string]string{
+		"name":        "acpitz",
+		"temp1_input": "40000",
+	})
+	symlinkSensorPath(t, zone0, filepath.Join(hwmonPath, "hwmon2", "device"))
+	symlinkSensorPath(t, zone0, filepath.Join(thermalPath, "thermal_zone0"))
+
+	zone1 := filepath.Join(devicesPath, "virtual", "thermal", "thermal_zone1")
+	writeSensorFiles(t, zone1, map[string]string{
+		"type":              "x86_pkg_temp",
+		"temp":              "55000",
+		"trip_point_0_type": "passive",
+		"trip_point_0_temp": "70000",
+		"trip_point_1_type": "hot",
+		"trip_point_1_temp": "90000",
+		"trip_point_2_type": "critical",
+		"trip_point_2_temp": "105000",
+	})
+	symlinkSensorPath(t, zone1, filepath.Join(thermalPath, "thermal_zone1"))
+
+	writeSensorFiles(t, filepath.Join(thermalPath, "thermal_zone2"), map[string]string{"type": "iwlwifi_1"})
+	writeSensorFiles(t, filepath.Join(thermalPath, "thermal_zone3"), map[string]string{"temp": "35000"})
+
+	oldHwmonPath, oldThermalPath := sysClassHwmonPath, sysClassThermalPath
+	sysClassHwmonPath, sysClassThermalPath = hwmonPath, thermalPath
+	t.Cleanup(func() {
+		sysClassHwmonPath, sysClassThermalPath = oldHwmonPath, oldThermalPath
+	})
+}
+
+// sensorFloat 返回浮点数指针
+func sensorFloat(value float64) *float64 {
+	return &value
+}
+
+func TestGetSensorInfo(t *testing.T) {
+	useSensorFixture(t)
+
+	sensors, err := GetSensorInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SensorInfo{
+		{Chip: "acpitz", Device: "thermal_zone0", Readings: []SensorReading{
+			{Label: "temp1", Type: "temp", Value: 40},
+		}},
+		{Chip: "coretemp", Device: "coretemp.0", Readings: []SensorReading{
+			{Label: "Package id 0", Type: "temp", Value: 45, High: sensorFloat(80), Critical: sensorFloat(100)},
+			{Label: "temp2", Type: "temp", Value: 50.5},
+			{Label: "fan1", Type: "fan", Value: 0},
+			{Label: "in0", Type: "in", Value: 1.2},
+			{Label: "power1", Type: "power", Value: 15},
+		}},
+		{Chip: "hwmon1", Device: "hwmon1", Readings: []SensorReading{
+			{Label: "temp1", Type: "temp", Value: 30},
+		}},
+		{Chip: "thermal_zone3", Device: "thermal_zone3", Readings: []SensorReading{
+			{Label: "temp", Type: "temp", Value: 35},
+		}},
+		{Chip: "x86_pkg_temp", Device: "thermal_zone1", Readings: []SensorReading{
+			{Label: "x86_pkg_temp", Type: "temp", Value: 55, High: sensorFloat(90), Critical: sensorFloat(105)},
+		}},
+	}
+	if !reflect.DeepEqual(sensors, want) {
+		t.Errorf("GetSensorInfo mismatch\n got: %s\nwant: %s", describeSensors(sensors), describeSensors(want))
+	}
+}
+
+func TestGetSensorInfoWithoutSysfs(t *testing.T) {
+	oldHwmonPath, oldThermalPath := sysClassHwmonPath, sysClassThermalPath
+	sysClassHwmonPath = filepath.Join(t.TempDir(), "hwmon")
+	sysClassThermalPath = filepath.Join(t.TempDir(), "thermal")
+	t.Cleanup(func() {
+		sysClassHwmonPath, sysClassThermalPath = oldHwmonPath, oldThermalPath
+	})
+
+	sensors, err := GetSensorInfo()
+	if err != nil {
+		t.Errorf("missing sysfs directories should not be an error: %v", err)
+	}
+	if len(sensors) != 0 {
+		t.Errorf("got %d sensors, want none", len(sensors))
+	}
+}
+
+// describeSensors 以可读形式列出传感器，指针字段展开为数值
+func describeSensors(sensors []SensorInfo) string {
+	var lines []string
+	for _, sensor := range sensors {
+		for _, reading := range sensor.Readings {
+			lines = append(lines, color.Sprintf("%s/%s %s %s=%v high=%s crit=%s", sensor.Chip, sensor.Device, reading.Type, reading.Label, reading.Value, formatSensorValue(reading.Type, reading.High), formatSensorValue(reading.Type, reading.Critical)))
+		}
+	}
+	return strings.Join(lines, "; ")
+}
+
+func TestComposeSensorReadingsColor(t *testing.T) {
+	oldLevel := color.ForceOpenColor()
+	t.Cleanup(func() { color.ForceSetColorLevel(oldLevel) })
+	if WarnText("x") == "x" || WarnText("x") == DangerText("x") {
+		t.Fatal("colors are not rendered, cannot tell warn and danger lines apart")
+	}
+
+	tests := []struct {
+		name    string
+		reading SensorReading
+		render  func(...any) string // 期望的着色，nil 表示不着色
+	}{
+		{"normal", SensorReading{Label: "cpu", Type: "temp", Value: 50, High: sensorFloat(80), Critical: sensorFloat(100)}, nil},
+		{"reaches high", SensorReading{Label: "cpu", Type: "temp", Value: 80, High: sensorFloat(80), Critical: sensorFloat(100)}, WarnText},
+		{"near critical", SensorReading{Label: "cpu", Type: "temp", Value: 90, Critical: sensorFloat(100)}, WarnText},
+		{"just below near critical", SensorReading{Label: "cpu", Type: "temp", Value: 89.9, Critical: sensorFloat(100)}, nil},
+		{"reaches critical", SensorReading{Label: "cpu", Type: "temp", Value: 100, High: sensorFloat(80), Critical: sensorFloat(100)}, DangerText},
+		{"zero thresholds ignored", SensorReading{Label: "cpu", Type: "temp", Value: 40, High: sensorFloat(0), Critical: sensorFloat(0)}, nil},
+		{"no thresholds", SensorReading{Label: "fan1", Type: "fan", Value: 5000}, nil},
+	}
+	for _, tt := range tests {
+		composed := ComposeSensorReadings([]SensorReading{tt.reading})
+		if len(composed) != 2 {
+			t.Fatalf("%s: got %d lines, want header and one reading", tt.name, len(composed))
+		}
+		plain := color.Sprintf("%-16v %12v %12v %12v", tt.reading.Label, formatSensorValue(tt.reading.Type, &tt.reading.Value), formatSensorValue(tt.reading.Type, tt.reading.High), formatSensorValue(tt.reading.Type, tt.reading.Critical))
+		want := plain
+		if tt.render != nil {
+			want = tt.render(plain)
+		}
+		if composed[1] != want {
+			t.Errorf("%s: got %q, want %q", tt.name, composed[1], want)
+		}
+	}
+
+	if composed := ComposeSensorReadings(nil); composed != nil {
+		t.Errorf("ComposeSensorReadings(nil) = %q, want nil", composed)
+	}
+}
@@ -0,0 +1,318 @@
+//go:build linux
+
+/*
+File: define_services_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-06 10:27:15
+
+Description: 查询 systemd 系统和用户管理器的状态及单元状态
+
+- 优先通过 D-Bus 查询 org.freedesktop.systemd1，总线不可用或总线上没有 systemd 时改为运行 systemctl
+- 查询方式由 unitQuerier 接口抽象，dialSystemdBus 和 systemctlRunner 可替换为模拟的总线或命令
+*/
+
+package general
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// systemd 的 D-Bus 名称
+const (
+	systemdBusName          = "org.freedesktop.systemd1"
+	systemdObjectPath       = "/org/freedesktop/systemd1"
+	systemdManagerInterface = "org.freedesktop.systemd1.Manager"
+	systemdUnitInterface    = "org.freedesktop.systemd1.Unit"
+)
+
+// systemctlTimestampLayout systemctl show 输出的时间戳格式
+const systemctlTimestampLayout = "Mon 2006-01-02 15:04:05 MST"
+
+// ServiceInfo 一个 systemd 管理器的服务信息
+type ServiceInfo struct {
+	Manager     string        `json:"ServiceManager"`     // 管理器：'system' 或 'user'
+	SystemState string        `json:"ServiceSystemState"` // 管理器状态，即 systemctl is-system-running 的输出，无法查询时为 'unavailable'
+	FailedUnits []string      `json:"ServiceFailedUnits"` // 失败的单元
+	Units       []ServiceUnit `json:"ServiceUnits"`       // 关注的单元
+}
+
+// ServiceUnit 单元状态
+type ServiceUnit struct {
+	Name        string `json:"Name"`        // 单元名
+	LoadState   string `json:"LoadState"`   // 加载状态，例如 'loaded'、'not-found'
+	Enabled     string `json:"Enabled"`     // 启用状态，例如 'enabled'、'disabled'、'static'
+	ActiveState string `json:"ActiveState"` // 活动状态，例如 'active'、'inactive'、'failed'
+	SubState    string `json:"SubState"`    // 子状态，例如 'running'、'exited'、'dead'
+	Since       int64  `json:"Since"`       // 进入当前活动状态的时间，Unix 时间戳，未知时为 0
+}
+
+// unitQuerier systemd 管理器的查询方式
+type unitQuerier interface {
+	// SystemState 查询管理器状态
+	SystemState() (string, error)
+	// FailedUnits 查询失败的单元名
+	FailedUnits() ([]string, error)
+	// Unit 查询单元状态，单元不存在时 LoadState 为 'not-found' 而不返回错误
+	Unit(name string) (ServiceUnit, error)
+	// Close 释放资源
+	Close()
+}
+
+// dialSystemdBus 通过 D-Bus 连接 systemd 管理器
+var dialSystemdBus = func(manager string) (unitQuerier, error) {
+	bus, err := dialDBus(manager)
+	if err != nil {
+		return nil, err
+	}
+	return dbusUnitQuerier{bus: bus}, nil
+}
+
+// systemctlRunner 运行 systemctl 的函数
+var systemctlRunner = RunCommandToBuffer
+
+// GetServiceInfo 获取 systemd 管理器的服务信息
+//
+// 参数：
+//   - manager: 管理器，'system' 或 'user'
+//   - units: 关注的单元名
+//
+// 返回：
+//   - 服务信息
+//   - 错误信息
+func GetServiceInfo(manager string, units []string) (ServiceInfo, error) {
+	serviceInfo := ServiceInfo{Manager: manager}
+
+	querier, state, err := openUnitQuerier(manager)
+	if err != nil {
+		serviceInfo.SystemState = "unavailable"
+		return serviceInfo, err
+	}
+	defer querier.Close()
+	serviceInfo.SystemState = state
+	// 管理器未运行（例如不以 systemd 为 1 号进程的容器）时无法查询单元
+	if state == "offline" {
+		return serviceInfo, nil
+	}
+
+	var errs []error
+	serviceInfo.FailedUnits, err = querier.FailedUnits()
+	errs = append(errs, err)
+	for _, name := range units {
+		unit, err := querier.Unit(name)
+		unit.Name = name
+		serviceInfo.Units = append(serviceInfo.Units, unit)
+		errs = append(errs, err)
+	}
+
+	return serviceInfo, errors.Join(errs...)
+}
+
+// openUnitQuerier 打开 systemd 管理器的查询方式并查询管理器状态
+//
+// 参数：
+//   - manager: 管理器，'system' 或 'user'
+//
+// 返回：
+//   - 查询方式
+//   - 管理器状态
+//   - 错误信息
+func openUnitQuerier(manager string) (unitQuerier, string, error) {
+	if querier, err := dialSystemdBus(manager); err == nil {
+		// 总线上可能没有 systemd，例如使用 OpenRC 的系统
+		if state, err := querier.SystemState(); err == nil {
+			return querier, state, nil
+		}
+		querier.Close()
+	}
+
+	querier := commandUnitQuerier{manager: manager}
+	state, err := querier.SystemState()
+	if err != nil {
+		return nil, "", err
+	}
+	return querier, state, nil
+}
+
+// dbusUnitQuerier 通过 D-Bus 查询 systemd 管理器
+type dbusUnitQuerier struct {
+	bus *dbusConn // D-Bus 连接
+}
+
+// SystemState 查询管理器状态
+func (q dbusUnitQuerier) SystemState() (string, error) {
+	value, err := q.bus.GetProperty(systemdBusName, systemdObjectPath, systemdManagerInterface, "SystemState")
+	if err != nil {
+		return "", err
+	}
+	state, _ := value.(string)
+	return state, nil
+}
+
+// FailedUnits 查询失败的单元名
+func (q dbusUnitQuerier) FailedUnits() ([]string, error) {
+	// 每个单元为 (名称, 描述, 加载状态, 活动状态, 子状态, ...)
+	body, err := q.bus.Call(systemdBusName, systemdObjectPath, systemdManagerInterface, "ListUnitsFiltered", []string{"failed"})
+	var dbusErr *dbusError
+	if errors.As(err, &dbusErr) && dbusErr.Name == "org.freedesktop.DBus.Error.UnknownMethod" {
+		// systemd 230 之前没有 ListUnitsFiltered
+		body, err = q.bus.Call(systemdBusName, systemdObjectPath, systemdManagerInterface, "ListUnits")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, fmt.Errorf("empty reply for ListUnits")
+	}
+
+	rawUnits, ok := body[0].([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reply for ListUnits: %T", body[0])
+	}
+
+	var failedUnits []string
+	for _, rawUnit := range rawUnits {
+		unit, _ := rawUnit.([]any)
+		if len(unit) < 4 {
+			continue
+		}
+		if activeState, _ := unit[3].(string); activeState == "failed" {
+			name, _ := unit[0].(string)
+			failedUnits = append(failedUnits, name)
+		}
+	}
+	return failedUnits, nil
+}
+
+// Unit 查询单元状态
+func (q dbusUnitQuerier) Unit(name string) (ServiceUnit, error) {
+	unit := ServiceUnit{Name: name}
+
+	// LoadUnit 在单元未加载时加载它，单元不存在时也返回对象路径，其 LoadState 为 'not-found'
+	body, err := q.bus.Call(systemdBusName, systemdObjectPath, systemdManagerInterface, "LoadUnit", name)
+	if err != nil {
+		return unit, err
+	}
+	if len(body) == 0 {
+		return unit, fmt.Errorf("empty reply for LoadUnit %s", name)
+	}
+	path, ok := body[0].(string)
+	if !ok {
+		return unit, fmt.Errorf("unexpected reply for LoadUnit %s: %T", name, body[0])
+	}
+
+	for property, target := range map[string]*string{
+		"LoadState":     &unit.LoadState,
+		"ActiveState":   &unit.ActiveState,
+		"SubState":      &unit.SubState,
+		"UnitFileState": &unit.Enabled,
+	} {
+		value, err := q.bus.GetProperty(systemdBusName, path, systemdUnitInterface, property)
+		if err != nil {
+			return unit, err
+		}
+		*target, _ = value.(string)
+	}
+	// 时间戳单位为微秒，从未改变过状态时为 0
+	value, err := q.bus.GetProperty(systemdBusName, path, systemdUnitInterface, "StateChangeTimestamp")
+	if err != nil {
+		return unit, err
+	}
+	if timestamp, _ := value.(uint64); timestamp > 0 {
+		unit.Since = int64(timestamp / 1e6)
+	}
+
+	return unit, nil
+}
+
+// Close 关闭 D-Bus 连接
+func (q dbusUnitQuerier) Close() {
+	q.bus.Close()
+}
+
+// commandUnitQuerier 通过 systemctl 查询 systemd 管理器
+type commandUnitQuerier struct {
+	manager string // 管理器，'system' 或 'user'
+}
+
+// run 运行 systemctl
+//
+// 参数：
+//   - args: systemctl 子命令及其参数
+//
+// 返回：
+//   - Stdout 内容
+//   - 错误信息
+func (q commandUnitQuerier) run(args ...string) (string, error) {
+	stdout, stderr, err := systemctlRunner("systemctl", append([]string{"--" + q.manager}, args...))
+	if err != nil && stderr != "" {
+		err = fmt.Errorf("%w: %s", err, stderr)
+	}
+	return stdout, err
+}
+
+// SystemState 查询管理器状态
+func (q commandUnitQuerier) SystemState() (string, error) {
+	// 管理器状态不是 running 时退出码也不为 0，以是否有输出判断是否成功
+	stdout, err := q.run("is-system-running")
+	if stdout == "" {
+		if err == nil {
+			err = fmt.Errorf("systemctl is-system-running returned nothing")
+		}
+		return "", err
+	}
+	return stdout, nil
+}
+
+// FailedUnits 查询失败的单元名
+func (q commandUnitQuerier) FailedUnits() ([]string, error) {
+	stdout, err := q.run("list-units", "--state=failed", "--plain", "--no-legend", "--no-pager")
+	if err != nil {
+		return nil, err
+	}
+
+	var failedUnits []string
+	for _, line := range strings.Split(stdout, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			failedUnits = append(failedUnits, fields[0])
+		}
+	}
+	return failedUnits, nil
+}
+
+// Unit 查询单元状态
+func (q commandUnitQuerier) Unit(name string) (ServiceUnit, error) {
+	unit := ServiceUnit{Name: name}
+
+	stdout, err := q.run("show", name, "--property=LoadState,ActiveState,SubState,UnitFileState,StateChangeTimestamp")
+	if err != nil {
+		return unit, err
+	}
+	// 每行格式为 '属性名=值'
+	for _, line := range strings.Split(stdout, "\n") {
+		key, value, _ := strings.Cut(line, "=")
+		switch key {
+		case "LoadState":
+			unit.LoadState = value
+		case "ActiveState":
+			unit.ActiveState = value
+		case "SubState":
+			unit.SubState = value
+		case "UnitFileState":
+			unit.Enabled = value
+		case "StateChangeTimestamp":
+			// 例如 'Fri 2024-09-06 09:30:12 CST'，从未改变过状态时为空
+			if since, err := time.ParseInLocation(systemctlTimestampLayout, value, time.Local); err == nil {
+				unit.Since = since.Unix()
+			}
+		}
+	}
+
+	return unit, nil
+}
+
+// Close 无需释放资源
+func (q commandUnitQuerier) Close() {}
@@ -0,0 +1,351 @@
+//go:build linux
+
+/*
+File: define_services_linux_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-11 17:22:40
+
+Description: 测试 systemd 管理器的查询，包括模拟的 systemctl、模拟的 D-Bus 总线和两者之间的回退
+*/
+
+package general
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSystemctl 模拟 systemctl 的输出
+type fakeSystemctl struct {
+	outputs map[string]fakeSystemctlOutput // 键为子命令
+	calls   [][]string                     // 收到的参数
+}
+
+// fakeSystemctlOutput 模拟的一次 systemctl 运行结果
+type fakeSystemctlOutput struct {
+	stdout string
+	stderr string
+	err    error
+}
+
+// useFakeSystemctl 替换 systemctlRunner，测试结束后恢复
+//
+// 参数：
+//   - t: 测试对象
+//   - outputs: 子命令及其运行结果，未列出的子命令返回错误
+//
+// 返回：
+//   - 模拟的 systemctl
+func useFakeSystemctl(t *testing.T, outputs map[string]fakeSystemctlOutput) *fakeSystemctl {
+	fake := &fakeSystemctl{outputs: outputs}
+	oldRunner := systemctlRunner
+	systemctlRunner = func(command string, args []string) (string, string, error) {
+		fake.calls = append(fake.calls, append([]string{command}, args...))
+		if len(args) < 2 {
+			return "", "", fmt.Errorf("unexpected systemctl arguments %q", args)
+		}
+		output, ok := fake.outputs[args[1]]
+		if !ok {
+			return "", "Unknown command verb " + args[1], errors.New("exit status 1")
+		}
+		return output.stdout, output.stderr, output.err
+	}
+	t.Cleanup(func() { systemctlRunner = oldRunner })
+	return fake
+}
+
+// useDialSystemdBus 替换 dialSystemdBus，测试结束后恢复
+//
+// 参数：
+//   - t: 测试对象
+//   - dial: 连接总线的函数
+func useDialSystemdBus(t *testing.T, dial func(manager string) (unitQuerier, error)) {
+	oldDial := dialSystemdBus
+	dialSystemdBus = dial
+	t.Cleanup(func() { dialSystemdBus = oldDial })
+}
+
+// fakeUnitQuerier 模拟的查询方式
+type fakeUnitQuerier struct {
+	state    string                 // 管理器状态
+	stateErr error                  // 查询管理器状态的错误
+	failed   []string               // 失败的单元
+	units    map[string]ServiceUnit // 单元状态，未列出的单元不存在
+	closed   *bool                  // 是否已关闭
+}
+
+func (q fakeUnitQuerier) SystemState() (string, error)   { return q.state, q.stateErr }
+func (q fakeUnitQuerier) FailedUnits() ([]string, error) { return q.failed, nil }
+func (q fakeUnitQuerier) Close()                         { *q.closed = true }
+
+func (q fakeUnitQuerier) Unit(name string) (ServiceUnit, error) {
+	unit, ok := q.units[name]
+	if !ok {
+		return ServiceUnit{LoadState: "not-found", ActiveState: "inactive", SubState: "dead"}, nil
+	}
+	return unit, nil
+}
+
+func TestCommandUnitQuerier(t *testing.T) {
+	since := time.Date(2024, 9, 6, 9, 30, 12, 0, time.Local)
+	fake := useFakeSystemctl(t, map[string]fakeSystemctlOutput{
+		// 状态不是 running 时退出码不为 0，但仍有输出
+		"is-system-running": {stdout: "degraded", err: errors.New("exit status 1")},
+		"list-units":        {stdout: "backup.service loaded failed failed Nightly backup\nnfs-mount.mount  loaded failed failed /mnt/nfs\n\n"},
+		"show": {stdout: strings.Join([]string{
+			"LoadState=loaded",
+			"ActiveState=active",
+			"SubState=running",
+			"UnitFileState=enabled",
+			"StateChangeTimestamp=" + since.Format(systemctlTimestampLayout),
+		}, "\n")},
+	})
+
+	querier := commandUnitQuerier{manager: "user"}
+	if state, err := querier.SystemState(); err != nil || state != "degraded" {
+		t.Errorf("SystemState = %q, %v, want \"degraded\"", state, err)
+	}
+	failed, err := querier.FailedUnits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"backup.service", "nfs-mount.mount"}; !slices.Equal(failed, want) {
+		t.Errorf("FailedUnits = %q, want %q", failed, want)
+	}
+	unit, err := querier.Unit("sshd.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ServiceUnit{Name: "sshd.service", LoadState: "loaded", Enabled: "enabled", ActiveState: "active", SubState: "running", Since: since.Unix()}
+	if unit != want {
+		t.Errorf("Unit = %+v, want %+v", unit, want)
+	}
+
+	for _, call := range fake.calls {
+		if len(call) < 2 || call[0] != "systemctl" || call[1] != "--user" {
+			t.Errorf("systemctl called with %q, want the --user manager first", call)
+		}
+	}
+	if last := fake.calls[len(fake.calls)-1]; !slices.Contains(last, "sshd.service") {
+		t.Errorf("show called with %q, want the unit name", last)
+	}
+}
+
+func TestCommandUnitQuerierEdgeCases(t *testing.T) {
+	useFakeSystemctl(t, map[string]fakeSystemctlOutput{
+		"list-units": {stdout: ""},
+		// 单元不存在，从未改变过状态，时间戳为空
+		"show": {stdout: "LoadState=not-found\nActiveState=inactive\nSubState=dead\nUnitFileState=\nStateChangeTimestamp="},
+	})
+
+	querier := commandUnitQuerier{manager: "system"}
+	if failed, err := querier.FailedUnits(); err != nil || failed != nil {
+		t.Errorf("FailedUnits = %q, %v, want none", failed, err)
+	}
+	unit, err := querier.Unit("missing.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (ServiceUnit{Name: "missing.service", LoadState: "not-found", ActiveState: "inactive", SubState: "dead"}); unit != want {
+		t.Errorf("Unit = %+v, want %+v", unit, want)
+	}
+
+	// 未列出的子命令失败，错误中应带有 stderr
+	if _, err := querier.SystemState(); err == nil || !strings.Contains(err.Error(), "Unknown command verb") {
+		t.Errorf("SystemState error = %v, want the stderr text", err)
+	}
+}
+
+func TestCommandUnitQuerierSystemStateEmpty(t *testing.T) {
+	useFakeSystemctl(t, map[string]fakeSystemctlOutput{
+		"is-system-running": {},
+	})
+	if state, err := (commandUnitQuerier{manager: "system"}).SystemState(); err == nil {
+		t.Errorf("SystemState = %q, want an error for empty output", state)
+	}
+}
+
+func TestOpenUnitQuerierFallback(t *testing.T) {
+	useFakeSystemctl(t, map[string]fakeSystemctlOutput{
+		"is-system-running": {stdout: "running"},
+	})
+
+	// 总线不可用
+	useDialSystemdBus(t, func(manager string) (unitQuerier, error) {
+		return nil, errors.New("dial unix /run/dbus/system_bus_socket: connect: no such file or directory")
+	})
+	querier, state, err := openUnitQuerier("system")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := querier.(commandUnitQuerier); !ok || state != "running" {
+		t.Errorf("bus unavailable: got %T with state %q, want commandUnitQuerier with \"running\"", querier, state)
+	}
+
+	// 总线可用但没有 systemd，连接应被关闭
+	closed := false
+	useDialSystemdBus(t, func(manager string) (unitQuerier, error) {
+		return fakeUnitQuerier{stateErr: &dbusError{Name: "org.freedesktop.DBus.Error.ServiceUnknown"}, closed: &closed}, nil
+	})
+	querier, state, err = openUnitQuerier("system")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := querier.(commandUnitQuerier); !ok || state != "running" {
+		t.Errorf("no systemd on bus: got %T with state %q, want commandUnitQuerier with \"running\"", querier, state)
+	}
+	if !closed {
+		t.Error("no systemd on bus: bus querier was not closed")
+	}
+
+	// 总线可用时不运行 systemctl
+	fake := useFakeSystemctl(t, nil)
+	useDialSystemdBus(t, func(manager string) (unitQuerier, error) {
+		return fakeUnitQuerier{state: "starting", closed: new(bool)}, nil
+	})
+	querier, state, err = openUnitQuerier("user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := querier.(fakeUnitQuerier); !ok || state != "starting" {
+		t.Errorf("bus available: got %T with state %q, want the bus querier with \"starting\"", querier, state)
+	}
+	if len(fake.calls) != 0 {
+		t.Errorf("bus available: systemctl was called %d times", len(fake.calls))
+	}
+}
+
+func TestGetServiceInfo(t *testing.T) {
+	useFakeSystemctl(t, nil)
+
+	closed := false
+	querier := fakeUnitQuerier{
+		state:  "degraded",
+		failed: []string{"backup.service"},
+		units: map[string]ServiceUnit{
+			"sshd.service": {LoadState: "loaded", Enabled: "enabled", ActiveState: "active", SubState: "running", Since: 1725586212},
+		},
+		closed: &closed,
+	}
+	useDialSystemdBus(t, func(manager string) (unitQuerier, error) { return querier, nil })
+
+	serviceInfo, err := GetServiceInfo("system", []string{"sshd.service", "missing.service"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ServiceInfo{
+		Manager:     "system",
+		SystemState: "degraded",
+		FailedUnits: []string{"backup.service"},
+		Units: []ServiceUnit{
+			{Name: "sshd.service", LoadState: "loaded", Enabled: "enabled", ActiveState: "active", SubState: "running", Since: 1725586212},
+			{Name: "missing.service", LoadState: "not-found", ActiveState: "inactive", SubState: "dead"},
+		},
+	}
+	if !reflect.DeepEqual(serviceInfo, want) {
+		t.Errorf("GetServiceInfo = %+v, want %+v", serviceInfo, want)
+	}
+	if !closed {
+		t.Error("querier was not closed")
+	}
+
+	// 管理器未运行时不查询单元
+	querier.state = "offline"
+	serviceInfo, err = GetServiceInfo("system", []string{"sshd.service"})
+	if err != nil || serviceInfo.SystemState != "offline" || serviceInfo.FailedUnits != nil || serviceInfo.Units != nil {
+		t.Errorf("offline: got %+v, %v, want only the state", serviceInfo, err)
+	}
+
+	// 总线和 systemctl 都不可用
+	useDialSystemdBus(t, func(manager string) (unitQuerier, error) { return nil, errors.New("no bus") })
+	serviceInfo, err = GetServiceInfo("user", []string{"sshd.service"})
+	if err == nil || serviceInfo.SystemState != "unavailable" || serviceInfo.Manager != "user" {
+		t.Errorf("unavailable: got %+v, %v, want state \"unavailable\" and an error", serviceInfo, err)
+	}
+}
+
+func TestDBusUnitQuerier(t *testing.T) {
+	const unitPath = "/org/freedesktop/systemd1/unit/sshd_2eservice"
+	properties := map[string]dbusVariant{
+		"SystemState":          {"s", "running"},
+		"LoadState":            {"s", "loaded"},
+		"ActiveState":          {"s", "active"},
+		"SubState":             {"s", "running"},
+		"UnitFileState":        {"s", "enabled"},
+		"StateChangeTimestamp": {"t", uint64(1725586212123456)},
+	}
+	// listedUnit 构造 ListUnits 返回的一个单元
+	listedUnit := func(name, activeState string) []any {
+		return []any{name, "", "loaded", activeState, activeState, "", "/org/freedesktop/systemd1/unit/x", uint32(0), "", "/"}
+	}
+
+	bus := dialFakeDBus(t, func(call fakeDBusCall) fakeDBusReply {
+		switch call.member {
+		case "Get":
+			property := call.args[1].(string)
+			if call.path != unitPath && property != "SystemState" {
+				return fakeDBusReply{errorName: "org.freedesktop.DBus.Error.UnknownObject", signature: "s", body: []any{call.path}}
+			}
+			return fakeDBusReply{signature: "v", body: []any{properties[property]}}
+		case "ListUnitsFiltered":
+			// 模拟 systemd 230 之前的版本
+			return fakeDBusReply{errorName: "org.freedesktop.DBus.Error.UnknownMethod", signature: "s", body: []any{"Unknown method ListUnitsFiltered"}}
+		case "ListUnits":
+			return fakeDBusReply{signature: "a(ssssssouso)", body: []any{[]any{
+				listedUnit("sshd.service", "active"),
+				listedUnit("backup.service", "failed"),
+				listedUnit("nfs-mount.mount", "failed"),
+			}}}
+		case "LoadUnit":
+			return fakeDBusReply{signature: "o", body: []any{unitPath}}
+		}
+		return fakeDBusReply{errorName: "org.freedesktop.DBus.Error.UnknownMethod", signature: "s", body: []any{call.member}}
+	})
+	querier := dbusUnitQuerier{bus: bus}
+
+	if state, err := querier.SystemState(); err != nil || state != "running" {
+		t.Errorf("SystemState = %q, %v, want \"running\"", state, err)
+	}
+	failed, err := querier.FailedUnits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"backup.service", "nfs-mount.mount"}; !slices.Equal(failed, want) {
+		t.Errorf("FailedUnits = %q, want %q", failed, want)
+	}
+	unit, err := querier.Unit("sshd.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ServiceUnit{Name: "sshd.service", LoadState: "loaded", Enabled: "enabled", ActiveState: "active", SubState: "running", Since: 1725586212}
+	if unit != want {
+		t.Errorf("Unit = %+v, want %+v", unit, want)
+	}
+}
+
+func TestDBusUnitQuerierUnexpectedReply(t *testing.T) {
+	// 应答的签名与方法不符时返回错误而不是 panic
+	bus := dialFakeDBus(t, func(call fakeDBusCall) fakeDBusReply {
+		switch call.member {
+		case "ListUnitsFiltered":
+			return fakeDBusReply{signature: "s", body: []any{"failed"}}
+		case "LoadUnit":
+			return fakeDBusReply{signature: "u", body: []any{uint32(1)}}
+		}
+		return fakeDBusReply{errorName: "org.freedesktop.DBus.Error.UnknownMethod", signature: "s", body: []any{call.member}}
+	})
+	querier := dbusUnitQuerier{bus: bus}
+
+	if failed, err := querier.FailedUnits(); err == nil {
+		t.Errorf("FailedUnits = %q, want error", failed)
+	}
+	if unit, err := querier.Unit("sshd.service"); err == nil {
+		t.Errorf("Unit = %+v, want error", unit)
+	}
+}
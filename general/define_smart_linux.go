@@ -0,0 +1,415 @@
+//go:build linux
+
+/*
+File: define_smart_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-26 10:18:44
+
+Description: 读取磁盘健康数据
+
+- 温度优先读取 hwmon（drivetemp、nvme 驱动提供），无需特殊权限
+- NVMe 磁盘通过 NVME_IOCTL_ADMIN_CMD 读取 SMART/Health 日志页
+- SATA 磁盘通过 SG_IO 发送 ATA PASS-THROUGH 命令读取 SMART 数据和总体状态
+- 读取设备文件通常需要 root 权限
+*/
+
+package general
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// devPath 设备文件目录
+var devPath = "/dev"
+
+// SMART 总体状态
+const (
+	smartPassed = "PASSED" // 正常
+	smartFailed = "FAILED" // 已超过厂商设定的故障阈值
+)
+
+// errHealthUnsupported 磁盘类型不支持读取健康数据
+var errHealthUnsupported = errors.New("health data is not supported for this disk")
+
+// diskHealth 磁盘健康数据，无法读取的项为 nil
+type diskHealth struct {
+	Status             string  // SMART 总体状态
+	Temperature        *int64  // 温度，单位为摄氏度
+	PowerOnHours       *uint64 // 通电时间，单位为小时
+	ReallocatedSectors *uint64 // 重映射扇区数，仅 ATA
+	PercentageUsed     *uint64 // 寿命已用百分比，仅 NVMe
+	MediaErrors        *uint64 // 介质错误数，仅 NVMe
+}
+
+// readDiskHealth 读取磁盘健康数据
+//
+// 参数：
+//   - name: 磁盘的内核设备名，例如 'sda'、'nvme0n1'
+//
+// 返回：
+//   - 健康数据，读取设备失败时仍包含 hwmon 提供的温度
+//   - 错误信息，无权读取设备时可用 errors.Is(err, os.ErrPermission) 判断
+func readDiskHealth(name string) (diskHealth, error) {
+	var (
+		health diskHealth
+		err    error
+	)
+
+	switch {
+	case strings.HasPrefix(name, "nvme"):
+		health, err = readNVMeHealth(filepath.Join(devPath, name))
+	case strings.HasPrefix(name, "sd"):
+		health, err = readATAHealth(filepath.Join(devPath, name))
+	default:
+		err = errHealthUnsupported
+	}
+
+	if temperature, ok := readHwmonTemperature(name); ok {
+		health.Temperature = &temperature
+	}
+
+	return health, err
+}
+
+// readHwmonTemperature 从 hwmon 读取磁盘温度
+//
+// 参数：
+//   - name: 磁盘的内核设备名
+//
+// 返回：
+//   - 温度，单位为摄氏度
+//   - 读取成功返回 true，否则返回 false
+func readHwmonTemperature(name string) (int64, bool) {
+	// SATA 磁盘（drivetemp）位于 device/hwmon/hwmonN，NVMe 磁盘位于控制器的 hwmonN
+	patterns := []string{
+		filepath.Join(sysClassBlockPath, name, "device", "hwmon", "hwmon*", "temp1_input"),
+		filepath.Join(sysClassBlockPath, name, "device", "hwmon*", "temp1_input"),
+	}
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(pattern)
+		for _, match := range matches {
+			data, err := os.ReadFile(match)
+			if err != nil {
+				continue
+			}
+			milliCelsius, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+			if err != nil {
+				continue
+			}
+			return milliCelsius / 1000, true
+		}
+	}
+	return 0, false
+}
+
+// NVMe 管理命令
+const (
+	nvmeIoctlAdminCmd = 0xC0484E41 // _IOWR('N', 0x41, struct nvme_passthru_cmd)
+	nvmeGetLogPage    = 0x02       // Get Log Page 命令
+	nvmeLogSMART      = 0x02       // SMART/Health 日志页
+	nvmeLogSMARTSize  = 512        // SMART/Health 日志页大小
+)
+
+// nvmePassthruCmd 对应内核的 struct nvme_passthru_cmd
+//
+//   - Addr 在内核中为 64 位整数，使用指针类型以便栈扩容移动缓冲区时随之更新，32 位架构上由 AddrPad 补齐
+type nvmePassthruCmd struct {
+	Opcode      uint8
+	Flags       uint8
+	Rsvd1       uint16
+	NSID        uint32
+	Cdw2        uint32
+	Cdw3        uint32
+	Metadata    uint64
+	Addr        unsafe.Pointer
+	AddrPad     [8 - unsafe.Sizeof(uintptr(0))]byte
+	MetadataLen uint32
+	DataLen     uint32
+	Cdw10       uint32
+	Cdw11       uint32
+	Cdw12       uint32
+	Cdw13       uint32
+	Cdw14       uint32
+	Cdw15       uint32
+	TimeoutMs   uint32
+	Result      uint32
+}
+
+// readNVMeHealth 读取 NVMe 磁盘的 SMART/Health 日志页
+//
+// 参数：
+//   - device: 设备文件路径
+//
+// 返回：
+//   - 健康数据
+//   - 错误信息
+func readNVMeHealth(device string) (diskHealth, error) {
+	file, err := os.OpenFile(device, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return diskHealth{}, err
+	}
+	defer file.Close()
+
+	buffer := make([]byte, nvmeLogSMARTSize)
+	command := nvmePassthruCmd{
+		Opcode:  nvmeGetLogPage,
+		NSID:    0xFFFFFFFF, // 控制器全局日志
+		Addr:    unsafe.Pointer(&buffer[0]),
+		DataLen: nvmeLogSMARTSize,
+		Cdw10:   (nvmeLogSMARTSize/4-1)<<16 | nvmeLogSMART, // 低 16 位为日志页 ID，高 16 位为双字数减一
+	}
+	if err := ioctl(file.Fd(), nvmeIoctlAdminCmd, unsafe.Pointer(&command)); err != nil {
+		return diskHealth{}, err
+	}
+	runtime.KeepAlive(buffer)
+
+	return parseNVMeSMARTLog(buffer), nil
+}
+
+// parseNVMeSMARTLog 解析 NVMe SMART/Health 日志页
+//
+// 参数：
+//   - log: 日志页数据
+//
+// 返回：
+//   - 健康数据，Critical Warning 为 0 时总体状态为正常
+func parseNVMeSMARTLog(log []byte) diskHealth {
+	if len(log) < nvmeLogSMARTSize {
+		return diskHealth{}
+	}
+
+	// 128 位计数器只取低 64 位
+	counter := func(offset int) *uint64 {
+		value := binary.LittleEndian.Uint64(log[offset : offset+8])
+		return &value
+	}
+
+	health := diskHealth{Status: smartPassed}
+	if log[0] != 0 {
+		health.Status = smartFailed
+	}
+	if kelvin := int64(binary.LittleEndian.Uint16(log[1:3])); kelvin > 0 {
+		celsius := kelvin - 273
+		health.Temperature = &celsius
+	}
+	percentageUsed := uint64(log[5])
+	health.PercentageUsed = &percentageUsed
+	health.PowerOnHours = counter(128)
+	health.MediaErrors = counter(160)
+
+	return health
+}
+
+// SCSI 通用接口和 ATA PASS-THROUGH 命令
+const (
+	sgIO              = 0x2285 // SG_IO
+	sgDxferNone       = -1     // 无数据传输
+	sgDxferFromDev    = -3     // 从设备读取数据
+	ataPassThrough16  = 0x85   // ATA PASS-THROUGH (16)
+	ataSMART          = 0xB0   // SMART 命令
+	ataSMARTReadData  = 0xD0   // SMART READ DATA 子命令
+	ataSMARTStatus    = 0xDA   // SMART RETURN STATUS 子命令
+	ataSMARTLBAMid    = 0x4F   // SMART 命令要求的 LBA Mid
+	ataSMARTLBAHigh   = 0xC2   // SMART 命令要求的 LBA High
+	ataSMARTFailedMid = 0xF4   // 超过阈值时返回的 LBA Mid
+	ataSMARTFailedHi  = 0x2C   // 超过阈值时返回的 LBA High
+	ataSMARTDataSize  = 512    // SMART 数据大小
+	sgTimeoutMs       = 3000   // 命令超时时间
+)
+
+// ATA SMART 属性 ID
+const (
+	ataAttrReallocatedSectors = 5   // 重映射扇区数
+	ataAttrPowerOnHours       = 9   // 通电时间
+	ataAttrAirflowTemperature = 190 // 气流温度
+	ataAttrTemperature        = 194 // 温度
+)
+
+// sgIOHdr 对应内核的 struct sg_io_hdr
+//
+//   - 缓冲区地址使用指针类型，以便栈扩容移动缓冲区时随之更新
+type sgIOHdr struct {
+	InterfaceID    int32
+	DxferDirection int32
+	CmdLen         uint8
+	MxSbLen        uint8
+	IovecCount     uint16
+	DxferLen       uint32
+	Dxferp         unsafe.Pointer
+	Cmdp           unsafe.Pointer
+	Sbp            unsafe.Pointer
+	Timeout        uint32
+	Flags          uint32
+	PackID         int32
+	UsrPtr         unsafe.Pointer
+	Status         uint8
+	MaskedStatus   uint8
+	MsgStatus      uint8
+	SbLenWr        uint8
+	HostStatus     uint16
+	DriverStatus   uint16
+	Resid          int32
+	Duration       uint32
+	Info           uint32
+}
+
+// readATAHealth 读取 SATA 磁盘的 SMART 数据和总体状态
+//
+// 参数：
+//   - device: 设备文件路径
+//
+// 返回：
+//   - 健康数据
+//   - 错误信息
+func readATAHealth(device string) (diskHealth, error) {
+	file, err := os.OpenFile(device, os.O_RDONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return diskHealth{}, err
+	}
+	defer file.Close()
+
+	// SMART READ DATA：PIO Data-In 协议，从设备读取 1 个扇区
+	data := make([]byte, ataSMARTDataSize)
+	readCommand := [16]byte{ataPassThrough16, 4 << 1, 0x0E, 0, ataSMARTReadData, 0, 1, 0, 0, 0, ataSMARTLBAMid, 0, ataSMARTLBAHigh, 0, ataSMART, 0}
+	if _, err := sendSGCommand(file.Fd(), readCommand, data); err != nil {
+		return diskHealth{}, err
+	}
+	health := parseATASMARTData(data)
+
+	// SMART RETURN STATUS：Non-data 协议，通过 CK_COND 在 sense 数据中返回寄存器
+	statusCommand := [16]byte{ataPassThrough16, 3 << 1, 0x20, 0, ataSMARTStatus, 0, 0, 0, 0, 0, ataSMARTLBAMid, 0, ataSMARTLBAHigh, 0, ataSMART, 0}
+	sense, err := sendSGCommand(file.Fd(), statusCommand, nil)
+	if err != nil {
+		return health, err
+	}
+	health.Status = parseATAStatusSense(sense)
+
+	return health, nil
+}
+
+// sendSGCommand 通过 SG_IO 发送 SCSI 命令
+//
+// 参数：
+//   - fd: 设备文件描述符
+//   - command: 命令描述块
+//   - data: 接收数据的缓冲区，为 nil 时不传输数据
+//
+// 返回：
+//   - sense 数据
+//   - 错误信息
+func sendSGCommand(fd uintptr, command [16]byte, data []byte) ([]byte, error) {
+	sense := make([]byte, 32)
+	header := sgIOHdr{
+		InterfaceID:    'S',
+		DxferDirection: sgDxferNone,
+		CmdLen:         uint8(len(command)),
+		MxSbLen:        uint8(len(sense)),
+		Cmdp:           unsafe.Pointer(&command[0]),
+		Sbp:            unsafe.Pointer(&sense[0]),
+		Timeout:        sgTimeoutMs,
+	}
+	if len(data) > 0 {
+		header.DxferDirection = sgDxferFromDev
+		header.DxferLen = uint32(len(data))
+		header.Dxferp = unsafe.Pointer(&data[0])
+	}
+
+	err := ioctl(fd, sgIO, unsafe.Pointer(&header))
+	runtime.KeepAlive(command)
+	runtime.KeepAlive(sense)
+	runtime.KeepAlive(data)
+	if err != nil {
+		return nil, err
+	}
+	if header.HostStatus != 0 || header.DriverStatus&0x0F != 0 && header.SbLenWr == 0 {
+		return nil, errHealthUnsupported
+	}
+
+	return sense[:header.SbLenWr], nil
+}
+
+// parseATASMARTData 解析 ATA SMART 数据中的属性表
+//
+// 参数：
+//   - data: SMART 数据
+//
+// 返回：
+//   - 健康数据，不含总体状态
+func parseATASMARTData(data []byte) diskHealth {
+	var health diskHealth
+	if len(data) < ataSMARTDataSize {
+		return health
+	}
+
+	// 属性表从偏移 2 开始，共 30 项，每项 12 字节：ID、标志（2）、当前值、最差值、原始值（6）、保留
+	for offset := 2; offset+12 <= 2+30*12; offset += 12 {
+		id := data[offset]
+		if id == 0 {
+			continue
+		}
+		raw := uint64(binary.LittleEndian.Uint32(data[offset+5:offset+9])) | uint64(binary.LittleEndian.Uint16(data[offset+9:offset+11]))<<32
+
+		switch id {
+		case ataAttrReallocatedSectors:
+			value := raw
+			health.ReallocatedSectors = &value
+		case ataAttrPowerOnHours:
+			value := raw & 0xFFFFFFFF // 部分厂商在高位记录分钟等数据
+			health.PowerOnHours = &value
+		case ataAttrTemperature, ataAttrAirflowTemperature:
+			if health.Temperature == nil || id == ataAttrTemperature {
+				value := int64(raw & 0xFF) // 高位记录最低、最高温度
+				health.Temperature = &value
+			}
+		}
+	}
+
+	return health
+}
+
+// parseATAStatusSense 从 SMART RETURN STATUS 命令的 sense 数据中解析总体状态
+//
+// 参数：
+//   - sense: 描述符格式的 sense 数据
+//
+// 返回：
+//   - 总体状态，无法解析时为空
+func parseATAStatusSense(sense []byte) string {
+	// 描述符格式（0x72）的 sense 数据，ATA Status Return 描述符（0x09）从偏移 8 开始
+	if len(sense) < 8+14 || sense[0]&0x7F != 0x72 || sense[8] != 0x09 {
+		return ""
+	}
+	lbaMid, lbaHigh := sense[8+9], sense[8+11]
+	switch {
+	case lbaMid == ataSMARTLBAMid && lbaHigh == ataSMARTLBAHigh:
+		return smartPassed
+	case lbaMid == ataSMARTFailedMid && lbaHigh == ataSMARTFailedHi:
+		return smartFailed
+	}
+	return ""
+}
+
+// ioctl 执行 ioctl 系统调用
+//
+// 参数：
+//   - fd: 文件描述符
+//   - request: 请求码
+//   - argument: 参数指针
+//
+// 返回：
+//   - 错误信息
+func ioctl(fd uintptr, request uintptr, argument unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(argument)); errno != 0 {
+		return errno
+	}
+	return nil
+}
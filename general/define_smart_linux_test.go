@@ -0,0 +1,221 @@
+//go:build linux
+
+/*
+File: define_smart_linux_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-10 14:06:27
+
+Description: 测试磁盘健康数据的解析和 ioctl 结构体布局
+*/
+
+package general
+
+import (
+	"encoding/binary"
+	"testing"
+	"unsafe"
+)
+
+// uint64Value 返回可比较的指针值，nil 记为 -1
+func uint64Value(value *uint64) int64 {
+	if value == nil {
+		return -1
+	}
+	return int64(*value)
+}
+
+// int64Value 返回可比较的指针值，nil 记为 -1
+func int64Value(value *int64) int64 {
+	if value == nil {
+		return -1
+	}
+	return *value
+}
+
+func TestIoctlStructLayout(t *testing.T) {
+	if size := unsafe.Sizeof(nvmePassthruCmd{}); size != 72 {
+		t.Errorf("sizeof(nvmePassthruCmd) = %d, want 72", size)
+	}
+	if offset := unsafe.Offsetof(nvmePassthruCmd{}.MetadataLen); offset != 32 {
+		t.Errorf("offsetof(nvmePassthruCmd.MetadataLen) = %d, want 32", offset)
+	}
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		if size := unsafe.Sizeof(sgIOHdr{}); size != 88 {
+			t.Errorf("sizeof(sgIOHdr) = %d, want 88", size)
+		}
+	}
+}
+
+func TestParseNVMeSMARTLog(t *testing.T) {
+	// newLog 构造 SMART/Health 日志页
+	newLog := func(criticalWarning byte, kelvin uint16, percentageUsed byte, powerOnHours, mediaErrors uint64) []byte {
+		log := make([]byte, nvmeLogSMARTSize)
+		log[0] = criticalWarning
+		binary.LittleEndian.PutUint16(log[1:3], kelvin)
+		log[5] = percentageUsed
+		binary.LittleEndian.PutUint64(log[128:136], powerOnHours)
+		binary.LittleEndian.PutUint64(log[136:144], 0xFFFF) // 高 64 位不参与计算
+		binary.LittleEndian.PutUint64(log[160:168], mediaErrors)
+		return log
+	}
+
+	tests := []struct {
+		name           string
+		log            []byte
+		status         string
+		temperature    int64
+		percentageUsed int64
+		powerOnHours   int64
+		mediaErrors    int64
+	}{
+		{"healthy", newLog(0, 310, 3, 12345, 0), smartPassed, 37, 3, 12345, 0},
+		{"critical warning", newLog(0x04, 330, 100, 40000, 7), smartFailed, 57, 100, 40000, 7},
+		{"no temperature", newLog(0, 0, 0, 1, 0), smartPassed, -1, 0, 1, 0},
+		{"short log", make([]byte, 100), "", -1, -1, -1, -1},
+		{"empty log", nil, "", -1, -1, -1, -1},
+	}
+	for _, tt := range tests {
+		health := parseNVMeSMARTLog(tt.log)
+		if health.Status != tt.status {
+			t.Errorf("%s: Status = %q, want %q", tt.name, health.Status, tt.status)
+		}
+		if got := int64Value(health.Temperature); got != tt.temperature {
+			t.Errorf("%s: Temperature = %d, want %d", tt.name, got, tt.temperature)
+		}
+		if got := uint64Value(health.PercentageUsed); got != tt.percentageUsed {
+			t.Errorf("%s: PercentageUsed = %d, want %d", tt.name, got, tt.percentageUsed)
+		}
+		if got := uint64Value(health.PowerOnHours); got != tt.powerOnHours {
+			t.Errorf("%s: PowerOnHours = %d, want %d", tt.name, got, tt.powerOnHours)
+		}
+		if got := uint64Value(health.MediaErrors); got != tt.mediaErrors {
+			t.Errorf("%s: MediaErrors = %d, want %d", tt.name, got, tt.mediaErrors)
+		}
+		if health.ReallocatedSectors != nil {
+			t.Errorf("%s: ReallocatedSectors should be nil for NVMe", tt.name)
+		}
+	}
+}
+
+func TestParseATASMARTData(t *testing.T) {
+	// ataAttribute 属性表中的一项
+	type ataAttribute struct {
+		id  byte
+		raw uint64
+	}
+	// newData 构造 SMART 数据，属性依次填入属性表
+	newData := func(attributes ...ataAttribute) []byte {
+		data := make([]byte, ataSMARTDataSize)
+		for index, attribute := range attributes {
+			offset := 2 + index*12
+			data[offset] = attribute.id
+			binary.LittleEndian.PutUint32(data[offset+5:offset+9], uint32(attribute.raw))
+			binary.LittleEndian.PutUint16(data[offset+9:offset+11], uint16(attribute.raw>>32))
+		}
+		return data
+	}
+
+	tests := []struct {
+		name               string
+		data               []byte
+		temperature        int64
+		powerOnHours       int64
+		reallocatedSectors int64
+	}{
+		{
+			name:               "common attributes",
+			data:               newData(ataAttribute{1, 0}, ataAttribute{5, 8}, ataAttribute{9, 21000}, ataAttribute{194, 35}),
+			temperature:        35,
+			powerOnHours:       21000,
+			reallocatedSectors: 8,
+		},
+		{
+			// 温度原始值的高位记录最低、最高温度，通电时间的高位记录分钟
+			name:               "packed raw values",
+			data:               newData(ataAttribute{9, 0x0025_0000_1234}, ataAttribute{194, 0x0014_0032_0028}),
+			temperature:        0x28,
+			powerOnHours:       0x1234,
+			reallocatedSectors: -1,
+		},
+		{
+			// 同时存在时以 194 为准，与出现顺序无关
+			name:               "temperature preferred over airflow",
+			data:               newData(ataAttribute{194, 41}, ataAttribute{190, 30}),
+			temperature:        41,
+			powerOnHours:       -1,
+			reallocatedSectors: -1,
+		},
+		{
+			name:               "airflow only",
+			data:               newData(ataAttribute{190, 30}),
+			temperature:        30,
+			powerOnHours:       -1,
+			reallocatedSectors: -1,
+		},
+		{
+			// 空项（ID 为 0）之后的属性仍然读取
+			name:               "gap in table",
+			data:               newData(ataAttribute{0, 0}, ataAttribute{5, 1}),
+			temperature:        -1,
+			powerOnHours:       -1,
+			reallocatedSectors: 1,
+		},
+		{
+			name:               "short data",
+			data:               make([]byte, 300),
+			temperature:        -1,
+			powerOnHours:       -1,
+			reallocatedSectors: -1,
+		},
+	}
+	for _, tt := range tests {
+		health := parseATASMARTData(tt.data)
+		if health.Status != "" {
+			t.Errorf("%s: Status = %q, want empty", tt.name, health.Status)
+		}
+		if got := int64Value(health.Temperature); got != tt.temperature {
+			t.Errorf("%s: Temperature = %d, want %d", tt.name, got, tt.temperature)
+		}
+		if got := uint64Value(health.PowerOnHours); got != tt.powerOnHours {
+			t.Errorf("%s: PowerOnHours = %d, want %d", tt.name, got, tt.powerOnHours)
+		}
+		if got := uint64Value(health.ReallocatedSectors); got != tt.reallocatedSectors {
+			t.Errorf("%s: ReallocatedSectors = %d, want %d", tt.name, got, tt.reallocatedSectors)
+		}
+	}
+}
+
+func TestParseATAStatusSense(t *testing.T) {
+	// newSense 构造带 ATA Status Return 描述符的描述符格式 sense 数据
+	newSense := func(responseCode, descriptor, lbaMid, lbaHigh byte) []byte {
+		sense := make([]byte, 8+14)
+		sense[0] = responseCode
+		sense[7] = 14
+		sense[8] = descriptor
+		sense[9] = 12
+		sense[8+9] = lbaMid
+		sense[8+11] = lbaHigh
+		return sense
+	}
+
+	tests := []struct {
+		name  string
+		sense []byte
+		want  string
+	}{
+		{"passed", newSense(0x72, 0x09, ataSMARTLBAMid, ataSMARTLBAHigh), smartPassed},
+		{"failed", newSense(0x72, 0x09, ataSMARTFailedMid, ataSMARTFailedHi), smartFailed},
+		{"response code with bit 7 set", newSense(0xF2, 0x09, ataSMARTLBAMid, ataSMARTLBAHigh), smartPassed},
+		{"unknown registers", newSense(0x72, 0x09, 0, 0), ""},
+		{"fixed format", newSense(0x70, 0x09, ataSMARTLBAMid, ataSMARTLBAHigh), ""},
+		{"other descriptor", newSense(0x72, 0x00, ataSMARTLBAMid, ataSMARTLBAHigh), ""},
+		{"short sense", newSense(0x72, 0x09, ataSMARTLBAMid, ataSMARTLBAHigh)[:16], ""},
+		{"empty sense", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := parseATAStatusSense(tt.sense); got != tt.want {
+			t.Errorf("%s: parseATAStatusSense = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
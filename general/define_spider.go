@@ -10,95 +10,587 @@ Description: 信息抓取器
 package general
 
 import (
+	"fmt"
+	"math"
 	"os/user"
-	"strings"
+	"path/filepath"
+	"sort"
+	"time"
 
-	"github.com/gookit/color"
-	"github.com/jaypipes/ghw"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
 	"github.com/shirou/gopsutil/v3/host"
 	"github.com/shirou/gopsutil/v3/load"
 	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/process"
 	"github.com/zcalusic/sysinfo"
 )
 
-var (
-	blockData, _ = ghw.Block()         // 存储设备信息
-	loadData, _  = load.Avg()          // 系统负载信息
-	memData, _   = mem.VirtualMemory() // 内存信息
-	hostData, _  = host.Info()         // 主机信息
-	userData, _  = user.Current()      // 用户信息
-)
+// LoadInfo 负载信息
+type LoadInfo struct {
+	Load1   float64 `json:"Load1"`   // 1分钟内的负载
+	Load5   float64 `json:"Load5"`   // 5分钟内的负载
+	Load15  float64 `json:"Load15"`  // 15分钟内的负载
+	Process uint64  `json:"Process"` // 进程数
+
+	TopProcesses []ProcessInfo `json:"TopProcesses"` // 进程排行
+
+	PressureCPU    *Pressure `json:"PressureCPU,omitempty"`    // CPU 压力，不支持 PSI 时为空
+	PressureMemory *Pressure `json:"PressureMemory,omitempty"` // 内存压力
+	PressureIO     *Pressure `json:"PressureIO,omitempty"`     // I/O 压力
+}
+
+// Pressure 资源压力（PSI）
+type Pressure struct {
+	Some PressureStall  `json:"Some"`           // 至少一个任务停顿
+	Full *PressureStall `json:"Full,omitempty"` // 所有非空闲任务同时停顿
+}
+
+// PressureStall 停顿时间占比，单位为 %
+type PressureStall struct {
+	Avg10  float64 `json:"Avg10"`  // 10 秒平均值
+	Avg60  float64 `json:"Avg60"`  // 60 秒平均值
+	Avg300 float64 `json:"Avg300"` // 300 秒平均值
+	Total  uint64  `json:"Total"`  // 累计停顿时间，单位为微秒
+}
+
+// ProcessInfo 进程信息
+type ProcessInfo struct {
+	PID        int32   `json:"PID"`        // 进程 ID
+	Name       string  `json:"Name"`       // 进程名
+	CPUPercent float64 `json:"CPUPercent"` // CPU 使用率，按单核计算，多线程进程可超过 100
+	RSS        uint64  `json:"RSS"`        // 常驻内存，单位为 Byte
+}
+
+// MemoryInfo 内存信息，容量单位为 Byte
+type MemoryInfo struct {
+	Total       uint64  `json:"MemoryTotal"`       // 内存总量
+	Used        uint64  `json:"MemoryUsed"`        // 已用内存
+	UsedPercent float64 `json:"MemoryUsedPercent"` // 内存使用率
+	Free        uint64  `json:"MemoryFree"`        // 空闲内存
+	Shared      uint64  `json:"MemoryShared"`      // 共享内存
+	BuffCache   uint64  `json:"MemoryBuffCache"`   // 缓存内存
+	Avail       uint64  `json:"MemoryAvail"`       // 可用内存
+
+	Slots          int            `json:"MemorySlots"`          // 内存插槽数
+	SlotsPopulated int            `json:"MemorySlotsPopulated"` // 已安装内存条的插槽数
+	ECC            string         `json:"MemoryECC"`            // 内存控制器支持的纠错类型
+	Modules        []MemoryModule `json:"MemoryModules"`        // 每个插槽的内存条
+
+	OOMKills      *uint64    `json:"MemoryOOMKills,omitempty"`      // 自启动以来 OOM killer 终止的进程数
+	LastOOMVictim *OOMVictim `json:"MemoryLastOOMVictim,omitempty"` // 最后一次被 OOM killer 终止的进程
+
+	CgroupLimit *uint64 `json:"MemoryCgroupLimit,omitempty"` // 当前进程所在 cgroup 的内存限制，不限制时为空
+	CgroupUsed  *uint64 `json:"MemoryCgroupUsed,omitempty"`  // 当前进程所在 cgroup 的内存用量，不在容器中且没有限制时为空
+}
+
+// OOMVictim 被 OOM killer 终止的进程
+type OOMVictim struct {
+	PID  int    `json:"PID"`  // 进程 ID
+	Name string `json:"Name"` // 进程名
+	Time int64  `json:"Time"` // 终止时间，Unix 时间戳，无法确定时为 0
+}
+
+// MemoryModule 内存插槽及其上的内存条，容量单位为 Byte
+type MemoryModule struct {
+	Locator      string `json:"Locator"`      // 插槽名，例如 'DIMM_A1'
+	BankLocator  string `json:"BankLocator"`  // 通道名，例如 'BANK 0'
+	Populated    bool   `json:"Populated"`    // 是否安装了内存条
+	Size         uint64 `json:"Size"`         // 容量
+	Type         string `json:"Type"`         // 内存类型，例如 'DDR4'、'DDR5'
+	Speed        int    `json:"Speed"`        // 运行速率，单位为 MT/s
+	Manufacturer string `json:"Manufacturer"` // 制造商
+	PartNumber   string `json:"PartNumber"`   // 料号
+	ECC          bool   `json:"ECC"`          // 是否带校验位
+}
+
+// memoryInventory 内存插槽和内存条信息
+type memoryInventory struct {
+	slots          int            // 插槽数，不少于内存条信息的数量
+	slotsPopulated int            // 已安装内存条的插槽数
+	ecc            string         // 纠错类型
+	modules        []MemoryModule // 每个插槽一个元素
+}
+
+// SwapInfo 交换分区信息，容量单位为 Byte
+type SwapInfo struct {
+	Status string `json:"SwapStatus"` // 交换分区状态，Available 或 Unavailable
+	Total  uint64 `json:"SwapTotal"`  // 交换分区总量
+	Free   uint64 `json:"SwapFree"`   // 交换分区空闲量
+}
+
+// BIOSInfo BIOS 信息
+type BIOSInfo struct {
+	Vendor  string `json:"BIOSVendor"`  // BIOS 厂商
+	Version string `json:"BIOSVersion"` // BIOS 版本
+	Date    string `json:"BIOSDate"`    // BIOS 日期
+}
+
+// BoardInfo 主板信息
+type BoardInfo struct {
+	Vendor  string `json:"BoardVendor"`  // 主板厂商
+	Name    string `json:"BoardName"`    // 主板名称
+	Version string `json:"BoardVersion"` // 主板版本
+}
+
+// CPUInfo CPU 信息
+type CPUInfo struct {
+	Model   string `json:"CPUModel"`   // CPU 型号
+	Number  uint   `json:"CPUNumber"`  // CPU 数量
+	Cores   uint   `json:"CPUCores"`   // CPU 核心数
+	Threads uint   `json:"CPUThreads"` // CPU 线程数
+	Cache   uint   `json:"CPUCache"`   // CPU 缓存，单位为 KiB
+
+	Governor        string             `json:"CPUGovernor"`        // 调频策略，各核心不同时以逗号分隔
+	Microcode       string             `json:"CPUMicrocode"`       // 微码版本
+	Virtualization  string             `json:"CPUVirtualization"`  // 硬件虚拟化：'VT-x' 或 'AMD-V'，不支持时为空
+	AVX             []string           `json:"CPUAVX"`             // 支持的 AVX 指令集，例如 'avx2'、'avx512f'
+	CacheL1d        uint64             `json:"CPUCacheL1d"`        // L1 数据缓存总容量，单位为 Byte
+	CacheL1i        uint64             `json:"CPUCacheL1i"`        // L1 指令缓存总容量，单位为 Byte
+	CacheL2         uint64             `json:"CPUCacheL2"`         // L2 缓存总容量，单位为 Byte
+	CacheL3         uint64             `json:"CPUCacheL3"`         // L3 缓存总容量，单位为 Byte
+	Vulnerabilities []CPUVulnerability `json:"CPUVulnerabilities"` // 漏洞及其缓解状态
+	CoreDetails     []CPUCore          `json:"CPUCoreDetails"`     // 每个逻辑处理器的详细信息
+
+	CPUUsage
+}
+
+// CPUUsage CPU 使用率，单位为 %，为两次采样之间的平均值
+type CPUUsage struct {
+	Total  float64        `json:"CPUUsage"`       // 总使用率，不含 iowait
+	User   float64        `json:"CPUUsageUser"`   // 用户态（含 nice）
+	System float64        `json:"CPUUsageSystem"` // 内核态（含中断）
+	Iowait float64        `json:"CPUUsageIowait"` // 等待 I/O
+	Steal  float64        `json:"CPUUsageSteal"`  // 被虚拟化宿主机占用
+	PerCPU []CPUCoreUsage `json:"CPUCoreUsage"`   // 每个逻辑处理器的使用率
+
+	CgroupQuota *float64 `json:"CPUCgroupQuota,omitempty"` // 当前进程所在 cgroup 的 CPU 配额，单位为 CPU 数，不限制时为空
+	CgroupUsage *float64 `json:"CPUCgroupUsage,omitempty"` // 当前进程所在 cgroup 的使用率，相对于配额，没有配额时相对于全部 CPU
+}
+
+// CPUCoreUsage 逻辑处理器使用率，单位为 %
+type CPUCoreUsage struct {
+	CPU    string  `json:"CPU"`    // 逻辑处理器名，例如 'cpu0'
+	Total  float64 `json:"Usage"`  // 总使用率，不含 iowait
+	User   float64 `json:"User"`   // 用户态（含 nice）
+	System float64 `json:"System"` // 内核态（含中断）
+	Iowait float64 `json:"Iowait"` // 等待 I/O
+	Steal  float64 `json:"Steal"`  // 被虚拟化宿主机占用
+}
+
+// CPUCore 逻辑处理器信息，频率单位为 MHz，无法读取时为 0
+type CPUCore struct {
+	CPU              int     `json:"CPU"`              // 逻辑处理器编号
+	CoreID           int     `json:"CoreID"`           // 物理核心编号，无法读取时为 -1
+	SocketID         int     `json:"SocketID"`         // 物理插槽编号，无法读取时为 -1
+	CurrentFrequency float64 `json:"CurrentFrequency"` // 当前频率
+	MinFrequency     float64 `json:"MinFrequency"`     // 最低频率
+	MaxFrequency     float64 `json:"MaxFrequency"`     // 最高频率
+	Governor         string  `json:"Governor"`         // 调频策略
+}
+
+// CPUVulnerability 处理器漏洞
+type CPUVulnerability struct {
+	Name   string `json:"Name"`   // 漏洞名，例如 'spectre_v2'
+	Status string `json:"Status"` // 缓解状态，例如 'Not affected'、'Mitigation: ...'、'Vulnerable'
+}
+
+// ProductInfo 产品信息
+type ProductInfo struct {
+	Vendor string `json:"ProductVendor"` // 产品厂商
+	Name   string `json:"ProductName"`   // 产品名称
+}
+
+// StorageInfo 存储设备信息
+type StorageInfo struct {
+	Name      string `json:"StorageName"`      // 设备名称
+	Driver    string `json:"StorageDriver"`    // 设备驱动
+	Vendor    string `json:"StorageVendor"`    // 设备厂商
+	Model     string `json:"StorageModel"`     // 设备型号
+	Type      string `json:"StorageType"`      // 设备类型
+	Removable bool   `json:"StorageRemovable"` // 是否可移除
+	Serial    string `json:"StorageSerial"`    // 设备序列号
+	Size      uint64 `json:"StorageSize"`      // 设备容量，单位为 Byte
+
+	FSType     string        `json:"StorageFSType"`     // 整盘格式化时的文件系统类型
+	MountPoint string        `json:"StorageMountPoint"` // 整盘格式化时的挂载点
+	Partitions []BlockDevice `json:"StoragePartitions"` // 分区
+	Holders    []BlockDevice `json:"StorageHolders"`    // 直接堆叠在整盘上的设备（如整盘作为 LVM 物理卷或 mdraid 成员）
+
+	Health             string  `json:"StorageHealth"`                       // SMART 总体状态：'PASSED' 或 'FAILED'，无法读取时为空
+	Temperature        *int64  `json:"StorageTemperature,omitempty"`        // 温度，单位为摄氏度
+	PowerOnHours       *uint64 `json:"StoragePowerOnHours,omitempty"`       // 通电时间，单位为小时
+	ReallocatedSectors *uint64 `json:"StorageReallocatedSectors,omitempty"` // 重映射扇区数，仅 ATA 磁盘
+	PercentageUsed     *uint64 `json:"StoragePercentageUsed,omitempty"`     // 寿命已用百分比，仅 NVMe 磁盘
+	MediaErrors        *uint64 `json:"StorageMediaErrors,omitempty"`        // 介质错误数，仅 NVMe 磁盘
+}
+
+// BlockDevice 块设备，即磁盘的分区或堆叠在分区、磁盘上的设备（LVM、dm-crypt、mdraid）
+type BlockDevice struct {
+	Name       string        `json:"Name"`       // 设备名，device-mapper 设备为其映射名
+	Type       string        `json:"Type"`       // 设备类型：'part'、'crypt'、'lvm'、'raid1' 等
+	Label      string        `json:"Label"`      // 文件系统卷标
+	UUID       string        `json:"UUID"`       // 文件系统 UUID，分区没有时为分区 UUID
+	FSType     string        `json:"FSType"`     // 文件系统类型
+	Size       uint64        `json:"Size"`       // 容量，单位为 Byte
+	MountPoint string        `json:"MountPoint"` // 挂载点
+	Slaves     []string      `json:"Slaves"`     // 下层设备，只有堆叠设备有
+	Holders    []BlockDevice `json:"Holders"`    // 堆叠在该设备上的设备
+}
+
+// FilesystemInfo 已挂载文件系统信息
+type FilesystemInfo struct {
+	MountPoint       string  `json:"FilesystemMountPoint"`       // 挂载点
+	Device           string  `json:"FilesystemDevice"`           // 设备
+	Type             string  `json:"FilesystemType"`             // 文件系统类型
+	Size             uint64  `json:"FilesystemSize"`             // 容量，单位为 Byte
+	Used             uint64  `json:"FilesystemUsed"`             // 已用容量，单位为 Byte
+	Avail            uint64  `json:"FilesystemAvail"`            // 可用容量，单位为 Byte
+	UsedPercent      float64 `json:"FilesystemUsedPercent"`      // 使用率，与 df 一致按已用/(已用+可用)计算
+	InodesTotal      uint64  `json:"FilesystemInodesTotal"`      // inode 总数，不支持 inode 的文件系统为 0
+	InodesUsed       uint64  `json:"FilesystemInodesUsed"`       // 已用 inode 数
+	InodeUsedPercent float64 `json:"FilesystemInodeUsedPercent"` // inode 使用率
+}
+
+// OSInfo 系统信息
+type OSInfo struct {
+	OS            string `json:"OS"`            // 操作系统
+	Arch          string `json:"Arch"`          // 系统架构
+	CurrentKernel string `json:"CurrentKernel"` // 当前内核版本
+	LatestKernel  string `json:"LatestKernel"`  // 本地最新内核版本
+	Platform      string `json:"Platform"`      // 平台
+	Hostname      string `json:"Hostname"`      // 主机名
+	TimeZone      string `json:"TimeZone"`      // 时区
+}
+
+// TimeInfo 时间信息
+type TimeInfo struct {
+	BootTime uint64 `json:"BootTime"` // 系统启动时间，Unix 时间戳
+	Uptime   uint64 `json:"Uptime"`   // 系统运行时间，单位为秒
+	BootPerformance
+}
+
+// BootPerformance 系统启动用时，来自 systemd-analyze
+type BootPerformance struct {
+	StartTime     string     `json:"StartTime"`               // 系统启动用时，systemd 尚未完成启动时为 'not finished'，不使用 systemd 时为 'unavailable'
+	Firmware      *float64   `json:"BootFirmware,omitempty"`  // 固件阶段用时，单位为秒，没有该阶段时为空
+	Loader        *float64   `json:"BootLoader,omitempty"`    // 引导加载程序阶段用时
+	Kernel        *float64   `json:"BootKernel,omitempty"`    // 内核阶段用时
+	Initrd        *float64   `json:"BootInitrd,omitempty"`    // initrd 阶段用时
+	Userspace     *float64   `json:"BootUserspace,omitempty"` // 用户空间阶段用时
+	Blame         []BootUnit `json:"BootBlame"`               // 启动用时最长的单元，按用时降序排列
+	CriticalChain []BootUnit `json:"BootCriticalChain"`       // 关键链，从默认目标到最先启动的单元
+}
+
+// BootUnit 单元的启动用时
+type BootUnit struct {
+	Unit      string   `json:"Unit"`                // 单元名
+	Activated *float64 `json:"Activated,omitempty"` // 单元进入活动状态的时间，相对于用户空间开始，单位为秒，仅关键链有该值
+	Time      *float64 `json:"Time,omitempty"`      // 单元启动用时，单位为秒，未记录时为空
+}
+
+// CurrentUserInfo 当前用户信息
+type CurrentUserInfo struct {
+	User     string `json:"User"`        // 用户名称
+	UserName string `json:"UserName"`    // 用户昵称
+	Uid      string `json:"UserUid"`     // 用户 ID
+	Gid      string `json:"UserGid"`     // 用户组 ID
+	HomeDir  string `json:"UserHomeDir"` // 用户主目录
+}
 
 // GetLoadInfo 获取负载信息
 //
 // 返回：
 //   - 系统负载信息
-func GetLoadInfo() map[string]any {
-	loadInfo := make(map[string]any)
-	loadInfo["Load1"] = loadData.Load1   // 1分钟内的负载
-	loadInfo["Load5"] = loadData.Load5   // 5分钟内的负载
-	loadInfo["Load15"] = loadData.Load15 // 15分钟内的负载
-	loadInfo["Process"] = hostData.Procs // 进程数
+//   - 错误信息
+func GetLoadInfo() (LoadInfo, error) {
+	var loadInfo LoadInfo
+
+	loadData, err := load.Avg()
+	if err != nil {
+		return loadInfo, err
+	}
+	hostData, err := host.Info()
+	if err != nil {
+		return loadInfo, err
+	}
+
+	loadInfo.Load1 = loadData.Load1
+	loadInfo.Load5 = loadData.Load5
+	loadInfo.Load15 = loadData.Load15
+	loadInfo.Process = hostData.Procs
+
+	loadInfo.PressureCPU = readPressure("cpu")
+	loadInfo.PressureMemory = readPressure("memory")
+	loadInfo.PressureIO = readPressure("io")
 
-	return loadInfo
+	return loadInfo, nil
 }
 
-// GetMemoryInfo 获取内存信息
+// pseudoFilesystemTypes 没有存储设备的伪文件系统类型
+var pseudoFilesystemTypes = map[string]bool{
+	"autofs":      true,
+	"binfmt_misc": true,
+	"bpf":         true,
+	"cgroup":      true,
+	"cgroup2":     true,
+	"configfs":    true,
+	"debugfs":     true,
+	"devfs":       true,
+	"devpts":      true,
+	"devtmpfs":    true,
+	"efivarfs":    true,
+	"fusectl":     true,
+	"hugetlbfs":   true,
+	"mqueue":      true,
+	"nsfs":        true,
+	"nullfs":      true,
+	"proc":        true,
+	"pstore":      true,
+	"ramfs":       true,
+	"rpc_pipefs":  true,
+	"securityfs":  true,
+	"sysfs":       true,
+	"tmpfs":       true,
+	"tracefs":     true,
+}
+
+// GetFilesystemInfo 获取已挂载文件系统信息
 //
 // 参数：
-//   - dataUnit: 存储数据单位
-//   - percentUnit: 百分比数据单位
+//   - showPseudo: 是否显示伪文件系统（如 proc、sysfs、tmpfs）
+//   - include: 包含模式，非空时只显示匹配的文件系统，匹配的伪文件系统也会显示
+//   - exclude: 排除模式，不显示匹配的文件系统，优先于包含模式
 //
 // 返回：
-//   - 内存信息
-func GetMemoryInfo(dataUnit string, percentUnit string) map[string]any {
-	// 内存数据
-	memTotal, memTotalUnit := Human(float64(memData.Total), "B")
-	memUsed, memUsedUnit := Human(float64(memData.Used), "B")
-	memUsedPercent, _ := Human(float64(memData.UsedPercent), percentUnit)
-	memFree, memFreeUnit := Human(float64(memData.Free), "B")
-	memShared, memSharedUnit := Human(float64(memData.Shared), "B")
-	memBuffCache, memBuffCacheUnit := Human(float64(memData.Buffers+memData.Cached), "B")
-	memAvail, memAvailUnit := Human(float64(memData.Available), "B")
-
-	formatString := "%.1f %s"
-
-	memoryInfo := make(map[string]any)
-	memoryInfo["MemoryTotal"] = color.Sprintf(formatString, memTotal, memTotalUnit)             // 内存总量
-	memoryInfo["MemoryUsed"] = color.Sprintf(formatString, memUsed, memUsedUnit)                // 已用内存
-	memoryInfo["MemoryUsedPercent"] = color.Sprintf("%.1f%s", memUsedPercent, percentUnit)      // 内存使用率
-	memoryInfo["MemoryFree"] = color.Sprintf(formatString, memFree, memFreeUnit)                // 空闲内存
-	memoryInfo["MemoryShared"] = color.Sprintf(formatString, memShared, memSharedUnit)          // 共享内存
-	memoryInfo["MemoryBuffCache"] = color.Sprintf(formatString, memBuffCache, memBuffCacheUnit) // 缓存内存
-	memoryInfo["MemoryAvail"] = color.Sprintf(formatString, memAvail, memAvailUnit)             // 可用内存
-
-	return memoryInfo
+//   - 已挂载文件系统信息，按挂载顺序排列
+//   - 错误信息
+func GetFilesystemInfo(showPseudo bool, include, exclude []string) ([]FilesystemInfo, error) {
+	partitions, err := disk.Partitions(true)
+	if err != nil {
+		return nil, err
+	}
+
+	var filesystems []FilesystemInfo
+	for _, partition := range partitions {
+		if !selectFilesystem(partition.Mountpoint, partition.Device, partition.Fstype, showPseudo, include, exclude) {
+			continue
+		}
+
+		filesystem := FilesystemInfo{
+			MountPoint: partition.Mountpoint,
+			Device:     partition.Device,
+			Type:       partition.Fstype,
+		}
+		// 无权读取的文件系统只显示挂载信息
+		if usage, err := disk.Usage(partition.Mountpoint); err == nil {
+			filesystem.Size = usage.Total
+			filesystem.Used = usage.Used
+			filesystem.Avail = usage.Free
+			filesystem.UsedPercent = usage.UsedPercent
+			filesystem.InodesTotal = usage.InodesTotal
+			filesystem.InodesUsed = usage.InodesUsed
+			filesystem.InodeUsedPercent = usage.InodesUsedPercent
+		}
+		filesystems = append(filesystems, filesystem)
+	}
+
+	return filesystems, nil
 }
 
-// GetSwapInfo 获取交换分区信息
+// selectFilesystem 判断是否显示文件系统
+//
+//   - 模式为 filepath.Match 格式，分别与挂载点、设备和文件系统类型匹配，任一匹配即视为匹配，例如 '/snap/*'、'/dev/loop*'、'nfs4'
 //
 // 参数：
-//   - dataUnit: 存储数据单位
+//   - mountPoint: 挂载点
+//   - device: 设备
+//   - fstype: 文件系统类型
+//   - showPseudo: 是否显示伪文件系统
+//   - include: 包含模式
+//   - exclude: 排除模式
+//
+// 返回：
+//   - 显示返回 true，否则返回 false
+func selectFilesystem(mountPoint, device, fstype string, showPseudo bool, include, exclude []string) bool {
+	matchAny := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			for _, name := range []string{mountPoint, device, fstype} {
+				if matched, _ := filepath.Match(pattern, name); matched {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if matchAny(exclude) {
+		return false
+	}
+	if len(include) > 0 {
+		return matchAny(include)
+	}
+	return showPseudo || !pseudoFilesystemTypes[fstype]
+}
+
+// processSampleInterval 首次抓取进程排行时两次采样的间隔
+const processSampleInterval = 500 * time.Millisecond
+
+// 上一次进程采样的数据，用于计算两次采样之间的 CPU 使用率
+var (
+	processCPUTimes   map[int32]float64 // 各进程的 CPU 时间，单位为秒
+	processSampleTime time.Time         // 采样时间
+)
+
+// GetTopProcesses 获取资源占用最高的进程
+//
+//   - CPU 使用率为两次采样之间的平均值，首次调用时采样两次，之后与上一次调用的采样比较，因此 watch 模式中为刷新间隔内的平均值
+//
+// 参数：
+//   - count: 进程数
+//   - sortKey: 排序依据，'cpu' 按 CPU 使用率排序，'rss' 按常驻内存排序
+//
+// 返回：
+//   - 按排序依据降序排列的进程
+//   - 错误信息
+func GetTopProcesses(count int, sortKey string) ([]ProcessInfo, error) {
+	if sortKey != "cpu" && sortKey != "rss" {
+		return nil, fmt.Errorf("Unsupported process sort key '%s', expected 'cpu' or 'rss'", sortKey)
+	}
+	if count <= 0 {
+		return nil, nil
+	}
+
+	if processSampleTime.IsZero() {
+		if _, err := sampleProcesses(); err != nil {
+			return nil, err
+		}
+		time.Sleep(processSampleInterval)
+	}
+	processes, err := sampleProcesses()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(processes, func(i, j int) bool {
+		if sortKey == "rss" {
+			return processes[i].RSS > processes[j].RSS
+		}
+		return processes[i].CPUPercent > processes[j].CPUPercent
+	})
+	if len(processes) > count {
+		processes = processes[:count]
+	}
+
+	return processes, nil
+}
+
+// sampleProcesses 采样所有进程，计算自上一次采样以来的 CPU 使用率
+//
+//   - 采样期间退出或无权读取的进程被忽略，上一次采样之后启动的进程 CPU 使用率为 0
+//
+// 返回：
+//   - 所有进程
+//   - 错误信息
+func sampleProcesses() ([]ProcessInfo, error) {
+	processList, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(processSampleTime).Seconds()
+	cpuTimes := make(map[int32]float64, len(processList))
+	processes := make([]ProcessInfo, 0, len(processList))
+	for _, proc := range processList {
+		times, err := proc.Times()
+		if err != nil {
+			continue
+		}
+		memoryInfo, err := proc.MemoryInfo()
+		if err != nil {
+			continue
+		}
+		name, _ := proc.Name()
+
+		cpuTime := times.User + times.System
+		cpuTimes[proc.Pid] = cpuTime
+		cpuPercent := 0.0
+		if lastCPUTime, ok := processCPUTimes[proc.Pid]; ok && elapsed > 0 && cpuTime >= lastCPUTime {
+			cpuPercent = (cpuTime - lastCPUTime) / elapsed * 100
+		}
+
+		processes = append(processes, ProcessInfo{
+			PID:        proc.Pid,
+			Name:       name,
+			CPUPercent: cpuPercent,
+			RSS:        memoryInfo.RSS,
+		})
+	}
+	processCPUTimes = cpuTimes
+	processSampleTime = now
+
+	return processes, nil
+}
+
+// GetMemoryInfo 获取内存信息
+//
+// 返回：
+//   - 内存信息
+//   - 错误信息
+func GetMemoryInfo() (MemoryInfo, error) {
+	var memoryInfo MemoryInfo
+
+	memData, err := mem.VirtualMemory()
+	if err != nil {
+		return memoryInfo, err
+	}
+
+	memoryInfo.Total = memData.Total
+	memoryInfo.Used = memData.Used
+	memoryInfo.UsedPercent = memData.UsedPercent
+	memoryInfo.Free = memData.Free
+	memoryInfo.Shared = memData.Shared
+	memoryInfo.BuffCache = memData.Buffers + memData.Cached
+	memoryInfo.Avail = memData.Available
+
+	// 内存插槽信息不影响内存用量的输出
+	inventory, _ := readMemoryModules()
+	memoryInfo.Slots = inventory.slots
+	memoryInfo.SlotsPopulated = inventory.slotsPopulated
+	memoryInfo.ECC = inventory.ecc
+	memoryInfo.Modules = inventory.modules
+
+	memoryInfo.OOMKills = readOOMKills()
+	memoryInfo.LastOOMVictim = readLastOOMVictim()
+	memoryInfo.CgroupLimit, memoryInfo.CgroupUsed = readCgroupMemory()
+
+	return memoryInfo, nil
+}
+
+// GetSwapInfo 获取交换分区信息
 //
 // 返回：
 //   - 交换分区信息
-func GetSwapInfo(dataUnit string) map[string]any {
-	swapTotal, swapTotalUnit := Human(float64(memData.SwapTotal), "B")
-	swapFree, swapFreeUnit := Human(float64(memData.SwapFree), "B")
+//   - 错误信息
+func GetSwapInfo() (SwapInfo, error) {
+	var swapInfo SwapInfo
 
-	formatString := "%.1f %s"
+	memData, err := mem.VirtualMemory()
+	if err != nil {
+		return swapInfo, err
+	}
 
-	swapInfo := make(map[string]any)
-	swapInfo["SwapStatus"] = func() string {
-		if swapTotal == 0 {
+	swapInfo.Total = memData.SwapTotal
+	swapInfo.Free = memData.SwapFree
+	swapInfo.Status = func() string {
+		if swapInfo.Total == 0 {
 			return "Unavailable"
 		}
 		return "Available"
 	}()
-	swapInfo["SwapTotal"] = color.Sprintf(formatString, swapTotal, swapTotalUnit) // 交换分区总量
-	swapInfo["SwapFree"] = color.Sprintf(formatString, swapFree, swapFreeUnit)    // 交换分区空闲量
 
-	return swapInfo
+	return swapInfo, nil
 }
 
 // GetBIOSInfo 获取 BIOS 信息
@@ -108,13 +600,12 @@ func GetSwapInfo(dataUnit string) map[string]any {
 //
 // 返回：
 //   - BIOS 信息
-func GetBIOSInfo(sysInfo sysinfo.SysInfo) map[string]any {
-	biosInfo := make(map[string]any)
-	biosInfo["BIOSVendor"] = sysInfo.BIOS.Vendor   // BIOS 厂商
-	biosInfo["BIOSVersion"] = sysInfo.BIOS.Version // BIOS 版本
-	biosInfo["BIOSDate"] = sysInfo.BIOS.Date       // BIOS 日期
-
-	return biosInfo
+func GetBIOSInfo(sysInfo sysinfo.SysInfo) BIOSInfo {
+	return BIOSInfo{
+		Vendor:  sysInfo.BIOS.Vendor,
+		Version: sysInfo.BIOS.Version,
+		Date:    sysInfo.BIOS.Date,
+	}
 }
 
 // GetBoardInfo 获取主板信息
@@ -124,35 +615,153 @@ func GetBIOSInfo(sysInfo sysinfo.SysInfo) map[string]any {
 //
 // 返回：
 //   - 主板信息
-func GetBoardInfo(sysInfo sysinfo.SysInfo) map[string]any {
-	boardInfo := make(map[string]any)
-	boardInfo["BoardVendor"] = sysInfo.Board.Vendor   // 主板厂商
-	boardInfo["BoardName"] = sysInfo.Board.Name       // 主板名称
-	boardInfo["BoardVersion"] = sysInfo.Board.Version // 主板版本
-
-	return boardInfo
+func GetBoardInfo(sysInfo sysinfo.SysInfo) BoardInfo {
+	return BoardInfo{
+		Vendor:  sysInfo.Board.Vendor,
+		Name:    sysInfo.Board.Name,
+		Version: sysInfo.Board.Version,
+	}
 }
 
 // GetCPUInfo 获取 CPU 信息
 //
 // 参数：
 //   - sysInfo: 总的系统信息
-//   - dataUnit: 存储数据单位
 //
 // 返回：
 //   - CPU 信息
-func GetCPUInfo(sysInfo sysinfo.SysInfo, dataUnit string) map[string]any {
-	cpuInfo := make(map[string]any)
-	cpuInfo["CPUModel"] = sysInfo.CPU.Model                            // CPU 型号
-	cpuInfo["CPUNumber"] = sysInfo.CPU.Cpus                            // CPU 数量
-	cpuInfo["CPUCores"] = sysInfo.CPU.Cores                            // CPU 核心数
-	cpuInfo["CPUThreads"] = sysInfo.CPU.Threads                        // CPU 线程数
-	cpuCache, cpuCacheUnit := Human(float64(sysInfo.CPU.Cache), "KiB") // CPU 缓存
-	cpuInfo["CPUCache"] = color.Sprintf("%.0f %s", cpuCache, cpuCacheUnit)
+func GetCPUInfo(sysInfo sysinfo.SysInfo) CPUInfo {
+	cpuInfo := CPUInfo{
+		Model:   sysInfo.CPU.Model,
+		Number:  sysInfo.CPU.Cpus,
+		Cores:   sysInfo.CPU.Cores,
+		Threads: sysInfo.CPU.Threads,
+		Cache:   sysInfo.CPU.Cache,
+	}
+	fillCPUDetails(&cpuInfo)
 
 	return cpuInfo
 }
 
+// 上一次 CPU 时间采样的数据，用于计算两次采样之间的使用率
+var (
+	lastCPUTimes      []cpu.TimesStat // 总的 CPU 时间
+	lastCPUTimesByCPU []cpu.TimesStat // 各逻辑处理器的 CPU 时间
+	lastCgroupQuota   *float64        // 当前进程所在 cgroup 的 CPU 配额
+	lastCgroupCPUTime *float64        // 当前进程所在 cgroup 的累计 CPU 时间，单位为秒
+	lastCPUSampleTime time.Time       // 采样时间
+)
+
+// GetCPUUsage 获取 CPU 使用率
+//
+//   - 使用率为两次采样之间的平均值，首次调用时间隔 window 采样两次，之后与上一次调用的采样比较，因此 watch 模式中为刷新间隔内的平均值
+//
+// 参数：
+//   - window: 首次调用时两次采样的间隔
+//
+// 返回：
+//   - CPU 使用率
+//   - 错误信息
+func GetCPUUsage(window time.Duration) (CPUUsage, error) {
+	var cpuUsage CPUUsage
+
+	if lastCPUTimes == nil {
+		if err := sampleCPUTimes(); err != nil {
+			return cpuUsage, err
+		}
+		time.Sleep(window)
+	}
+	previousTimes, previousTimesByCPU := lastCPUTimes, lastCPUTimesByCPU
+	previousCgroupCPUTime, previousSampleTime := lastCgroupCPUTime, lastCPUSampleTime
+	if err := sampleCPUTimes(); err != nil {
+		return cpuUsage, err
+	}
+
+	// cgroup 的 CPU 时间按墙上时间计算使用率，再换算到配额或全部 CPU
+	cpuUsage.CgroupQuota = lastCgroupQuota
+	if previousCgroupCPUTime != nil && lastCgroupCPUTime != nil {
+		elapsed := lastCPUSampleTime.Sub(previousSampleTime).Seconds()
+		cpus := float64(len(lastCPUTimesByCPU))
+		if cpuUsage.CgroupQuota != nil {
+			cpus = *cpuUsage.CgroupQuota
+		}
+		if elapsed > 0 && cpus > 0 {
+			usage := math.Max(0, math.Min(100, (*lastCgroupCPUTime-*previousCgroupCPUTime)/elapsed/cpus*100))
+			cpuUsage.CgroupUsage = &usage
+		}
+	}
+
+	if len(previousTimes) > 0 && len(lastCPUTimes) > 0 {
+		total := calculateCPUUsage(previousTimes[0], lastCPUTimes[0])
+		cpuUsage.Total, cpuUsage.User, cpuUsage.System, cpuUsage.Iowait, cpuUsage.Steal = total.Total, total.User, total.System, total.Iowait, total.Steal
+	}
+	previousByName := make(map[string]cpu.TimesStat, len(previousTimesByCPU))
+	for _, times := range previousTimesByCPU {
+		previousByName[times.CPU] = times
+	}
+	for _, times := range lastCPUTimesByCPU {
+		// 采样期间上线的逻辑处理器没有上一次的数据
+		if previous, ok := previousByName[times.CPU]; ok {
+			cpuUsage.PerCPU = append(cpuUsage.PerCPU, calculateCPUUsage(previous, times))
+		}
+	}
+
+	return cpuUsage, nil
+}
+
+// sampleCPUTimes 采样总的和各逻辑处理器的 CPU 时间，以及当前进程所在 cgroup 的 CPU 配额和 CPU 时间
+//
+// 返回：
+//   - 错误信息
+func sampleCPUTimes() error {
+	times, err := cpu.Times(false)
+	if err != nil {
+		return err
+	}
+	timesByCPU, err := cpu.Times(true)
+	if err != nil {
+		return err
+	}
+	lastCPUTimes, lastCPUTimesByCPU = times, timesByCPU
+	lastCgroupQuota, lastCgroupCPUTime = readCgroupCPU()
+	lastCPUSampleTime = time.Now()
+
+	return nil
+}
+
+// calculateCPUUsage 计算两次采样之间的使用率
+//
+//   - Linux 的 user 和 nice 已包含 guest 和 guest_nice，不再重复计算
+//
+// 参数：
+//   - previous: 上一次采样
+//   - current: 本次采样
+//
+// 返回：
+//   - 使用率，两次采样之间没有 CPU 时间变化时均为 0
+func calculateCPUUsage(previous, current cpu.TimesStat) CPUCoreUsage {
+	usage := CPUCoreUsage{CPU: current.CPU}
+
+	sum := func(times cpu.TimesStat) float64 {
+		return times.User + times.Nice + times.System + times.Irq + times.Softirq + times.Idle + times.Iowait + times.Steal
+	}
+	elapsed := sum(current) - sum(previous)
+	if elapsed <= 0 {
+		return usage
+	}
+	percent := func(delta float64) float64 {
+		return math.Max(0, math.Min(100, delta/elapsed*100))
+	}
+
+	usage.User = percent(current.User + current.Nice - previous.User - previous.Nice)
+	usage.System = percent(current.System + current.Irq + current.Softirq - previous.System - previous.Irq - previous.Softirq)
+	usage.Iowait = percent(current.Iowait - previous.Iowait)
+	usage.Steal = percent(current.Steal - previous.Steal)
+	usage.Total = percent(elapsed - (current.Idle - previous.Idle) - (current.Iowait - previous.Iowait))
+
+	return usage
+}
+
 // GetProductInfo 获取产品信息
 //
 // 参数：
@@ -160,31 +769,31 @@ func GetCPUInfo(sysInfo sysinfo.SysInfo, dataUnit string) map[string]any {
 //
 // 返回：
 //   - 产品信息
-func GetProductInfo(sysInfo sysinfo.SysInfo) map[string]any {
-	productInfo := make(map[string]any)
-	productInfo["ProductVendor"] = sysInfo.Product.Vendor // 产品厂商
-	productInfo["ProductName"] = sysInfo.Product.Name     // 产品名称
-
-	return productInfo
+func GetProductInfo(sysInfo sysinfo.SysInfo) ProductInfo {
+	return ProductInfo{
+		Vendor: sysInfo.Product.Vendor,
+		Name:   sysInfo.Product.Name,
+	}
 }
 
 // GetTimeInfo 获取时间信息
 //
+// 参数：
+//   - blameCount: 启动用时排行显示的单元数，为 0 时不抓取
+//
 // 返回：
 //   - 时间信息
 //   - 错误信息
-func GetTimeInfo() (map[string]any, error) {
-	timeInfo := make(map[string]any)
-	timeInfo["BootTime"] = UnixTime2TimeString(int64(hostData.BootTime)) // 系统启动时间
-	day, hour, minute, second := UnixTime2DayHourMinuteSecond(int64(hostData.Uptime))
-	result := color.Sprintf("%vd %vh %vm %vs", day, hour, minute, second)
-	timeInfo["Uptime"] = result // 系统运行时间
-	starttimeArgs := []string{"time"}
-	StartTime, _, err := RunCommandToBuffer("systemd-analyze", starttimeArgs)
+func GetTimeInfo(blameCount int) (TimeInfo, error) {
+	var timeInfo TimeInfo
+
+	hostData, err := host.Info()
 	if err != nil {
-		return nil, err
+		return timeInfo, err
 	}
-	timeInfo["StartTime"] = strings.Split(strings.Split(StartTime, "\n")[0], "= ")[1] // 系统启动用时
+	timeInfo.BootTime = hostData.BootTime
+	timeInfo.Uptime = hostData.Uptime
+	timeInfo.BootPerformance = GetBootPerformance(blameCount)
 
 	return timeInfo, nil
 }
@@ -193,13 +802,20 @@ func GetTimeInfo() (map[string]any, error) {
 //
 // 返回：
 //   - 用户信息
-func GetUserInfo() map[string]any {
-	userInfo := make(map[string]any)
-	userInfo["User"] = userData.Name           // 用户名称
-	userInfo["UserName"] = userData.Username   // 用户昵称
-	userInfo["UserUid"] = userData.Uid         // 用户 ID
-	userInfo["UserGid"] = userData.Gid         // 用户组 ID
-	userInfo["UserHomeDir"] = userData.HomeDir // 用户主目录
-
-	return userInfo
+//   - 错误信息
+func GetUserInfo() (CurrentUserInfo, error) {
+	var userInfo CurrentUserInfo
+
+	userData, err := user.Current()
+	if err != nil {
+		return userInfo, err
+	}
+
+	userInfo.User = userData.Name
+	userInfo.UserName = userData.Username
+	userInfo.Uid = userData.Uid
+	userInfo.Gid = userData.Gid
+	userInfo.HomeDir = userData.HomeDir
+
+	return userInfo, nil
 }
@@ -12,37 +12,56 @@ Description: 信息抓取器
 package general
 
 import (
-	"strconv"
-
 	"github.com/gookit/color"
+	"github.com/jaypipes/ghw"
+	"github.com/shirou/gopsutil/v3/host"
 	"github.com/zcalusic/sysinfo"
 )
 
 // GetStorageInfo 获取存储设备信息
 //
 // 返回：
-//   - 存储设备信息
-func GetStorageInfo() map[string]any {
-	storageInfo := make(map[string]any)
-	index := 1 // 排除编号为0的虚拟设备
+//   - 存储设备信息，已排除虚拟设备
+//   - 错误信息
+func GetStorageInfo() ([]StorageInfo, error) {
+	blockData, err := ghw.Block()
+	if err != nil {
+		return nil, err
+	}
+
+	var storageInfo []StorageInfo
 	for _, disk := range blockData.Disks {
-		storageValue := make(map[string]any)
 		if disk.SizeBytes > 0 && disk.DriveType.String() != "virtual" {
-			storageValue["StorageName"] = disk.Name
-			storageValue["StorageDriver"] = disk.StorageController.String()
-			storageValue["StorageVendor"] = disk.Vendor
-			storageValue["StorageModel"] = disk.Model
-			storageValue["StorageType"] = disk.DriveType.String()
-			storageValue["StorageRemovable"] = strconv.FormatBool(disk.IsRemovable)
-			storageValue["StorageSerial"] = disk.SerialNumber
-			storageSize, storageSizeUnit := Human(float64(disk.SizeBytes), "B")
-			storageValue["StorageSize"] = color.Sprintf("%.1f %s", storageSize, storageSizeUnit)
-			storageInfo[color.Sprintf("%d", index)] = storageValue
-			index += 1
+			storageInfo = append(storageInfo, StorageInfo{
+				Name:      disk.Name,
+				Driver:    disk.StorageController.String(),
+				Vendor:    disk.Vendor,
+				Model:     disk.Model,
+				Type:      disk.DriveType.String(),
+				Removable: disk.IsRemovable,
+				Serial:    disk.SerialNumber,
+				Size:      disk.SizeBytes,
+
+				Partitions: func() []BlockDevice {
+					var partitions []BlockDevice
+					for _, partition := range disk.Partitions {
+						partitions = append(partitions, BlockDevice{
+							Name:       partition.Name,
+							Type:       "part",
+							Label:      partition.Label,
+							UUID:       partition.UUID,
+							FSType:     partition.Type,
+							Size:       partition.SizeBytes,
+							MountPoint: partition.MountPoint,
+						})
+					}
+					return partitions
+				}(),
+			})
 		}
 	}
 
-	return storageInfo
+	return storageInfo, nil
 }
 
 // GetOSInfo 获取系统信息
@@ -52,19 +71,25 @@ func GetStorageInfo() map[string]any {
 //
 // 返回：
 //   - 系统信息 (OS Info)
-func GetOSInfo(sysInfo sysinfo.SysInfo) map[string]any {
-	osInfo := make(map[string]any)
+//   - 错误信息
+func GetOSInfo(sysInfo sysinfo.SysInfo) (OSInfo, error) {
+	var osInfo OSInfo
+
+	hostData, err := host.Info()
+	if err != nil {
+		return osInfo, err
+	}
 
 	// 需要额外步骤获取的信息
 	osCode := FindOSCode(hostData.PlatformVersion) // 系统代号
 	timeZone := GetTimeZoneOriginal()              // 时区
 
-	osInfo["OS"] = color.Sprintf("%s %s", osCode, hostData.PlatformVersion) // 操作系统
-	osInfo["Arch"] = hostData.KernelArch                                    // 系统架构
-	osInfo["CurrentKernel"] = hostData.KernelVersion                        // 当前内核版本
-	osInfo["Platform"] = UpperFirstChar(hostData.Platform)                  // 平台
-	osInfo["Hostname"] = hostData.Hostname                                  // 主机名
-	osInfo["TimeZone"] = timeZone                                           // 时区
+	osInfo.OS = color.Sprintf("%s %s", osCode, hostData.PlatformVersion)
+	osInfo.Arch = hostData.KernelArch
+	osInfo.CurrentKernel = hostData.KernelVersion
+	osInfo.Platform = UpperFirstChar(hostData.Platform)
+	osInfo.Hostname = hostData.Hostname
+	osInfo.TimeZone = timeZone
 
-	return osInfo
+	return osInfo, nil
 }
@@ -13,140 +13,237 @@ package general
 
 import (
 	"bufio"
-	"os"
-
 	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/jaypipes/ghw"
-
-	"github.com/gookit/color"
+	"github.com/shirou/gopsutil/v3/host"
 	"github.com/zcalusic/sysinfo"
 )
 
-var (
-	pciData, _     = ghw.PCI()     // PCI 信息
-	networkData, _ = ghw.Network() // 网络设备信息
-	gpuData, _     = ghw.GPU()     // 显卡信息
-)
+// GPUInfo 显卡信息
+type GPUInfo struct {
+	Address         string `json:"GPUAddress"`         // 显卡地址
+	Driver          string `json:"GPUDriver"`          // 显卡驱动
+	Product         string `json:"GPUProduct"`         // 显卡型号
+	Vendor          string `json:"GPUVendor"`          // 显卡厂商
+	SubsystemVendor string `json:"GPUSubsystemVendor"` // 子系统厂商，即板卡厂商
+	Revision        string `json:"GPURevision"`        // 修订版本
+	BootVGA         bool   `json:"GPUBootVGA"`         // 是否驱动启动显示
+}
+
+// pciDevicesPath sysfs 中 PCI 设备的路径
+var pciDevicesPath = "/sys/bus/pci/devices"
+
+// NicInfo 网卡信息
+type NicInfo struct {
+	Name       string `json:"NicName"`       // 网卡名称
+	MacAddress string `json:"NicMacAddress"` // MAC 地址
+	Driver     string `json:"NicDriver"`     // 网卡驱动
+	Vendor     string `json:"NicVendor"`     // 网卡厂商
+	Product    string `json:"NicProduct"`    // 网卡型号
+	PCIAddress string `json:"NicPCIAddress"` // PCI 地址
+	Speed      string `json:"NicSpeed"`      // 网卡速率
+	Duplex     string `json:"NicDuplex"`     // 工作模式
+	Virtual    bool   `json:"NicVirtual"`    // 是否为虚拟网卡
+
+	State    string   `json:"NicState"`    // 链路状态，例如 'up'、'down'、'unknown'
+	Carrier  *bool    `json:"NicCarrier"`  // 是否有载波，网卡未启用时为 nil
+	MTU      int      `json:"NicMTU"`      // MTU
+	IPv4     []string `json:"NicIPv4"`     // IPv4 地址及前缀长度
+	IPv6     []string `json:"NicIPv6"`     // IPv6 地址及前缀长度
+	Gateways []string `json:"NicGateways"` // 经该网卡的默认网关
+	DNS      []string `json:"NicDNS"`      // DNS 服务器
+	RXBytes  uint64   `json:"NicRXBytes"`  // 接收字节数
+	TXBytes  uint64   `json:"NicTXBytes"`  // 发送字节数
+
+	SSID      string   `json:"NicSSID"`      // 无线网络名称
+	BSSID     string   `json:"NicBSSID"`     // 无线接入点 MAC 地址
+	Frequency int      `json:"NicFrequency"` // 无线频率，单位为 MHz
+	Channel   int      `json:"NicChannel"`   // 无线信道
+	Signal    *int     `json:"NicSignal"`    // 无线信号强度，单位为 dBm
+	Bitrate   *float64 `json:"NicBitrate"`   // 无线发送速率，单位为 Mbit/s
+	RegDomain string   `json:"NicRegDomain"` // 无线管制域
+}
+
+// UpdatablePackageInfo 可更新包信息
+type UpdatablePackageInfo struct {
+	LastCheckTime string               `json:"LastCheckTime"`            // 最后检查时间
+	PackageList   UpdatablePackageList `json:"UpdatablePackageList"`     // 可更新包列表
+	Quantity      int                  `json:"UpdatablePackageQuantity"` // 可更新包数量
+}
+
+// UpdatablePackageList 按来源区分的可更新包列表
+type UpdatablePackageList struct {
+	Arch []string `json:"Arch"` // Arch Linux 官方仓库可更新包
+	Aur  []string `json:"Aur"`  // AUR 可更新包
+}
+
+// UpdateDaemonInfo 更新检测服务信息
+type UpdateDaemonInfo struct {
+	Status string `json:"UpdateCheckDaemonStatus"` // 更新检测服务状态
+}
 
 // GetStorageInfo 获取存储设备信息
 //
 // 返回：
-//   - 存储设备信息
-func GetStorageInfo() map[string]any {
-	storageInfo := make(map[string]any)
-	index := 1 // 排除编号为0的虚拟设备
+//   - 存储设备信息，已排除虚拟设备
+//   - 错误信息
+func GetStorageInfo() ([]StorageInfo, error) {
+	blockData, err := ghw.Block()
+	if err != nil {
+		return nil, err
+	}
+	// PCI 信息只用于补全磁盘厂商，获取失败时不影响其他信息
+	pciData, _ := ghw.PCI()
+	blockReader := newBlockReader()
+
+	var (
+		storageInfo []StorageInfo
+		deniedDisks []string // 无权读取健康数据的磁盘
+	)
 	for _, disk := range blockData.Disks {
-		storageValue := make(map[string]any)
 		if disk.SizeBytes > 0 && disk.DriveType.String() != "virtual" {
-			storageValue["StorageName"] = disk.Name
-			storageValue["StorageDriver"] = disk.StorageController.String()
-			storageValue["StorageVendor"] = func() string {
-				if disk.Vendor == "unknown" {
-					// 检测是否符合 PCI 地址格式
-					pciPattern := "^[0-9A-Fa-f]{4}:[0-9A-Fa-f]{2}:[0-9A-Fa-f]{2}\\.[0-9A-Fa-f]$"
-					diskPciAddress := func() string {
-						if len(strings.Split(disk.BusPath, "-")) < 2 {
-							return ""
+			info := StorageInfo{
+				Name:   disk.Name,
+				Driver: disk.StorageController.String(),
+				Vendor: func() string {
+					if disk.Vendor == "unknown" {
+						// 检测是否符合 PCI 地址格式
+						pciPattern := "^[0-9A-Fa-f]{4}:[0-9A-Fa-f]{2}:[0-9A-Fa-f]{2}\\.[0-9A-Fa-f]$"
+						diskPciAddress := func() string {
+							if len(strings.Split(disk.BusPath, "-")) < 2 {
+								return ""
+							}
+							return strings.Split(disk.BusPath, "-")[1]
+						}()
+						matched, err := regexp.MatchString(pciPattern, diskPciAddress)
+						if err != nil {
+							return "--/--"
+						}
+						if matched && pciData != nil {
+							if device := pciData.GetDevice(diskPciAddress); device != nil {
+								return device.Vendor.Name
+							}
 						}
-						return strings.Split(disk.BusPath, "-")[1]
-					}()
-					matched, err := regexp.MatchString(pciPattern, diskPciAddress)
-					if err != nil {
 						return "--/--"
 					}
-					if matched {
-						return pciData.GetDevice(diskPciAddress).Vendor.Name
-					} else {
-						return "--/--"
+					return disk.Vendor
+				}(),
+				Model:     disk.Model,
+				Type:      disk.DriveType.String(),
+				Removable: disk.IsRemovable,
+				Serial:    disk.SerialNumber,
+				Size:      disk.SizeBytes,
+
+				FSType:     blockReader.mounts[disk.Name].FSType,
+				MountPoint: blockReader.mounts[disk.Name].MountPoint,
+				Partitions: func() []BlockDevice {
+					var partitions []BlockDevice
+					for _, partition := range disk.Partitions {
+						partitions = append(partitions, blockReader.partition(partition))
 					}
-				}
-				return disk.Vendor
-			}()
-			storageValue["StorageModel"] = disk.Model
-			storageValue["StorageType"] = disk.DriveType.String()
-			storageValue["StorageRemovable"] = strconv.FormatBool(disk.IsRemovable)
-			storageValue["StorageSerial"] = disk.SerialNumber
-			storageSize, storageSizeUnit := Human(float64(disk.SizeBytes), "B")
-			storageValue["StorageSize"] = color.Sprintf("%.1f %s", storageSize, storageSizeUnit)
-			storageInfo[color.Sprintf("%d", index)] = storageValue
-			index += 1
+					return partitions
+				}(),
+				Holders: blockReader.holders(disk.Name),
+			}
+
+			// 不支持读取健康数据的磁盘（如 virtio）静默跳过
+			health, err := readDiskHealth(disk.Name)
+			if errors.Is(err, os.ErrPermission) {
+				deniedDisks = append(deniedDisks, disk.Name)
+			}
+			info.Health = health.Status
+			info.Temperature = health.Temperature
+			info.PowerOnHours = health.PowerOnHours
+			info.ReallocatedSectors = health.ReallocatedSectors
+			info.PercentageUsed = health.PercentageUsed
+			info.MediaErrors = health.MediaErrors
+
+			storageInfo = append(storageInfo, info)
 		}
 	}
+	if len(deniedDisks) > 0 {
+		Notify("SMART data of " + strings.Join(deniedDisks, ", ") + " requires root permission")
+	}
 
-	return storageInfo
+	return storageInfo, nil
 }
 
 // GetGPUInfo 获取显卡信息
 //
 // 返回：
-//   - 显卡信息
-func GetGPUInfo() map[string]any {
-	type GPUDataJ2S struct {
-		GPU struct {
-			Cards []struct {
-				Address string `json:"address"`
-				Index   int    `json:"index"`
-				PCI     struct {
-					Driver   string `json:"driver"`
-					Address  string `json:"address"`
-					Revision string `json:"revision"`
-					Vendor   struct {
-						ID   string `json:"id"`
-						NAME string `json:"name"`
-					} `json:"vendor"`
-					Product struct {
-						ID   string `json:"id"`
-						NAME string `json:"name"`
-					} `json:"product"`
-					Subsystem struct {
-						ID   string `json:"id"`
-						NAME string `json:"name"`
-					} `json:"subsystem"`
-					Class struct {
-						ID   string `json:"id"`
-						NAME string `json:"name"`
-					} `json:"class"`
-					Subclass struct {
-						ID   string `json:"id"`
-						NAME string `json:"name"`
-					} `json:"subclass"`
-					ProgrammingInterface struct {
-						ID   string `json:"id"`
-						NAME string `json:"name"`
-					} `json:"programming_interface"`
-				} `json:"pci"`
-			} `json:"cards"`
-		} `json:"gpu"`
-	}
+//   - 显卡信息，每张显卡一个元素，没有显卡时为空
+//   - 错误信息
+func GetGPUInfo() ([]GPUInfo, error) {
+	var gpuInfo []GPUInfo
 
-	// 获取 JSON 类型的显卡信息
-	gpuDataJson := gpuData.JSONString(false)
+	// 没有显卡时 ghw 会输出警告，此处以空结果表示
+	gpuData, err := ghw.GPU(ghw.WithDisableWarnings())
+	if err != nil {
+		return gpuInfo, err
+	}
+	// PCI 信息只用于补全子系统厂商名，获取失败时不影响其他信息
+	pciData, _ := ghw.PCI(ghw.WithDisableWarnings())
 
-	// 解析 JSON
-	var gpuDataJ2S GPUDataJ2S
-	if err := json.Unmarshal([]byte(gpuDataJson), &gpuDataJ2S); err != nil {
-		fileName, lineNo := GetCallerInfo()
-		color.Printf("%s %s %s\n", DangerText(ErrorInfoFlag), SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+	for _, card := range gpuData.GraphicsCards {
+		info := GPUInfo{
+			Address: card.Address,
+			BootVGA: isBootVGA(pciDevicesPath, card.Address),
+		}
+		if device := card.DeviceInfo; device != nil {
+			info.Driver = device.Driver
+			info.Revision = device.Revision
+			if device.Vendor != nil {
+				info.Vendor = device.Vendor.Name
+			}
+			if device.Product != nil {
+				info.Product = device.Product.Name
+			}
+			if device.Subsystem != nil {
+				info.SubsystemVendor = device.Subsystem.VendorID
+				if pciData != nil {
+					if vendor, ok := pciData.Vendors[device.Subsystem.VendorID]; ok && vendor != nil {
+						info.SubsystemVendor = vendor.Name
+					}
+				}
+			}
+		}
+		gpuInfo = append(gpuInfo, info)
 	}
 
-	gpuInfo := make(map[string]any)
-	gpuInfo["GPUDriver"] = gpuDataJ2S.GPU.Cards[0].PCI.Driver
-	gpuInfo["GPUAddress"] = gpuDataJ2S.GPU.Cards[0].PCI.Address
-	gpuInfo["GPUVendor"] = gpuDataJ2S.GPU.Cards[0].PCI.Vendor.NAME
-	gpuInfo["GPUProduct"] = gpuDataJ2S.GPU.Cards[0].PCI.Product.NAME
+	return gpuInfo, nil
+}
 
-	return gpuInfo
+// isBootVGA 检测显卡是否驱动启动显示
+//
+// 参数：
+//   - devicesPath: sysfs 中 PCI 设备的路径
+//   - address: 显卡的 PCI 地址
+//
+// 返回：
+//   - 驱动启动显示返回 true，否则返回 false
+func isBootVGA(devicesPath, address string) bool {
+	bootVGA, err := os.ReadFile(filepath.Join(devicesPath, address, "boot_vga"))
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(bootVGA)) == "1"
 }
 
 // GetNicInfo 获取网卡信息
 //
+// 参数：
+//   - showVirtual: 是否包含虚拟网卡（docker0、veth、wg0 等）
+//
 // 返回：
 //   - 网卡信息
-func GetNicInfo() map[string]any {
+//   - 错误信息
+func GetNicInfo(showVirtual bool) ([]NicInfo, error) {
 	type NICDataJ2S struct {
 		Name       string `json:"name"`
 		MacAddress string `json:"mac_address"`
@@ -159,59 +256,49 @@ func GetNicInfo() map[string]any {
 		Nics []NICDataJ2S `json:"nics"`
 	}
 
+	networkData, err := ghw.Network()
+	if err != nil {
+		return nil, err
+	}
+	// PCI 信息只用于补全网卡驱动、厂商和型号，获取失败时不影响其他信息
+	pciData, _ := ghw.PCI()
+	networkReader := newNetworkReader()
+
 	// 获取 JSON 类型的网络信息
 	networkDataJson := networkData.JSONString(false)
 
 	// 解析 JSON
 	var networkDataJ2S map[string]NetworkDataJ2S
 	if err := json.Unmarshal([]byte(networkDataJson), &networkDataJ2S); err != nil {
-		fileName, lineNo := GetCallerInfo()
-		color.Printf("%s %s %s\n", DangerText(ErrorInfoFlag), SecondaryText("[", fileName, ":", lineNo+1, "]"), err)
+		return nil, err
 	}
 
 	// 访问解析后的数据
-	networkInfo := make(map[string]any)
+	var nicInfo []NicInfo
 	network := networkDataJ2S["network"]
-	index := 1 // 排除编号为0的虚拟网卡
 	for _, nic := range network.Nics {
-		networkValue := make(map[string]any)
-		if !nic.IsVirtual {
-			networkValue["NicName"] = nic.Name
-			if nic.PCIAddress != "" {
-				networkValue["NicPCIAddress"] = nic.PCIAddress
-				networkValue["NicDriver"] = pciData.GetDevice(nic.PCIAddress).Driver
-				networkValue["NicProduct"] = pciData.GetDevice(nic.PCIAddress).Product.Name
-				networkValue["NicVendor"] = pciData.GetDevice(nic.PCIAddress).Vendor.Name
-			} else {
-				networkValue["NicPCIAddress"] = "--/--"
-				networkValue["NicDriver"] = "--/--"
-				networkValue["NicProduct"] = "--/--"
-				networkValue["NicVendor"] = "--/--"
+		if !nic.IsVirtual || showVirtual {
+			nicValue := NicInfo{
+				Name:       nic.Name,
+				MacAddress: nic.MacAddress,
+				PCIAddress: nic.PCIAddress,
+				Speed:      nic.Speed,
+				Duplex:     nic.Duplex,
+				Virtual:    nic.IsVirtual,
 			}
-			networkValue["NicMacAddress"] = func() string {
-				if nic.MacAddress == "" {
-					return "--/--"
-				}
-				return nic.MacAddress
-			}()
-			networkValue["NicSpeed"] = func() string {
-				if nic.Speed == "" {
-					return "--/--"
-				}
-				return nic.Speed
-			}()
-			networkValue["NicDuplex"] = func() string {
-				if nic.Duplex == "" {
-					return "--/--"
+			networkReader.fill(&nicValue)
+			if nic.PCIAddress != "" && pciData != nil {
+				if device := pciData.GetDevice(nic.PCIAddress); device != nil {
+					nicValue.Driver = device.Driver
+					nicValue.Product = device.Product.Name
+					nicValue.Vendor = device.Vendor.Name
 				}
-				return nic.Duplex
-			}()
-			networkInfo[color.Sprintf("%d", index)] = networkValue
-			index += 1
+			}
+			nicInfo = append(nicInfo, nicValue)
 		}
 	}
 
-	return networkInfo
+	return nicInfo, nil
 }
 
 // GetOSInfo 获取系统信息
@@ -221,17 +308,24 @@ func GetNicInfo() map[string]any {
 //
 // 返回：
 //   - 系统信息 (OS Info)
-func GetOSInfo(sysInfo sysinfo.SysInfo) map[string]any {
-	osInfo := make(map[string]any)
-	osInfo["OS"] = UpperFirstChar(sysInfo.OS.Name)         // 操作系统
-	osInfo["Arch"] = sysInfo.OS.Architecture               // 系统架构
-	osInfo["CurrentKernel"] = sysInfo.Kernel.Release       // 当前内核版本
-	osInfo["LatestKernel"] = GetLatestKernelVersion()      // 本地最新内核版本
-	osInfo["Platform"] = UpperFirstChar(sysInfo.OS.Vendor) // 平台
-	osInfo["Hostname"] = hostData.Hostname                 // 主机名
-	osInfo["TimeZone"] = sysInfo.Node.Timezone             // 时区
-
-	return osInfo
+//   - 错误信息
+func GetOSInfo(sysInfo sysinfo.SysInfo) (OSInfo, error) {
+	var osInfo OSInfo
+
+	hostData, err := host.Info()
+	if err != nil {
+		return osInfo, err
+	}
+
+	osInfo.OS = UpperFirstChar(sysInfo.OS.Name)
+	osInfo.Arch = sysInfo.OS.Architecture
+	osInfo.CurrentKernel = sysInfo.Kernel.Release
+	osInfo.LatestKernel = GetLatestKernelVersion()
+	osInfo.Platform = UpperFirstChar(sysInfo.OS.Vendor)
+	osInfo.Hostname = hostData.Hostname
+	osInfo.TimeZone = sysInfo.Node.Timezone
+
+	return osInfo, nil
 }
 
 // GetPackageInfo 获取安装包信息
@@ -239,142 +333,122 @@ func GetOSInfo(sysInfo sysinfo.SysInfo) map[string]any {
 // 返回：
 //   - 安装包信息
 //   - 错误信息
-func GetPackageInfo() (map[string]any, error) {
-	packageInfo := make(map[string]any)
-	packageData, err := GetInstalledPackageData()
-	if err != nil {
-		return nil, err
-	}
-	packageInfo["PackageAsExplicitCount"] = packageData.AsExplicitCount                                                    // 单独指定安装包总数
-	packageInfo["PackageAsDependencyCount"] = packageData.AsDependencyCount                                                // 作为依赖安装包总数
-	packageInfo["PackageTotalCount"] = packageData.PackageTotalCount                                                       // 已安装包总数
-	packageInfo["PackageTotalSize"] = color.Sprintf("%.2f %s", packageData.PackageTotalSize, packageData.PackageTotalUnit) // 已安装包总大小
-
-	return packageInfo, nil
+func GetPackageInfo() (PackageInfo, error) {
+	return GetInstalledPackageData()
 }
 
 // GetUpdatablePackageInfo 读取可更新包信息
 //
 // 参数：
 //   - archFilePath: Arch Linux 官方仓库更新信息记录文件路径
-//   - archDividing: 记录文件中 Arch Linux 官方仓库更新信息的开始符
 //   - aurFilePath: AUR 更新信息记录文件路径
-//   - aurDividing: 记录文件中 AUR 更新信息的开始符
 //   - line: 读取指定行，等于 0 时读取全部行
 //
 // 返回：
 //   - 可更新包信息
 //   - 错误信息
-func GetUpdatablePackageInfo(archFilePath, archDividing string, aurFilePath, aurDividing string, line int) (map[string]any, error) {
-	var lastCheckTime string
-	var archPackageSlice = []string{archDividing}
-	var aurPackageSlice = []string{aurDividing}
+func GetUpdatablePackageInfo(archFilePath, aurFilePath string, line int) (UpdatablePackageInfo, error) {
+	var updateInfo UpdatablePackageInfo
 
 	// Arch Linux 官方仓库可更新包
 	if archFilePath != "" && FileExist(archFilePath) {
 		// 获取文件最后修改时间作为最新更新检查时间
-		lastCheckTime = GetFileModTime(archFilePath)
+		updateInfo.LastCheckTime = GetFileModTime(archFilePath)
 
-		// 打开文件
-		text, err := os.Open(archFilePath)
+		archPackages, err := readRecordLines(archFilePath, line)
 		if err != nil {
-			return nil, err
-		}
-		defer text.Close()
-
-		// 创建一个扫描器对象按行遍历
-		scanner := bufio.NewScanner(text)
-		// 行计数
-		count := 1
-		// 逐行读取，输出指定行
-		for scanner.Scan() {
-			if line == count {
-				archPackageSlice = append(archPackageSlice, scanner.Text())
-				break
-			}
-			archPackageSlice = append(archPackageSlice, scanner.Text())
-			count++
+			return updateInfo, err
 		}
+		updateInfo.PackageList.Arch = archPackages
 	}
-	archPackageSliceLength := len(archPackageSlice) - 1
 
 	// AUR 可更新包
 	if aurFilePath != "" && FileExist(aurFilePath) {
 		// 获取文件最后修改时间作为最新更新检查时间
-		lastCheckTime = GetFileModTime(aurFilePath)
+		updateInfo.LastCheckTime = GetFileModTime(aurFilePath)
 
-		// 打开文件
-		text, err := os.Open(aurFilePath)
+		aurPackages, err := readRecordLines(aurFilePath, line)
 		if err != nil {
-			return nil, err
-		}
-		defer text.Close()
-
-		// 创建一个扫描器对象按行遍历
-		scanner := bufio.NewScanner(text)
-		// 行计数
-		count := 1
-		// 逐行读取，输出指定行
-		for scanner.Scan() {
-			if line == count {
-				aurPackageSlice = append(aurPackageSlice, scanner.Text())
-				break
-			}
-			aurPackageSlice = append(aurPackageSlice, scanner.Text())
-			count++
+			return updateInfo, err
 		}
+		updateInfo.PackageList.Aur = aurPackages
 	}
-	aurPackageSliceLength := len(aurPackageSlice) - 1
-
-	// 获取到 AUR 可更新数量后为可更新包名前加一个空行和 Arch 官方仓库可更新包名作区分
-	aurPackageSlice = func() []string {
-		if len(aurPackageSlice) == 0 {
-			return aurPackageSlice
-		}
-		newAurPackageSlice := make([]string, len(aurPackageSlice)+1)
-		copy(newAurPackageSlice[1:], aurPackageSlice)
-		return newAurPackageSlice
-	}()
 
-	updateInfo := make(map[string]any)
-	updateInfo["LastCheckTime"] = lastCheckTime
-	updateInfo["UpdatablePackageList"] = append(archPackageSlice, aurPackageSlice...)
-	updateInfo["UpdatablePackageQuantity"] = strconv.Itoa(archPackageSliceLength + aurPackageSliceLength)
+	updateInfo.Quantity = len(updateInfo.PackageList.Arch) + len(updateInfo.PackageList.Aur)
 
 	return updateInfo, nil
 }
 
+// readRecordLines 逐行读取更新信息记录文件
+//
+// 参数：
+//   - filePath: 记录文件路径
+//   - line: 读取到指定行为止，等于 0 时读取全部行
+//
+// 返回：
+//   - 读取到的行
+//   - 错误信息
+func readRecordLines(filePath string, line int) ([]string, error) {
+	// 打开文件
+	text, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer text.Close()
+
+	var lines []string
+
+	// 创建一个扫描器对象按行遍历
+	scanner := bufio.NewScanner(text)
+	// 行计数
+	count := 1
+	// 逐行读取，输出指定行
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if line == count {
+			break
+		}
+		count++
+	}
+
+	return lines, scanner.Err()
+}
+
 // GetCheckUpdateDaemonInfo 获取更新检测服务的信息
 //
 // 参数：
 //   - basis: 判断更新检测服务状态的依据
+//   - owner: 服务所属的管理器，user 或 system
 //
 // 返回：
 //   - 更新检测服务的信息
 //   - 错误信息
-func GetCheckUpdateDaemonInfo(basis string, owner string) (map[string]any, error) {
-	manager := "--system"
+func GetCheckUpdateDaemonInfo(basis string, owner string) (UpdateDaemonInfo, error) {
+	manager := "system"
 	if owner == "user" {
-		manager = "--user"
+		manager = "user"
 	}
 
-	daemonInfo := make(map[string]any)
+	var daemonInfo UpdateDaemonInfo
 
-	// 检查更新检测服务是否可用（值为 enabled, disabled 或空字符串）
-	daemonIsEnabledArgs := []string{manager, "is-enabled", basis}
-	updateDaemonIsEnabled, _, _ := RunCommandToBuffer("systemctl", daemonIsEnabledArgs)
+	// 无法查询管理器或单元时视为服务不存在
+	daemonInfo.Status = "not-found"
+	querier, _, err := openUnitQuerier(manager)
+	if err != nil {
+		return daemonInfo, nil
+	}
+	defer querier.Close()
+	unit, err := querier.Unit(basis)
+	if err != nil {
+		return daemonInfo, nil
+	}
 
-	switch updateDaemonIsEnabled {
+	// 检查更新检测服务是否可用（值为 enabled, disabled 或其他），可用时检查其是否处于活动状态
+	switch unit.Enabled {
 	case "enabled":
-		// 检查更新检测服务是否处于活动状态
-		daemonIsActiveArgs := []string{manager, "is-active", basis}
-		updateDaemonIsActive, _, _ := RunCommandToBuffer("systemctl", daemonIsActiveArgs)
-
-		daemonInfo["UpdateCheckDaemonStatus"] = UpperFirstChar(updateDaemonIsActive)
+		daemonInfo.Status = UpperFirstChar(unit.ActiveState)
 	case "disabled":
-		daemonInfo["UpdateCheckDaemonStatus"] = "disabled"
-	default:
-		daemonInfo["UpdateCheckDaemonStatus"] = "not-found"
+		daemonInfo.Status = "disabled"
 	}
 	return daemonInfo, nil
 }
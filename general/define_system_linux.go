@@ -11,34 +11,102 @@ Description: 操作系统相关方法
 package general
 
 import (
+	"bufio"
 	"os"
 	"strings"
 )
 
 const releaseFile = "/etc/os-release"
 
-// GetSystemID 获取系统 ID_LIKE 或 ID
+// systemFamily 系统 ID 对应的系统族，系统族决定使用哪种包管理器后端
+var systemFamily = map[string]string{
+	"arch":         "arch",
+	"debian":       "debian",
+	"ubuntu":       "debian",
+	"fedora":       "rpm",
+	"rhel":         "rpm",
+	"centos":       "rpm",
+	"suse":         "rpm",
+	"opensuse":     "rpm",
+	"sles":         "rpm",
+	"mageia":       "rpm",
+	"openmandriva": "rpm",
+}
+
+// readOSRelease 读取 os-release 文件
+//
+// 参数：
+//   - filePath: os-release 文件路径
 //
 // 返回：
-//   - 系统 ID_LIKE 或 ID
+//   - 键值对，值已去除引号
+//   - 错误信息
+func readOSRelease(filePath string) (map[string]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	release := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, found := strings.Cut(line, "="); found {
+			release[key] = strings.Trim(value, `"'`)
+		}
+	}
+
+	return release, scanner.Err()
+}
+
+// GetSystemID 获取系统 ID
+//
+// 返回：
+//   - 系统 ID
 //   - 错误信息
 func GetSystemID() (string, error) {
-	file, err := os.Open(releaseFile)
+	release, err := readOSRelease(releaseFile)
 	if err != nil {
 		return "", err
 	}
-	defer file.Close()
 
-	var id string
+	return release["ID"], nil
+}
+
+// GetSystemFamily 获取系统族
+//
+// 返回：
+//   - 系统族，'arch'、'debian' 或 'rpm'，未支持的系统为空
+//   - 错误信息
+func GetSystemFamily() (string, error) {
+	release, err := readOSRelease(releaseFile)
+	if err != nil {
+		return "", err
+	}
+
+	return systemFamilyOf(release["ID"], release["ID_LIKE"]), nil
+}
 
-	id = ReadFileKey(releaseFile, "ID_LIKE=")
-	if id != "" {
-		id = strings.TrimPrefix(id, "ID_LIKE=")
-	} else {
-		id = ReadFileKey(releaseFile, "ID=")
-		id = strings.TrimPrefix(id, "ID=")
+// systemFamilyOf 根据 ID 和 ID_LIKE 判断系统族
+//
+//   - 先匹配 ID，再按顺序匹配 ID_LIKE 中以空格分隔的各个 ID，例如 Rocky Linux 的 ID_LIKE 为 'rhel centos fedora'
+//
+// 参数：
+//   - id: os-release 中的 ID
+//   - idLike: os-release 中的 ID_LIKE
+//
+// 返回：
+//   - 系统族，未支持的系统为空
+func systemFamilyOf(id, idLike string) string {
+	for _, token := range append([]string{id}, strings.Fields(idLike)...) {
+		if family, ok := systemFamily[strings.ToLower(token)]; ok {
+			return family
+		}
 	}
-	id = strings.Trim(id, `"`)
 
-	return id, nil
+	return ""
 }
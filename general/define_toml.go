@@ -25,10 +25,24 @@ type Config struct {
 	Genealogy GenealogyConfig `toml:"genealogy"`
 }
 type MainConfig struct {
-	Colorful bool `toml:"colorful"`
-	Cycle    bool `toml:"cycle"`
+	ConfigVersion int    `toml:"config_version"` // 配置文件格式的版本，旧版本生成的配置文件没有该项
+	Colorful      bool   `toml:"colorful"`
+	Cycle         bool   `toml:"cycle"`
+	Interval      int    `toml:"interval"`      // watch 模式的刷新间隔，单位为秒
+	ResourceView  string `toml:"resource_view"` // 内存和 CPU 以主机还是 cgroup 的数值为主，'host' 或 'cgroup'
 }
 
+// CurrentConfigVersion 当前配置文件格式的版本
+//
+//   - 1: 存储数据单位配置项生效，此前的版本忽略这些配置项，总是自动选择单位
+const CurrentConfigVersion = 1
+
+// 内存和 CPU 的主视图
+const (
+	ResourceViewHost   = "host"   // 以主机的数值为主
+	ResourceViewCgroup = "cgroup" // 以当前进程所在 cgroup 的数值为主
+)
+
 type BiosConfig struct {
 	Items []string `toml:"items"`
 }
@@ -36,14 +50,26 @@ type BoardConfig struct {
 	Items []string `toml:"items"`
 }
 type CPUConfig struct {
-	CacheUnit string   `toml:"cache_unit"`
-	Items     []string `toml:"items"`
+	CacheUnit    string   `toml:"cache_unit"`
+	PercentUnit  string   `toml:"percent_unit"`
+	SampleWindow int      `toml:"sample_window"` // 首次计算使用率时两次采样的间隔，单位为毫秒
+	Items        []string `toml:"items"`
+}
+type FilesystemConfig struct {
+	DataUnit    string   `toml:"data_unit"`
+	PercentUnit string   `toml:"percent_unit"`
+	ShowPseudo  bool     `toml:"show_pseudo"` // 是否显示伪文件系统
+	Include     []string `toml:"include"`     // 包含模式，匹配挂载点、设备或文件系统类型
+	Exclude     []string `toml:"exclude"`     // 排除模式，匹配挂载点、设备或文件系统类型
+	Items       []string `toml:"items"`
 }
 type GPUConfig struct {
 	Items []string `toml:"items"`
 }
 type LoadConfig struct {
-	Items []string `toml:"items"`
+	TopCount   int      `toml:"top_count"`    // 进程排行显示的进程数，为 0 时不抓取
+	TopSortKey string   `toml:"top_sort_key"` // 进程排行的排序依据，'cpu' 或 'rss'
+	Items      []string `toml:"items"`
 }
 type MemoryConfig struct {
 	DataUnit    string   `toml:"data_unit"`
@@ -51,7 +77,9 @@ type MemoryConfig struct {
 	Items       []string `toml:"items"`
 }
 type NicConfig struct {
-	Items []string `toml:"items"`
+	DataUnit    string   `toml:"data_unit"`
+	ShowVirtual bool     `toml:"show_virtual"` // 是否显示虚拟网卡
+	Items       []string `toml:"items"`
 }
 type OSConfig struct {
 	Items []string `toml:"items"`
@@ -60,7 +88,8 @@ type ProductConfig struct {
 	Items []string `toml:"items"`
 }
 type StorageConfig struct {
-	Items []string `toml:"items"`
+	DataUnit string   `toml:"data_unit"`
+	Items    []string `toml:"items"`
 }
 type SwapConfig struct {
 	DataUnit    string          `toml:"data_unit"`
@@ -72,7 +101,8 @@ type SwapItemsConfig struct {
 	Unavailable []string `toml:"unavailable"`
 }
 type TimeConfig struct {
-	Items []string `toml:"items"`
+	BlameCount int      `toml:"blame_count"` // 启动用时排行显示的单元数，为 0 时不抓取
+	Items      []string `toml:"items"`
 }
 type UserConfig struct {
 	Items []string `toml:"items"`
@@ -12,28 +12,31 @@ Description: 操作 TOML 配置文件
 package general
 
 type GenealogyConfig struct {
-	Bios    BiosConfig    `toml:"bios"`
-	Board   BoardConfig   `toml:"board"`
-	CPU     CPUConfig     `toml:"cpu"`
-	GPU     GPUConfig     `toml:"gpu"`
-	Load    LoadConfig    `toml:"load"`
-	Memory  MemoryConfig  `toml:"memory"`
-	Nic     NicConfig     `toml:"nic"`
-	OS      OSConfig      `toml:"os"`
-	Product ProductConfig `toml:"product"`
-	Storage StorageConfig `toml:"storage"`
-	Swap    SwapConfig    `toml:"swap"`
-	Time    TimeConfig    `toml:"time"`
-	User    UserConfig    `toml:"user"`
+	Bios       BiosConfig       `toml:"bios"`
+	Board      BoardConfig      `toml:"board"`
+	CPU        CPUConfig        `toml:"cpu"`
+	Filesystem FilesystemConfig `toml:"filesystem"`
+	GPU        GPUConfig        `toml:"gpu"`
+	Load       LoadConfig       `toml:"load"`
+	Memory     MemoryConfig     `toml:"memory"`
+	Nic        NicConfig        `toml:"nic"`
+	OS         OSConfig         `toml:"os"`
+	Product    ProductConfig    `toml:"product"`
+	Storage    StorageConfig    `toml:"storage"`
+	Swap       SwapConfig       `toml:"swap"`
+	Time       TimeConfig       `toml:"time"`
+	User       UserConfig       `toml:"user"`
 }
 
 // 配置项
 var (
 	// 允许用户修改的配置项
 	// 使用默认值的配置项
-	colorful  = true
-	cycle     = true
-	biosItems = []string{
+	colorful     = true
+	cycle        = true
+	interval     = 2
+	resourceView = ResourceViewHost
+	biosItems    = []string{
 		"BIOSVendor",
 		"BIOSVersion",
 		"BIOSDate",
@@ -49,9 +52,32 @@ var (
 		"CPUCores",
 		"CPUThreads",
 		"CPUCache",
+		"CPUUsage",
+		"CPUUsageUser",
+		"CPUUsageSystem",
+		"CPUUsageIowait",
+		"CPUUsageSteal",
+		"CPUCoreUsage",
 	}
-	cpuCacheUnit = "KB"
-	gpuItems     = []string{
+	cpuCacheUnit    = DataUnitAuto
+	cpuPercentUnit  = PercentUnitPercent
+	cpuSampleWindow = 500
+	filesystemItems = []string{
+		"FilesystemMountPoint",
+		"FilesystemDevice",
+		"FilesystemType",
+		"FilesystemSize",
+		"FilesystemUsed",
+		"FilesystemAvail",
+		"FilesystemUsedPercent",
+		"FilesystemInodeUsedPercent",
+	}
+	filesystemDataUnit    = DataUnitAuto
+	filesystemPercentUnit = PercentUnitPercent
+	filesystemShowPseudo  = false
+	filesystemInclude     = []string{}
+	filesystemExclude     = []string{"/dev/loop*"}
+	gpuItems              = []string{
 		"GPUAddress",
 		"GPUDriver",
 		"GPUProduct",
@@ -62,8 +88,11 @@ var (
 		"Load5",
 		"Load15",
 		"Process",
+		"TopProcesses",
 	}
-	memoryItems = []string{
+	loadTopCount   = 5
+	loadTopSortKey = "cpu"
+	memoryItems    = []string{
 		"MemoryUsedPercent",
 		"MemoryTotal",
 		"MemoryUsed",
@@ -72,8 +101,8 @@ var (
 		"MemoryBuffCache",
 		"MemoryShared",
 	}
-	memoryDataUnit    = "GB"
-	memoryPercentUnit = "%"
+	memoryDataUnit    = DataUnitAuto
+	memoryPercentUnit = PercentUnitPercent
 	nicItems          = []string{
 		"NicName",
 		"NicMacAddress",
@@ -83,8 +112,18 @@ var (
 		"NicPCIAddress",
 		"NicSpeed",
 		"NicDuplex",
+		"NicState",
+		"NicMTU",
+		"NicIPv4",
+		"NicIPv6",
+		"NicGateways",
+		"NicDNS",
+		"NicRXBytes",
+		"NicTXBytes",
 	}
-	osItems = []string{
+	nicDataUnit    = DataUnitAuto
+	nicShowVirtual = false
+	osItems        = []string{
 		"OS",
 		"CurrentKernel",
 		"Platform",
@@ -96,7 +135,8 @@ var (
 		"ProductVendor",
 		"ProductName",
 	}
-	storageItems = []string{
+	storageDataUnit = DataUnitAuto
+	storageItems    = []string{
 		"StorageName",
 		"StorageSize",
 		"StorageType",
@@ -105,6 +145,9 @@ var (
 		"StorageModel",
 		"StorageSerial",
 		"StorageRemovable",
+		"StorageHealth",
+		"StorageTemperature",
+		"StoragePartitions",
 	}
 	swapItemsAvailable = []string{
 		"SwapTotal",
@@ -113,8 +156,9 @@ var (
 	swapItemsUnavailable = []string{
 		"SwapStatus",
 	}
-	swapDataUnit    = "GB"
-	swapPercentUnit = "%"
+	swapDataUnit    = DataUnitAuto
+	swapPercentUnit = PercentUnitPercent
+	timeBlameCount  = 10
 	timeItems       = []string{
 		"StartTime",
 		"Uptime",
@@ -132,8 +176,11 @@ var (
 // 配置
 var appConfig = Config{
 	Main: MainConfig{
-		Colorful: colorful,
-		Cycle:    cycle,
+		ConfigVersion: CurrentConfigVersion,
+		Colorful:      colorful,
+		Cycle:         cycle,
+		Interval:      interval,
+		ResourceView:  resourceView,
 	},
 	Genealogy: GenealogyConfig{
 		Bios: BiosConfig{
@@ -143,14 +190,26 @@ var appConfig = Config{
 			Items: boardItems,
 		},
 		CPU: CPUConfig{
-			CacheUnit: cpuCacheUnit,
-			Items:     cpuItems,
+			CacheUnit:    cpuCacheUnit,
+			PercentUnit:  cpuPercentUnit,
+			SampleWindow: cpuSampleWindow,
+			Items:        cpuItems,
+		},
+		Filesystem: FilesystemConfig{
+			DataUnit:    filesystemDataUnit,
+			PercentUnit: filesystemPercentUnit,
+			ShowPseudo:  filesystemShowPseudo,
+			Include:     filesystemInclude,
+			Exclude:     filesystemExclude,
+			Items:       filesystemItems,
 		},
 		GPU: GPUConfig{
 			Items: gpuItems,
 		},
 		Load: LoadConfig{
-			Items: loadItems,
+			TopCount:   loadTopCount,
+			TopSortKey: loadTopSortKey,
+			Items:      loadItems,
 		},
 		Memory: MemoryConfig{
 			DataUnit:    memoryDataUnit,
@@ -158,7 +217,9 @@ var appConfig = Config{
 			Items:       memoryItems,
 		},
 		Nic: NicConfig{
-			Items: nicItems,
+			DataUnit:    nicDataUnit,
+			ShowVirtual: nicShowVirtual,
+			Items:       nicItems,
 		},
 		OS: OSConfig{
 			Items: osItems,
@@ -167,7 +228,8 @@ var appConfig = Config{
 			Items: productItems,
 		},
 		Storage: StorageConfig{
-			Items: storageItems,
+			DataUnit: storageDataUnit,
+			Items:    storageItems,
 		},
 		Swap: SwapConfig{
 			DataUnit:    swapDataUnit,
@@ -178,7 +240,8 @@ var appConfig = Config{
 			},
 		},
 		Time: TimeConfig{
-			Items: timeItems,
+			BlameCount: timeBlameCount,
+			Items:      timeItems,
 		},
 		User: UserConfig{
 			Items: userItems,
@@ -12,25 +12,48 @@ Description: 操作 TOML 配置文件
 package general
 
 type GenealogyConfig struct {
-	Bios    BiosConfig    `toml:"bios"`
-	Board   BoardConfig   `toml:"board"`
-	CPU     CPUConfig     `toml:"cpu"`
-	GPU     GPUConfig     `toml:"gpu"`
-	Load    LoadConfig    `toml:"load"`
-	Memory  MemoryConfig  `toml:"memory"`
-	Nic     NicConfig     `toml:"nic"`
-	OS      OSConfig      `toml:"os"`
-	Package PackageConfig `toml:"package"`
-	Product ProductConfig `toml:"product"`
-	Storage StorageConfig `toml:"storage"`
-	Swap    SwapConfig    `toml:"swap"`
-	Time    TimeConfig    `toml:"time"`
-	Update  UpdateConfig  `toml:"update"`
-	User    UserConfig    `toml:"user"`
+	Battery    BatteryConfig    `toml:"battery"`
+	Bios       BiosConfig       `toml:"bios"`
+	Board      BoardConfig      `toml:"board"`
+	CPU        CPUConfig        `toml:"cpu"`
+	Filesystem FilesystemConfig `toml:"filesystem"`
+	GPU        GPUConfig        `toml:"gpu"`
+	Load       LoadConfig       `toml:"load"`
+	Memory     MemoryConfig     `toml:"memory"`
+	Nic        NicConfig        `toml:"nic"`
+	OS         OSConfig         `toml:"os"`
+	Package    PackageConfig    `toml:"package"`
+	Product    ProductConfig    `toml:"product"`
+	Sensors    SensorsConfig    `toml:"sensors"`
+	Services   ServicesConfig   `toml:"services"`
+	Storage    StorageConfig    `toml:"storage"`
+	Swap       SwapConfig       `toml:"swap"`
+	Time       TimeConfig       `toml:"time"`
+	Update     UpdateConfig     `toml:"update"`
+	User       UserConfig       `toml:"user"`
+	Virt       VirtConfig       `toml:"virt"`
+}
+type BatteryConfig struct {
+	PercentUnit string             `toml:"percent_unit"`
+	Items       BatteryItemsConfig `toml:"items"`
+}
+type BatteryItemsConfig struct {
+	Available   []string `toml:"available"`
+	Unavailable []string `toml:"unavailable"`
 }
 type PackageConfig struct {
+	DataUnit string   `toml:"data_unit"`
+	Items    []string `toml:"items"`
+}
+type SensorsConfig struct {
 	Items []string `toml:"items"`
 }
+type ServicesConfig struct {
+	Managers    []string `toml:"managers"`
+	SystemUnits []string `toml:"system_units"`
+	UserUnits   []string `toml:"user_units"`
+	Items       []string `toml:"items"`
+}
 type UpdateConfig struct {
 	Basis          string   `toml:"basis"`
 	ArchRecordFile string   `toml:"arch_record_file"`
@@ -39,6 +62,10 @@ type UpdateConfig struct {
 	AurDividing    string   `toml:"aur_dividing"`
 	Items          []string `toml:"items"`
 }
+type VirtConfig struct {
+	DataUnit string   `toml:"data_unit"`
+	Items    []string `toml:"items"`
+}
 
 // 配置项
 var (
@@ -47,9 +74,28 @@ var (
 	ArchUpdateRecordFile = "/tmp/checker-arch.log" // Arch Linux 官方仓库可更新包记录文件
 	AurUpdateRecordFile  = "/tmp/checker-aur.log"  // AUR 可更新包记录文件
 	// 使用默认值的配置项
-	colorful  = true
-	cycle     = true
-	biosItems = []string{
+	colorful              = true
+	cycle                 = true
+	interval              = 2
+	resourceView          = ResourceViewHost
+	batteryItemsAvailable = []string{
+		"BatteryName",
+		"BatteryStatus",
+		"BatteryCapacity",
+		"BatteryEnergyFull",
+		"BatteryEnergyFullDesign",
+		"BatteryHealth",
+		"BatteryCycleCount",
+		"BatteryManufacturer",
+		"BatteryModel",
+		"BatteryACOnline",
+	}
+	batteryItemsUnavailable = []string{
+		"BatteryAvailability",
+		"BatteryACOnline",
+	}
+	batteryPercentUnit = PercentUnitPercent
+	biosItems          = []string{
 		"BIOSVendor",
 		"BIOSVersion",
 		"BIOSDate",
@@ -65,21 +111,62 @@ var (
 		"CPUCores",
 		"CPUThreads",
 		"CPUCache",
+		"CPUUsage",
+		"CPUUsageUser",
+		"CPUUsageSystem",
+		"CPUUsageIowait",
+		"CPUUsageSteal",
+		"CPUCacheL1d",
+		"CPUCacheL1i",
+		"CPUCacheL2",
+		"CPUCacheL3",
+		"CPUGovernor",
+		"CPUMicrocode",
+		"CPUVirtualization",
+		"CPUCoreDetails",
+		"CPUCoreUsage",
+		"CPUCgroupQuota",
 	}
-	cpuCacheUnit = "KB"
-	gpuItems     = []string{
+	cpuCacheUnit    = DataUnitAuto
+	cpuPercentUnit  = PercentUnitPercent
+	cpuSampleWindow = 500
+	filesystemItems = []string{
+		"FilesystemMountPoint",
+		"FilesystemDevice",
+		"FilesystemType",
+		"FilesystemSize",
+		"FilesystemUsed",
+		"FilesystemAvail",
+		"FilesystemUsedPercent",
+		"FilesystemInodeUsedPercent",
+	}
+	filesystemDataUnit    = DataUnitAuto
+	filesystemPercentUnit = PercentUnitPercent
+	filesystemShowPseudo  = false
+	filesystemInclude     = []string{}
+	filesystemExclude     = []string{"/dev/loop*"}
+	gpuItems              = []string{
 		"GPUAddress",
 		"GPUDriver",
 		"GPUProduct",
 		"GPUVendor",
+		"GPUSubsystemVendor",
+		"GPURevision",
+		"GPUBootVGA",
 	}
 	loadItems = []string{
 		"Load1",
 		"Load5",
 		"Load15",
 		"Process",
+		"TopProcesses",
+		"PressureCPU",
+		"PressureMemory",
+		"PressureIO",
 	}
-	memoryItems = []string{
+	loadTopCount   = 5
+	loadTopSortKey = "cpu"
+	memoryItems    = []string{
 		"MemoryUsedPercent",
 		"MemoryTotal",
 		"MemoryUsed",
@@ -87,9 +174,15 @@ var (
 		"MemoryFree",
 		"MemoryBuffCache",
 		"MemoryShared",
+		"MemorySlots",
+		"MemorySlotsPopulated",
+		"MemoryModules",
+		"MemoryOOMKills",
+		"MemoryLastOOMVictim",
+		"MemoryCgroupLimit",
 	}
-	memoryDataUnit    = "GB"
-	memoryPercentUnit = "%"
+	memoryDataUnit    = DataUnitAuto
+	memoryPercentUnit = PercentUnitPercent
 	nicItems          = []string{
 		"NicName",
 		"NicMacAddress",
@@ -99,8 +192,18 @@ var (
 		"NicPCIAddress",
 		"NicSpeed",
 		"NicDuplex",
+		"NicState",
+		"NicMTU",
+		"NicIPv4",
+		"NicIPv6",
+		"NicGateways",
+		"NicDNS",
+		"NicRXBytes",
+		"NicTXBytes",
 	}
-	osItems = []string{
+	nicDataUnit    = DataUnitAuto
+	nicShowVirtual = false
+	osItems        = []string{
 		"OS",
 		"CurrentKernel",
 		"LatestKernel",
@@ -109,7 +212,8 @@ var (
 		"TimeZone",
 		"Hostname",
 	}
-	packageItems = []string{
+	packageDataUnit = DataUnitAuto
+	packageItems    = []string{
 		"PackageAsExplicitCount",
 		"PackageAsDependencyCount",
 		"PackageTotalCount",
@@ -119,7 +223,22 @@ var (
 		"ProductVendor",
 		"ProductName",
 	}
-	storageItems = []string{
+	sensorsItems = []string{
+		"SensorChip",
+		"SensorDevice",
+		"SensorReadings",
+	}
+	servicesManagers    = []string{"system", "user"}
+	servicesSystemUnits = []string{"sshd.service", "NetworkManager.service"}
+	servicesUserUnits   = []string{UpdateBasis}
+	servicesItems       = []string{
+		"ServiceManager",
+		"ServiceSystemState",
+		"ServiceFailedUnits",
+		"ServiceUnits",
+	}
+	storageDataUnit = DataUnitAuto
+	storageItems    = []string{
 		"StorageName",
 		"StorageSize",
 		"StorageType",
@@ -128,6 +247,9 @@ var (
 		"StorageModel",
 		"StorageSerial",
 		"StorageRemovable",
+		"StorageHealth",
+		"StorageTemperature",
+		"StoragePartitions",
 	}
 	swapItemsAvailable = []string{
 		"SwapTotal",
@@ -136,12 +258,20 @@ var (
 	swapItemsUnavailable = []string{
 		"SwapStatus",
 	}
-	swapDataUnit    = "GB"
-	swapPercentUnit = "%"
+	swapDataUnit    = DataUnitAuto
+	swapPercentUnit = PercentUnitPercent
+	timeBlameCount  = 10
 	timeItems       = []string{
 		"StartTime",
 		"Uptime",
 		"BootTime",
+		"BootFirmware",
+		"BootLoader",
+		"BootKernel",
+		"BootInitrd",
+		"BootUserspace",
+		"BootBlame",
+		"BootCriticalChain",
 	}
 	updateItems = []string{
 		"UpdateCheckDaemonStatus",
@@ -158,15 +288,34 @@ var (
 		"UserGid",
 		"UserHomeDir",
 	}
+	virtDataUnit = DataUnitAuto
+	virtItems    = []string{
+		"VirtType",
+		"VirtHypervisor",
+		"VirtContainer",
+		"VirtCgroupMode",
+		"VirtCPUQuota",
+		"VirtMemoryLimit",
+	}
 )
 
 // 配置
 var appConfig = Config{
 	Main: MainConfig{
-		Colorful: colorful,
-		Cycle:    cycle,
+		ConfigVersion: CurrentConfigVersion,
+		Colorful:      colorful,
+		Cycle:         cycle,
+		Interval:      interval,
+		ResourceView:  resourceView,
 	},
 	Genealogy: GenealogyConfig{
+		Battery: BatteryConfig{
+			PercentUnit: batteryPercentUnit,
+			Items: BatteryItemsConfig{
+				Available:   batteryItemsAvailable,
+				Unavailable: batteryItemsUnavailable,
+			},
+		},
 		Bios: BiosConfig{
 			Items: biosItems,
 		},
@@ -174,14 +323,26 @@ var appConfig = Config{
 			Items: boardItems,
 		},
 		CPU: CPUConfig{
-			CacheUnit: cpuCacheUnit,
-			Items:     cpuItems,
+			CacheUnit:    cpuCacheUnit,
+			PercentUnit:  cpuPercentUnit,
+			SampleWindow: cpuSampleWindow,
+			Items:        cpuItems,
+		},
+		Filesystem: FilesystemConfig{
+			DataUnit:    filesystemDataUnit,
+			PercentUnit: filesystemPercentUnit,
+			ShowPseudo:  filesystemShowPseudo,
+			Include:     filesystemInclude,
+			Exclude:     filesystemExclude,
+			Items:       filesystemItems,
 		},
 		GPU: GPUConfig{
 			Items: gpuItems,
 		},
 		Load: LoadConfig{
-			Items: loadItems,
+			TopCount:   loadTopCount,
+			TopSortKey: loadTopSortKey,
+			Items:      loadItems,
 		},
 		Memory: MemoryConfig{
 			DataUnit:    memoryDataUnit,
@@ -189,19 +350,32 @@ var appConfig = Config{
 			Items:       memoryItems,
 		},
 		Nic: NicConfig{
-			Items: nicItems,
+			DataUnit:    nicDataUnit,
+			ShowVirtual: nicShowVirtual,
+			Items:       nicItems,
 		},
 		OS: OSConfig{
 			Items: osItems,
 		},
 		Package: PackageConfig{
-			Items: packageItems,
+			DataUnit: packageDataUnit,
+			Items:    packageItems,
 		},
 		Product: ProductConfig{
 			Items: productItems,
 		},
+		Sensors: SensorsConfig{
+			Items: sensorsItems,
+		},
+		Services: ServicesConfig{
+			Managers:    servicesManagers,
+			SystemUnits: servicesSystemUnits,
+			UserUnits:   servicesUserUnits,
+			Items:       servicesItems,
+		},
 		Storage: StorageConfig{
-			Items: storageItems,
+			DataUnit: storageDataUnit,
+			Items:    storageItems,
 		},
 		Swap: SwapConfig{
 			DataUnit:    swapDataUnit,
@@ -212,7 +386,8 @@ var appConfig = Config{
 			},
 		},
 		Time: TimeConfig{
-			Items: timeItems,
+			BlameCount: timeBlameCount,
+			Items:      timeItems,
 		},
 		Update: UpdateConfig{
 			Basis:          UpdateBasis,
@@ -225,5 +400,9 @@ var appConfig = Config{
 		User: UserConfig{
 			Items: userItems,
 		},
+		Virt: VirtConfig{
+			DataUnit: virtDataUnit,
+			Items:    virtItems,
+		},
 	},
 }
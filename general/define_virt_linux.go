@@ -0,0 +1,194 @@
+//go:build linux
+
+/*
+File: define_virt_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-05 10:42:30
+
+Description: 检测虚拟化和容器环境
+
+- 虚拟机通过 DMI 中的厂商和产品名、/sys/hypervisor 以及 CPUID 的 hypervisor 位（/proc/cpuinfo 的 'hypervisor' 标志）检测
+- 容器通过 'container' 环境变量、/.dockerenv、/run/.containerenv 以及 1 号进程所在的 cgroup 检测
+- cgroup 模式和资源限制见 define_cgroup_linux.go
+*/
+
+package general
+
+import (
+	"os"
+	"strings"
+)
+
+var (
+	dmiIDPath           = "/sys/class/dmi/id"  // DMI 信息
+	sysHypervisorPath   = "/sys/hypervisor"    // 半虚拟化的 hypervisor 信息
+	dockerEnvFile       = "/.dockerenv"        // Docker 容器的标志文件
+	containerEnvFile    = "/run/.containerenv" // Podman 容器的标志文件
+	procInitEnvironFile = "/proc/1/environ"    // 1 号进程的环境变量
+	procInitCgroupFile  = "/proc/1/cgroup"     // 1 号进程所在的 cgroup
+)
+
+// VirtInfo 虚拟化信息
+type VirtInfo struct {
+	Type        string   `json:"VirtType"`                  // 运行环境：'Bare metal'、'Virtual machine' 或 'Container'
+	Hypervisor  string   `json:"VirtHypervisor"`            // 虚拟机的 hypervisor，例如 'KVM'、'VMware'，无法识别时为空
+	Container   string   `json:"VirtContainer"`             // 容器引擎，例如 'Docker'、'Kubernetes'
+	CgroupMode  string   `json:"VirtCgroupMode"`            // cgroup 模式：'v1'、'v2' 或 'hybrid'
+	CPUQuota    *float64 `json:"VirtCPUQuota,omitempty"`    // cgroup 的 CPU 配额，单位为 CPU 数，不限制时为空
+	MemoryLimit *uint64  `json:"VirtMemoryLimit,omitempty"` // cgroup 的内存限制，单位为 Byte，不限制时为空
+}
+
+// dmiHypervisors DMI 中的特征字符串及对应的 hypervisor，按顺序匹配
+var dmiHypervisors = []struct {
+	Pattern    string // 特征字符串
+	Hypervisor string // hypervisor 名
+}{
+	{"KVM", "KVM"},
+	{"Amazon EC2", "Amazon EC2"},
+	{"Google Compute Engine", "Google Compute Engine"},
+	{"QEMU", "QEMU"},
+	{"VMware", "VMware"},
+	{"VMW", "VMware"},
+	{"innotek GmbH", "VirtualBox"},
+	{"VirtualBox", "VirtualBox"},
+	{"Xen", "Xen"},
+	{"Parallels", "Parallels"},
+	{"BHYVE", "bhyve"},
+	{"Bochs", "Bochs"},
+}
+
+// containerEngines 'container' 环境变量的值及对应的容器引擎
+var containerEngines = map[string]string{
+	"docker":         "Docker",
+	"podman":         "Podman",
+	"lxc":            "LXC",
+	"lxc-libvirt":    "LXC",
+	"systemd-nspawn": "systemd-nspawn",
+	"oci":            "OCI",
+}
+
+// cgroupContainerPatterns cgroup 路径中的特征字符串及对应的容器引擎，按顺序匹配，Kubernetes 的 kubepods 另行优先识别
+var cgroupContainerPatterns = []struct {
+	Pattern   string // 特征字符串
+	Container string // 容器引擎
+}{
+	{"libpod", "Podman"},
+	{"docker", "Docker"},
+	{"lxc", "LXC"},
+	{"machine.slice/machine-", "systemd-nspawn"},
+}
+
+// GetVirtInfo 获取虚拟化信息
+//
+// 返回：
+//   - 虚拟化信息
+func GetVirtInfo() VirtInfo {
+	virtInfo := VirtInfo{
+		Hypervisor: detectHypervisor(),
+		Container:  detectContainer(),
+	}
+	switch {
+	case virtInfo.Container != "":
+		virtInfo.Type = "Container"
+	case virtInfo.Hypervisor != "" || hasHypervisorFlag():
+		virtInfo.Type = "Virtual machine"
+	default:
+		virtInfo.Type = "Bare metal"
+	}
+
+	hierarchies := readCgroupHierarchies()
+	virtInfo.CgroupMode = cgroupMode(hierarchies)
+	virtInfo.CPUQuota = readCgroupCPUQuota(hierarchies)
+	virtInfo.MemoryLimit = readCgroupMemoryLimit(hierarchies)
+
+	return virtInfo
+}
+
+// detectHypervisor 通过 DMI 和 /sys/hypervisor 识别 hypervisor
+//
+// 返回：
+//   - hypervisor 名，无法识别时为空
+func detectHypervisor() string {
+	var dmiValues []string
+	for _, file := range []string{"sys_vendor", "product_name", "product_version", "board_vendor", "bios_vendor"} {
+		if value := readSensorValue(dmiIDPath, file); value != "" {
+			dmiValues = append(dmiValues, value)
+		}
+	}
+	dmi := strings.Join(dmiValues, "\n")
+
+	for _, hypervisor := range dmiHypervisors {
+		if strings.Contains(dmi, hypervisor.Pattern) {
+			return hypervisor.Hypervisor
+		}
+	}
+	// Hyper-V 的厂商为 'Microsoft Corporation'，需结合产品名与 Surface 等实体机区分
+	if strings.Contains(dmi, "Microsoft Corporation") && strings.Contains(readSensorValue(dmiIDPath, "product_name"), "Virtual Machine") {
+		return "Hyper-V"
+	}
+	// Xen 半虚拟化客户机没有 DMI
+	if hypervisorType := readSensorValue(sysHypervisorPath, "type"); hypervisorType != "" {
+		if hypervisorType == "xen" {
+			return "Xen"
+		}
+		return hypervisorType
+	}
+
+	return ""
+}
+
+// hasHypervisorFlag 判断 CPUID 的 hypervisor 位是否置位，该位只在虚拟机中置位
+//
+// 返回：
+//   - 置位返回 true，否则返回 false
+func hasHypervisorFlag() bool {
+	return indexOf(strings.Fields(readCPUInfoProperties()["flags"]), "hypervisor") >= 0
+}
+
+// detectContainer 识别容器引擎
+//
+// 返回：
+//   - 容器引擎名，不在容器中时为空
+func detectContainer() string {
+	initCgroup, _ := os.ReadFile(procInitCgroupFile)
+	// Kubernetes 的 Pod 使用 Docker、containerd 等引擎，优先识别
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" || strings.Contains(string(initCgroup), "kubepods") {
+		return "Kubernetes"
+	}
+
+	// systemd-nspawn、Podman、LXC 等为 1 号进程设置 container 环境变量，读取 1 号进程的环境变量需要 root 权限
+	container := os.Getenv("container")
+	if environ, err := os.ReadFile(procInitEnvironFile); err == nil {
+		for _, variable := range strings.Split(string(environ), "\x00") {
+			if value, found := strings.CutPrefix(variable, "container="); found {
+				container = value
+				break
+			}
+		}
+	}
+	if container != "" {
+		if engine, ok := containerEngines[container]; ok {
+			return engine
+		}
+		return container
+	}
+
+	if _, err := os.Stat(dockerEnvFile); err == nil {
+		return "Docker"
+	}
+	if _, err := os.Stat(containerEnvFile); err == nil {
+		return "Podman"
+	}
+	for _, line := range strings.Split(string(initCgroup), "\n") {
+		_, path, _ := strings.Cut(line, ":")
+		_, path, _ = strings.Cut(path, ":")
+		for _, pattern := range cgroupContainerPatterns {
+			if strings.Contains(path, pattern.Pattern) {
+				return pattern.Container
+			}
+		}
+	}
+
+	return ""
+}
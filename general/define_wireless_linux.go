@@ -0,0 +1,446 @@
+//go:build linux
+
+/*
+File: define_wireless_linux.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-08-30 09:35:42
+
+Description: 读取无线网卡的连接信息
+
+- 优先通过 generic netlink 的 nl80211 接口读取 SSID、BSSID、频率、信号强度、速率和管制域
+- netlink 不可用时从 /proc/net/wireless 读取信号强度，从 sysfs 读取管制域
+*/
+
+package general
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+var (
+	procNetWirelessFile = "/proc/net/wireless"                               // 无线网卡统计
+	regdomParameterFile = "/sys/module/cfg80211/parameters/ieee80211_regdom" // cfg80211 的管制域参数
+)
+
+// generic netlink 控制器
+const (
+	genlIDCtrl            = 0x10 // GENL_ID_CTRL
+	ctrlCmdGetFamily      = 3    // CTRL_CMD_GETFAMILY
+	ctrlAttrFamilyID      = 1    // CTRL_ATTR_FAMILY_ID
+	ctrlAttrFamilyName    = 2    // CTRL_ATTR_FAMILY_NAME
+	genlHeaderLen         = 4    // struct genlmsghdr
+	netlinkReceiveTimeout = 1    // 接收超时，单位为秒
+)
+
+// nl80211 命令和属性
+const (
+	nl80211CmdGetInterface  = 5  // NL80211_CMD_GET_INTERFACE
+	nl80211CmdGetStation    = 17 // NL80211_CMD_GET_STATION
+	nl80211CmdGetReg        = 31 // NL80211_CMD_GET_REG
+	nl80211AttrWiphy        = 1  // NL80211_ATTR_WIPHY
+	nl80211AttrIfindex      = 3  // NL80211_ATTR_IFINDEX
+	nl80211AttrMAC          = 6  // NL80211_ATTR_MAC
+	nl80211AttrStaInfo      = 21 // NL80211_ATTR_STA_INFO
+	nl80211AttrRegAlpha2    = 33 // NL80211_ATTR_REG_ALPHA2
+	nl80211AttrWiphyFreq    = 38 // NL80211_ATTR_WIPHY_FREQ
+	nl80211AttrSSID         = 52 // NL80211_ATTR_SSID
+	nl80211StaInfoSignal    = 7  // NL80211_STA_INFO_SIGNAL
+	nl80211StaInfoTxBitrate = 8  // NL80211_STA_INFO_TX_BITRATE
+	nl80211RateBitrate      = 1  // NL80211_RATE_INFO_BITRATE，单位为 100 kbit/s
+	nl80211RateBitrate32    = 5  // NL80211_RATE_INFO_BITRATE32，单位为 100 kbit/s
+)
+
+// wirelessInfo 无线网卡的连接信息
+type wirelessInfo struct {
+	SSID      string   // 网络名称
+	BSSID     string   // 接入点 MAC 地址
+	Frequency int      // 频率，单位为 MHz
+	Signal    *int     // 信号强度，单位为 dBm
+	Bitrate   *float64 // 发送速率，单位为 Mbit/s
+	RegDomain string   // 管制域，例如 'CN'、'US'，'00' 为全球通用
+}
+
+// isWireless 判断网卡是否为无线网卡
+//
+// 参数：
+//   - name: 网卡名
+//
+// 返回：
+//   - 是无线网卡返回 true，否则返回 false
+func isWireless(name string) bool {
+	for _, entry := range []string{"wireless", "phy80211"} {
+		if _, err := os.Stat(filepath.Join(sysClassNetPath, name, entry)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// readWirelessInfo 读取无线网卡的连接信息
+//
+// 参数：
+//   - iface: 无线网卡
+//
+// 返回：
+//   - 连接信息，未连接时只有管制域
+func readWirelessInfo(iface *net.Interface) wirelessInfo {
+	info, err := readNL80211Info(iface.Index)
+	if err != nil {
+		info = wirelessInfo{Signal: readProcWirelessSignal(iface.Name)}
+	}
+	if info.RegDomain == "" {
+		if regdom, err := os.ReadFile(regdomParameterFile); err == nil {
+			info.RegDomain = strings.TrimSpace(string(regdom))
+		}
+	}
+	return info
+}
+
+// readProcWirelessSignal 从 /proc/net/wireless 读取信号强度
+//
+// 参数：
+//   - name: 网卡名
+//
+// 返回：
+//   - 信号强度，单位为 dBm，没有该网卡或未连接时为 nil
+func readProcWirelessSignal(name string) *int {
+	file, err := os.Open(procNetWirelessFile)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	// 前两行为表头，之后每行为 '网卡名: 状态 链路质量 信号强度 噪声 ...'，数值可能以 '.' 结尾
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		iface, stats, found := strings.Cut(scanner.Text(), ":")
+		if !found || strings.TrimSpace(iface) != name {
+			continue
+		}
+		fields := strings.Fields(stats)
+		if len(fields) < 3 {
+			return nil
+		}
+		signal, err := strconv.ParseFloat(strings.TrimSuffix(fields[2], "."), 64)
+		if err != nil || signal == 0 {
+			return nil
+		}
+		level := int(signal)
+		if level > 63 {
+			level -= 256 // 部分驱动以无符号数表示负的 dBm
+		}
+		return &level
+	}
+	return nil
+}
+
+// readNL80211Info 通过 nl80211 读取无线网卡的连接信息
+//
+// 参数：
+//   - ifindex: 网卡序号
+//
+// 返回：
+//   - 连接信息
+//   - 错误信息，内核不支持 generic netlink 或 nl80211 时返回错误
+func readNL80211Info(ifindex int) (wirelessInfo, error) {
+	var info wirelessInfo
+
+	conn, err := dialGenericNetlink()
+	if err != nil {
+		return info, err
+	}
+	defer conn.close()
+
+	familyID, err := conn.resolveFamily("nl80211")
+	if err != nil {
+		return info, err
+	}
+	ifindexAttr := netlinkAttr(nl80211AttrIfindex, binary.NativeEndian.AppendUint32(nil, uint32(ifindex)))
+
+	// 接口信息：SSID、频率和所属的物理设备
+	messages, err := conn.request(familyID, nl80211CmdGetInterface, 0, ifindexAttr)
+	if err != nil {
+		return info, err
+	}
+	wiphy := -1
+	for _, message := range messages {
+		attrs := parseNetlinkAttrs(message)
+		info.SSID = string(attrs[nl80211AttrSSID])
+		if frequency := attrs[nl80211AttrWiphyFreq]; len(frequency) >= 4 {
+			info.Frequency = int(binary.NativeEndian.Uint32(frequency))
+		}
+		if index := attrs[nl80211AttrWiphy]; len(index) >= 4 {
+			wiphy = int(binary.NativeEndian.Uint32(index))
+		}
+	}
+
+	// 站点信息：已连接的接入点、信号强度和发送速率
+	if info.SSID != "" {
+		messages, err := conn.request(familyID, nl80211CmdGetStation, syscall.NLM_F_DUMP, ifindexAttr)
+		if err == nil && len(messages) > 0 {
+			parseStationMessage(messages[0], &info)
+		}
+	}
+
+	// 管制域：优先使用物理设备自身的管制域，没有时为全局管制域
+	readRegDomain := func(attrs []byte) string {
+		messages, err := conn.request(familyID, nl80211CmdGetReg, 0, attrs)
+		if err != nil {
+			return ""
+		}
+		for _, message := range messages {
+			if alpha2 := parseNetlinkAttrs(message)[nl80211AttrRegAlpha2]; len(alpha2) > 0 {
+				return strings.TrimRight(string(alpha2), "\x00")
+			}
+		}
+		return ""
+	}
+	if wiphy >= 0 {
+		info.RegDomain = readRegDomain(netlinkAttr(nl80211AttrWiphy, binary.NativeEndian.AppendUint32(nil, uint32(wiphy))))
+	}
+	if info.RegDomain == "" {
+		info.RegDomain = readRegDomain(nil)
+	}
+
+	return info, nil
+}
+
+// parseStationMessage 解析 NL80211_CMD_GET_STATION 的应答
+//
+//   - 信号强度位于嵌套的 STA_INFO 属性中，发送速率位于 STA_INFO 再嵌套的 TX_BITRATE 属性中
+//
+// 参数：
+//   - message: 去掉 genlmsghdr 后的属性数据
+//   - info: 连接信息，写入 BSSID、信号强度和发送速率
+func parseStationMessage(message []byte, info *wirelessInfo) {
+	attrs := parseNetlinkAttrs(message)
+	if mac := attrs[nl80211AttrMAC]; len(mac) == 6 {
+		info.BSSID = net.HardwareAddr(mac).String()
+	}
+	stationInfo := parseNetlinkAttrs(attrs[nl80211AttrStaInfo])
+	if signal := stationInfo[nl80211StaInfoSignal]; len(signal) >= 1 {
+		level := int(int8(signal[0]))
+		info.Signal = &level
+	}
+	rateInfo := parseNetlinkAttrs(stationInfo[nl80211StaInfoTxBitrate])
+	if bitrate := rateInfo[nl80211RateBitrate32]; len(bitrate) >= 4 {
+		rate := float64(binary.NativeEndian.Uint32(bitrate)) / 10
+		info.Bitrate = &rate
+	} else if bitrate := rateInfo[nl80211RateBitrate]; len(bitrate) >= 2 {
+		rate := float64(binary.NativeEndian.Uint16(bitrate)) / 10
+		info.Bitrate = &rate
+	}
+}
+
+// wirelessChannel 由频率计算信道
+//
+// 参数：
+//   - frequency: 频率，单位为 MHz
+//
+// 返回：
+//   - 信道，无法识别的频率为 0
+func wirelessChannel(frequency int) int {
+	switch {
+	case frequency == 2484:
+		return 14
+	case frequency >= 2412 && frequency < 2484:
+		return (frequency - 2407) / 5
+	case frequency >= 5160 && frequency <= 5885:
+		return (frequency - 5000) / 5
+	case frequency >= 5955 && frequency <= 7115:
+		return (frequency - 5950) / 5
+	case frequency >= 58320 && frequency <= 70200:
+		return (frequency - 56160) / 2160
+	}
+	return 0
+}
+
+// genericNetlink generic netlink 连接
+type genericNetlink struct {
+	fd       int    // 套接字
+	sequence uint32 // 消息序号
+}
+
+// dialGenericNetlink 建立 generic netlink 连接
+//
+// 返回：
+//   - generic netlink 连接
+//   - 错误信息
+func dialGenericNetlink() (*genericNetlink, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC, syscall.NETLINK_GENERIC)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	timeout := syscall.Timeval{Sec: netlinkReceiveTimeout}
+	if err := syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &timeout); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	return &genericNetlink{fd: fd}, nil
+}
+
+// close 关闭连接
+func (conn *genericNetlink) close() {
+	syscall.Close(conn.fd)
+}
+
+// resolveFamily 查询 generic netlink 协议族的 ID
+//
+// 参数：
+//   - name: 协议族名称
+//
+// 返回：
+//   - 协议族 ID
+//   - 错误信息
+func (conn *genericNetlink) resolveFamily(name string) (uint16, error) {
+	messages, err := conn.request(genlIDCtrl, ctrlCmdGetFamily, 0, netlinkAttr(ctrlAttrFamilyName, append([]byte(name), 0)))
+	if err != nil {
+		return 0, err
+	}
+	for _, message := range messages {
+		if id := parseNetlinkAttrs(message)[ctrlAttrFamilyID]; len(id) >= 2 {
+			return binary.NativeEndian.Uint16(id), nil
+		}
+	}
+	return 0, errors.New("generic netlink family " + name + " not found")
+}
+
+// request 发送请求并接收全部应答
+//
+// 参数：
+//   - family: 协议族 ID
+//   - command: 命令
+//   - flags: 附加的 netlink 标志，例如 NLM_F_DUMP
+//   - attrs: 已编码的属性
+//
+// 返回：
+//   - 各应答消息去掉 genlmsghdr 后的属性数据
+//   - 错误信息
+func (conn *genericNetlink) request(family uint16, command uint8, flags uint16, attrs []byte) ([][]byte, error) {
+	conn.sequence++
+
+	length := syscall.NLMSG_HDRLEN + genlHeaderLen + len(attrs)
+	message := make([]byte, 0, length)
+	message = binary.NativeEndian.AppendUint32(message, uint32(length))
+	message = binary.NativeEndian.AppendUint16(message, family)
+	message = binary.NativeEndian.AppendUint16(message, syscall.NLM_F_REQUEST|flags)
+	message = binary.NativeEndian.AppendUint32(message, conn.sequence)
+	message = binary.NativeEndian.AppendUint32(message, 0)
+	message = append(message, command, 1, 0, 0) // 命令、版本和保留字段
+	message = append(message, attrs...)
+
+	if err := syscall.Sendto(conn.fd, message, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return nil, err
+	}
+
+	return receiveNetlinkReplies(conn.sequence, func(buffer []byte) (int, error) {
+		n, _, err := syscall.Recvfrom(conn.fd, buffer, 0)
+		return n, err
+	})
+}
+
+// receiveNetlinkReplies 接收请求的全部应答
+//
+//   - dump 请求的应答可能分布在多个数据报中，接收缓冲区在每次接收时被覆盖，应答数据须复制后保存
+//
+// 参数：
+//   - sequence: 请求的消息序号
+//   - receive: 接收一个数据报的函数，返回写入缓冲区的长度
+//
+// 返回：
+//   - 各应答消息去掉 genlmsghdr 后的属性数据
+//   - 错误信息
+func receiveNetlinkReplies(sequence uint32, receive func(buffer []byte) (int, error)) ([][]byte, error) {
+	var replies [][]byte
+	buffer := make([]byte, 32*1024)
+	for {
+		n, err := receive(buffer)
+		if err != nil {
+			return nil, err
+		}
+		messages, err := syscall.ParseNetlinkMessage(buffer[:n])
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range messages {
+			if message.Header.Seq != sequence {
+				continue
+			}
+			switch message.Header.Type {
+			case syscall.NLMSG_DONE:
+				return replies, nil
+			case syscall.NLMSG_ERROR:
+				if len(message.Data) >= 4 {
+					if errno := int32(binary.NativeEndian.Uint32(message.Data)); errno != 0 {
+						return nil, syscall.Errno(-errno)
+					}
+				}
+				return replies, nil
+			}
+			if len(message.Data) >= genlHeaderLen {
+				replies = append(replies, append([]byte(nil), message.Data[genlHeaderLen:]...))
+			}
+			// 非 dump 请求只有一条应答
+			if message.Header.Flags&syscall.NLM_F_MULTI == 0 {
+				return replies, nil
+			}
+		}
+	}
+}
+
+// netlinkAttr 编码一个 netlink 属性，按 4 字节对齐
+//
+// 参数：
+//   - attrType: 属性类型
+//   - value: 属性值
+//
+// 返回：
+//   - 编码后的属性
+func netlinkAttr(attrType uint16, value []byte) []byte {
+	length := syscall.SizeofRtAttr + len(value)
+	attr := binary.NativeEndian.AppendUint16(nil, uint16(length))
+	attr = binary.NativeEndian.AppendUint16(attr, attrType)
+	attr = append(attr, value...)
+	for len(attr)%syscall.NLMSG_ALIGNTO != 0 {
+		attr = append(attr, 0)
+	}
+	return attr
+}
+
+// parseNetlinkAttrs 解析 netlink 属性
+//
+// 参数：
+//   - data: 属性数据
+//
+// 返回：
+//   - 属性值，键为属性类型（已去掉嵌套和字节序标志）
+func parseNetlinkAttrs(data []byte) map[uint16][]byte {
+	attrs := make(map[uint16][]byte)
+	for len(data) >= syscall.SizeofRtAttr {
+		length := int(binary.NativeEndian.Uint16(data[0:2]))
+		attrType := binary.NativeEndian.Uint16(data[2:4]) & 0x3FFF // NLA_TYPE_MASK
+		if length < syscall.SizeofRtAttr || length > len(data) {
+			break
+		}
+		attrs[attrType] = data[syscall.SizeofRtAttr:length]
+		aligned := (length + syscall.NLMSG_ALIGNTO - 1) &^ (syscall.NLMSG_ALIGNTO - 1)
+		if aligned > len(data) {
+			break
+		}
+		data = data[aligned:]
+	}
+	return attrs
+}
@@ -0,0 +1,361 @@
+//go:build linux
+
+/*
+File: define_wireless_linux_test.go
+Author: YJ
+Email: yj1516268@outlook.com
+Created Time: 2024-09-11 09:48:35
+
+Description: 测试 netlink 属性和无线网卡信息的解析
+*/
+
+package general
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+// nlaFNested NLA_F_NESTED 标志
+const nlaFNested = 0x8000
+
+// rawNetlinkAttr 编码一个不补齐的 netlink 属性
+//
+// 参数：
+//   - length: 写入头部的长度
+//   - attrType: 属性类型
+//   - value: 属性值
+//
+// 返回：
+//   - 编码后的属性
+func rawNetlinkAttr(length, attrType uint16, value []byte) []byte {
+	attr := binary.NativeEndian.AppendUint16(nil, length)
+	attr = binary.NativeEndian.AppendUint16(attr, attrType)
+	return append(attr, value...)
+}
+
+func TestParseNetlinkAttrs(t *testing.T) {
+	join := func(parts ...[]byte) []byte {
+		return bytes.Join(parts, nil)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want map[uint16][]byte
+	}{
+		{
+			name: "empty",
+			data: nil,
+			want: map[uint16][]byte{},
+		},
+		{
+			name: "aligned attributes",
+			data: join(netlinkAttr(1, []byte{1, 0, 0, 0}), netlinkAttr(2, []byte("wlan0\x00\x00\x00"))),
+			want: map[uint16][]byte{1: {1, 0, 0, 0}, 2: []byte("wlan0\x00\x00\x00")},
+		},
+		{
+			// 长度不是 4 的倍数的属性之后有填充，下一个属性从对齐位置开始
+			name: "padded attribute",
+			data: join(netlinkAttr(7, []byte{0xCC}), netlinkAttr(52, []byte("home"))),
+			want: map[uint16][]byte{7: {0xCC}, 52: []byte("home")},
+		},
+		{
+			// 最后一个属性省略了填充
+			name: "unpadded last attribute",
+			data: join(netlinkAttr(1, []byte{1, 2, 3, 4}), rawNetlinkAttr(6, 2, []byte{5, 6})),
+			want: map[uint16][]byte{1: {1, 2, 3, 4}, 2: {5, 6}},
+		},
+		{
+			// 声明的长度超出剩余数据，停止解析并保留之前的属性
+			name: "truncated attribute",
+			data: join(netlinkAttr(1, []byte{1, 2, 3, 4}), rawNetlinkAttr(12, 2, []byte{5, 6})),
+			want: map[uint16][]byte{1: {1, 2, 3, 4}},
+		},
+		{
+			name: "length shorter than header",
+			data: join(netlinkAttr(1, []byte{1, 2, 3, 4}), rawNetlinkAttr(2, 2, nil), netlinkAttr(3, []byte{7, 8, 9, 10})),
+			want: map[uint16][]byte{1: {1, 2, 3, 4}},
+		},
+		{
+			name: "trailing bytes shorter than header",
+			data: join(netlinkAttr(1, []byte{1, 2, 3, 4}), []byte{8, 0}),
+			want: map[uint16][]byte{1: {1, 2, 3, 4}},
+		},
+		{
+			name: "nested and byte order flags",
+			data: join(netlinkAttr(21|nlaFNested, netlinkAttr(7, []byte{0xCC})), netlinkAttr(3|0x4000, []byte{1, 0, 0, 0})),
+			want: map[uint16][]byte{21: netlinkAttr(7, []byte{0xCC}), 3: {1, 0, 0, 0}},
+		},
+	}
+	for _, tt := range tests {
+		got := parseNetlinkAttrs(tt.data)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %d attributes, want %d: %v", tt.name, len(got), len(tt.want), got)
+			continue
+		}
+		for attrType, value := range tt.want {
+			if !bytes.Equal(got[attrType], value) {
+				t.Errorf("%s: attribute %d = %v, want %v", tt.name, attrType, got[attrType], value)
+			}
+		}
+	}
+}
+
+// rawNetlinkMessage 编码一条 netlink 消息，数据按 4 字节对齐
+//
+// 参数：
+//   - msgType: 消息类型
+//   - flags: 消息标志
+//   - sequence: 消息序号
+//   - data: 消息数据
+//
+// 返回：
+//   - 编码后的消息
+func rawNetlinkMessage(msgType, flags uint16, sequence uint32, data []byte) []byte {
+	message := binary.NativeEndian.AppendUint32(nil, uint32(syscall.NLMSG_HDRLEN+len(data)))
+	message = binary.NativeEndian.AppendUint16(message, msgType)
+	message = binary.NativeEndian.AppendUint16(message, flags)
+	message = binary.NativeEndian.AppendUint32(message, sequence)
+	message = binary.NativeEndian.AppendUint32(message, 0)
+	message = append(message, data...)
+	for len(message)%syscall.NLMSG_ALIGNTO != 0 {
+		message = append(message, 0)
+	}
+	return message
+}
+
+// fakeNetlinkReceiver 依次返回给定数据报的接收函数
+//
+// 参数：
+//   - t: 测试对象
+//   - datagrams: 数据报
+//
+// 返回：
+//   - 接收函数，数据报用完时返回 EAGAIN
+//   - 已接收的数据报数
+func fakeNetlinkReceiver(t *testing.T, datagrams ...[]byte) (func(buffer []byte) (int, error), *int) {
+	received := 0
+	return func(buffer []byte) (int, error) {
+		if received >= len(datagrams) {
+			t.Errorf("receive called after all %d datagrams were consumed", len(datagrams))
+			return 0, syscall.EAGAIN
+		}
+		n := copy(buffer, datagrams[received])
+		received++
+		return n, nil
+	}, &received
+}
+
+func TestReceiveNetlinkReplies(t *testing.T) {
+	const sequence = 5
+	genlHeader := []byte{nl80211CmdGetStation, 1, 0, 0}
+	// 两个接入点的应答长度相同，内容不同，共用缓冲区时前一条会被后一条覆盖
+	first := netlinkAttr(nl80211AttrMAC, []byte{0x00, 0x11, 0x22, 0xAA, 0xBB, 0xCC})
+	second := netlinkAttr(nl80211AttrMAC, []byte{0x00, 0x11, 0x22, 0xDD, 0xEE, 0xFF})
+	multi := uint16(syscall.NLM_F_MULTI)
+
+	receive, received := fakeNetlinkReceiver(t,
+		bytes.Join([][]byte{
+			rawNetlinkMessage(genlIDCtrl+1, multi, sequence, append(genlHeader, first...)),
+			rawNetlinkMessage(genlIDCtrl+1, multi, sequence-1, append(genlHeader, second...)), // 其他请求的应答
+		}, nil),
+		rawNetlinkMessage(genlIDCtrl+1, multi, sequence, append(genlHeader, second...)),
+		rawNetlinkMessage(syscall.NLMSG_DONE, multi, sequence, make([]byte, 4)),
+	)
+	replies, err := receiveNetlinkReplies(sequence, receive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replies) != 2 || !bytes.Equal(replies[0], first) || !bytes.Equal(replies[1], second) {
+		t.Errorf("replies = %q, want %q and %q", replies, first, second)
+	}
+	if *received != 3 {
+		t.Errorf("received %d datagrams, want 3", *received)
+	}
+
+	// 非 dump 请求只有一条应答，不再继续接收
+	receive, received = fakeNetlinkReceiver(t, rawNetlinkMessage(genlIDCtrl, 0, sequence, append(genlHeader, first...)))
+	if replies, err := receiveNetlinkReplies(sequence, receive); err != nil || len(replies) != 1 || *received != 1 {
+		t.Errorf("single reply: got %q, %v after %d datagrams", replies, err, *received)
+	}
+
+	// 内核返回错误
+	errno := -int32(syscall.ENODEV)
+	errorMessage := binary.NativeEndian.AppendUint32(nil, uint32(errno))
+	receive, _ = fakeNetlinkReceiver(t, rawNetlinkMessage(syscall.NLMSG_ERROR, 0, sequence, append(errorMessage, make([]byte, syscall.NLMSG_HDRLEN)...)))
+	if _, err := receiveNetlinkReplies(sequence, receive); !errors.Is(err, syscall.ENODEV) {
+		t.Errorf("error reply: err = %v, want ENODEV", err)
+	}
+
+	// 确认消息（错误码为 0）结束接收
+	receive, _ = fakeNetlinkReceiver(t, rawNetlinkMessage(syscall.NLMSG_ERROR, 0, sequence, make([]byte, 4+syscall.NLMSG_HDRLEN)))
+	if replies, err := receiveNetlinkReplies(sequence, receive); err != nil || replies != nil {
+		t.Errorf("ack: got %q, %v, want no replies", replies, err)
+	}
+
+	// 接收失败
+	failing := func(buffer []byte) (int, error) { return 0, syscall.EINTR }
+	if _, err := receiveNetlinkReplies(sequence, failing); !errors.Is(err, syscall.EINTR) {
+		t.Errorf("receive failure: err = %v, want EINTR", err)
+	}
+}
+
+func TestParseStationMessage(t *testing.T) {
+	mac := []byte{0x00, 0x11, 0x22, 0xAA, 0xBB, 0xCC}
+	signal := []byte{byte(0xCC)} // -52 dBm
+	uint16Value := func(value uint16) []byte { return binary.NativeEndian.AppendUint16(nil, value) }
+	uint32Value := func(value uint32) []byte { return binary.NativeEndian.AppendUint32(nil, value) }
+
+	// station 构造 STA_INFO 嵌套属性，其中再嵌套 TX_BITRATE
+	station := func(rateAttrs ...[]byte) []byte {
+		staInfo := netlinkAttr(nl80211StaInfoSignal, signal)
+		if len(rateAttrs) > 0 {
+			staInfo = append(staInfo, netlinkAttr(nl80211StaInfoTxBitrate|nlaFNested, bytes.Join(rateAttrs, nil))...)
+		}
+		return append(netlinkAttr(nl80211AttrMAC, mac), netlinkAttr(nl80211AttrStaInfo|nlaFNested, staInfo)...)
+	}
+
+	// TX_BITRATE 头部声明 16 字节，实际只有 6 字节
+	truncatedStaInfo := append(netlinkAttr(nl80211StaInfoSignal, signal), rawNetlinkAttr(16, nl80211StaInfoTxBitrate, []byte{8, 0, nl80211RateBitrate32, 0, 0x23, 0x1D})...)
+	truncatedStation := append(netlinkAttr(nl80211AttrMAC, mac), netlinkAttr(nl80211AttrStaInfo|nlaFNested, truncatedStaInfo)...)
+
+	tests := []struct {
+		name    string
+		message []byte
+		bssid   string
+		signal  int
+		bitrate float64 // 0 表示没有速率
+	}{
+		{
+			name:    "32-bit bitrate preferred",
+			message: station(netlinkAttr(nl80211RateBitrate, uint16Value(1300)), netlinkAttr(nl80211RateBitrate32, uint32Value(8667))),
+			bssid:   "00:11:22:aa:bb:cc",
+			signal:  -52,
+			bitrate: 866.7,
+		},
+		{
+			name:    "16-bit bitrate",
+			message: station(netlinkAttr(nl80211RateBitrate, uint16Value(540))),
+			bssid:   "00:11:22:aa:bb:cc",
+			signal:  -52,
+			bitrate: 54,
+		},
+		{
+			name:    "no bitrate",
+			message: station(),
+			bssid:   "00:11:22:aa:bb:cc",
+			signal:  -52,
+		},
+		{
+			// TX_BITRATE 声明的长度超出 STA_INFO，已解析的信号强度仍然有效
+			name:    "truncated nested bitrate",
+			message: truncatedStation,
+			bssid:   "00:11:22:aa:bb:cc",
+			signal:  -52,
+		},
+	}
+	for _, tt := range tests {
+		var info wirelessInfo
+		parseStationMessage(tt.message, &info)
+		if info.BSSID != tt.bssid {
+			t.Errorf("%s: BSSID = %q, want %q", tt.name, info.BSSID, tt.bssid)
+		}
+		if info.Signal == nil || *info.Signal != tt.signal {
+			t.Errorf("%s: Signal = %v, want %d", tt.name, info.Signal, tt.signal)
+		}
+		switch {
+		case tt.bitrate == 0 && info.Bitrate != nil:
+			t.Errorf("%s: Bitrate = %v, want nil", tt.name, *info.Bitrate)
+		case tt.bitrate != 0 && (info.Bitrate == nil || *info.Bitrate != tt.bitrate):
+			t.Errorf("%s: Bitrate = %v, want %v", tt.name, info.Bitrate, tt.bitrate)
+		}
+	}
+
+	var info wirelessInfo
+	parseStationMessage(nil, &info)
+	if info.BSSID != "" || info.Signal != nil || info.Bitrate != nil {
+		t.Errorf("empty message: got %+v, want zero value", info)
+	}
+}
+
+func TestWirelessChannel(t *testing.T) {
+	tests := []struct {
+		frequency int
+		want      int
+	}{
+		{2412, 1},
+		{2437, 6},
+		{2472, 13},
+		{2484, 14},
+		{5180, 36},
+		{5500, 100},
+		{5825, 165},
+		{5955, 1},
+		{6115, 33},
+		{7115, 233},
+		{58320, 1},
+		{60480, 2},
+		{0, 0},
+		{2400, 0},
+		{5000, 0},
+		{9000, 0},
+	}
+	for _, tt := range tests {
+		if got := wirelessChannel(tt.frequency); got != tt.want {
+			t.Errorf("wirelessChannel(%d) = %d, want %d", tt.frequency, got, tt.want)
+		}
+	}
+}
+
+func TestReadProcWirelessSignal(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "wireless")
+	content := "Inter-| sta-|   Quality        |   Discarded packets               | Missed | WE\n" +
+		" face | tus | link level noise |  nwid  crypt   frag  retry   misc | beacon | 22\n" +
+		"wlp2s0: 0000   58.  -52.  -256        0      0      0      0     12        0\n" +
+		" wlan1: 0000   40   200   0           0      0      0      0      0        0\n" +
+		" wlan2: 0000    0     0   0           0      0      0      0      0        0\n" +
+		" wlan3: 0000   40\n"
+	if err := os.WriteFile(fixture, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	oldFile := procNetWirelessFile
+	procNetWirelessFile = fixture
+	t.Cleanup(func() { procNetWirelessFile = oldFile })
+
+	tests := []struct {
+		name string
+		want *int
+	}{
+		{"wlp2s0", intPointer(-52)},
+		{"wlan1", intPointer(-56)}, // 无符号表示的负数
+		{"wlan2", nil},             // 未连接
+		{"wlan3", nil},             // 字段不足
+		{"wlan9", nil},             // 没有该网卡
+		{"face", nil},              // 表头
+	}
+	for _, tt := range tests {
+		got := readProcWirelessSignal(tt.name)
+		switch {
+		case tt.want == nil && got != nil:
+			t.Errorf("readProcWirelessSignal(%q) = %d, want nil", tt.name, *got)
+		case tt.want != nil && (got == nil || *got != *tt.want):
+			t.Errorf("readProcWirelessSignal(%q) = %v, want %d", tt.name, got, *tt.want)
+		}
+	}
+
+	procNetWirelessFile = filepath.Join(t.TempDir(), "missing")
+	if got := readProcWirelessSignal("wlp2s0"); got != nil {
+		t.Errorf("missing file: got %d, want nil", *got)
+	}
+}
+
+// intPointer 返回整数指针
+func intPointer(value int) *int {
+	return &value
+}